@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -12,8 +16,11 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
 
+	"github.com/yunomu/kansousen/lib/jwt"
 	"github.com/yunomu/kansousen/lib/lambda/lambdagateway"
 	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+
+	"github.com/yunomu/kansousen/api/kifu/route"
 )
 
 var logger *zap.Logger
@@ -32,6 +39,62 @@ func (*apiLogger) Error(msg string, err error) {
 	logger.Error(msg, zap.Error(err))
 }
 
+// corsFromEnv returns the CORS policy configured by the environment variables:
+// CORS_ALLOW_ORIGINS (comma separated, default: *), CORS_ALLOW_CREDENTIALS and CORS_MAX_AGE (duration).
+// CORS_ALLOW_CREDENTIALS requires the explicit origins.
+func corsFromEnv() *lambdagateway.CORS {
+	cors := lambdagateway.DefaultCORS()
+
+	if s := os.Getenv("CORS_ALLOW_ORIGINS"); s != "" {
+		cors.AllowOrigins = strings.Split(s, ",")
+	}
+	if s := os.Getenv("CORS_ALLOW_CREDENTIALS"); s != "" {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			zap.L().Fatal("ParseBool", zap.String("key", "CORS_ALLOW_CREDENTIALS"), zap.Error(err))
+		}
+		cors.AllowCredentials = b
+	}
+	if s := os.Getenv("CORS_MAX_AGE"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			zap.L().Fatal("ParseDuration", zap.String("key", "CORS_MAX_AGE"), zap.Error(err))
+		}
+		cors.MaxAge = d
+	}
+
+	if err := cors.Validate(); err != nil {
+		zap.L().Fatal("Validate", zap.String("key", "CORS_ALLOW_ORIGINS"), zap.Error(err))
+	}
+
+	return cors
+}
+
+// verifierFromEnv returns the verifier of the tokens if JWKS_URL is set,
+// for the deployments not fronted by the JWT authorizer of API Gateway. It is required with HTTP_ADDR.
+// JWT_ISSUER, JWT_AUDIENCE (comma separated) and JWT_TOKEN_USE restrict the claims.
+func verifierFromEnv() *jwt.Verifier {
+	jwksUrl := os.Getenv("JWKS_URL")
+	if jwksUrl == "" {
+		return nil
+	}
+
+	var opts []jwt.VerifierOption
+	if s := os.Getenv("JWT_ISSUER"); s != "" {
+		opts = append(opts, jwt.VerifierSetIssuer(s))
+	}
+	if s := os.Getenv("JWT_AUDIENCE"); s != "" {
+		for _, aud := range strings.Split(s, ",") {
+			opts = append(opts, jwt.VerifierAddAudience(aud))
+		}
+	}
+	if s := os.Getenv("JWT_TOKEN_USE"); s != "" {
+		opts = append(opts, jwt.VerifierSetTokenUse(s))
+	}
+
+	return jwt.NewVerifier(jwt.NewRemoteKeySet(jwksUrl), opts...)
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -45,29 +108,47 @@ func main() {
 	}
 	basePath := os.Getenv("BASE_PATH")
 
-	session := session.New()
-	lambdaClient := lambda.New(session, aws.NewConfig().WithRegion(region))
+	// KIFU_FUNCTION is the URL of the kifu function served over HTTP, or the ARN of the Lambda function.
+	// the function over HTTP requires KIFU_FUNCTION_SECRET, same as HTTP_SECRET of the function.
+	invoker := lambdagateway.LambdaInvoker(lambda.New(session.New(), aws.NewConfig().WithRegion(region)))
+	if strings.HasPrefix(kifuFuncArn, "http://") || strings.HasPrefix(kifuFuncArn, "https://") {
+		secret := os.Getenv("KIFU_FUNCTION_SECRET")
+		if secret == "" {
+			zap.L().Fatal("Getenv", zap.String("key", "KIFU_FUNCTION_SECRET"))
+		}
+		invoker = lambdagateway.HTTPInvoker(nil, lambdagateway.HTTPInvokerSetSecret(secret))
+	}
 
-	gw := lambdagateway.NewLambdaGateway(lambdaClient,
+	opts := []lambdagateway.GatewayOption{
 		lambdagateway.WithAPIRequestID(lambdarpc.ApiRequestIdField),
 		lambdagateway.WithClaimSubID(lambdarpc.UserIdField),
-		lambdagateway.AddFunction("/post-kifu", "POST", kifuFuncArn, "PostKifu"),
-		lambdagateway.AddFunction("/get-kifu", "POST", kifuFuncArn, "GetKifu"),
-		lambdagateway.AddFunction("/delete-kifu", "POST", kifuFuncArn, "DeleteKifu"),
-		lambdagateway.AddFunction("/recent-kifu", "POST", kifuFuncArn, "RecentKifu"),
-		lambdagateway.AddFunction("/same-positions", "POST", kifuFuncArn, "GetSamePositions"),
+		lambdagateway.WithClaimGroups(lambdarpc.GroupsField),
+		lambdagateway.WithScopes(lambdarpc.ScopesField),
 		lambdagateway.SetBasePath(basePath),
 		lambdagateway.SetLogger(&apiLogger{}),
-		lambdagateway.SetFunctionErrorHandler(func(e *lambdagateway.LambdaError) error {
-			switch e.ErrorType {
-			case "InvalidArgumentError":
-				return lambdagateway.ClientError(400, e.ErrorMessage)
-			default:
-				zap.L().Error("lambda.Invoke", zap.Any("error", e))
-				return lambdagateway.ServerError()
-			}
-		}),
-	)
+		lambdagateway.SetCORS(corsFromEnv()),
+		lambdagateway.SetFunctionErrorHandler(route.FunctionErrorHandler(&apiLogger{})),
+	}
+	verifier := verifierFromEnv()
+	if verifier != nil {
+		opts = append(opts, lambdagateway.VerifyToken(verifier))
+	}
+	opts = append(opts, route.Functions(kifuFuncArn)...)
+
+	gw := lambdagateway.NewGateway(invoker, opts...)
+
+	// serve over HTTP instead of the Lambda runtime, e.g. in a container.
+	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
+		// no authorizer of API Gateway sets the claims.
+		if verifier == nil {
+			logger.Fatal("Getenv", zap.String("key", "JWKS_URL"))
+		}
+		logger.Info("Listen", zap.String("addr", addr))
+		if err := http.ListenAndServe(addr, lambdagateway.HTTPHandler(gw, lambdagateway.HTTPSetLogger(&apiLogger{}))); err != nil {
+			logger.Fatal("ListenAndServe", zap.Error(err))
+		}
+		return
+	}
 
 	lambdaclient.StartWithContext(ctx, gw.Serve)
 }
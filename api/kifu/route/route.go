@@ -0,0 +1,71 @@
+// Package route defines the routes of the kifu API, shared by the API Lambda and the local server.
+package route
+
+import (
+	"github.com/yunomu/kansousen/lib/lambda/lambdagateway"
+)
+
+// Functions returns the options adding the routes to the kifu function.
+func Functions(kifuFuncArn string) []lambdagateway.GatewayOption {
+	return []lambdagateway.GatewayOption{
+		lambdagateway.AddFunction("/post-kifu", "POST", kifuFuncArn, "PostKifu"),
+		lambdagateway.AddFunction("/get-kifu", "POST", kifuFuncArn, "GetKifu"),
+		lambdagateway.AddFunction("/delete-kifu", "POST", kifuFuncArn, "DeleteKifu"),
+		lambdagateway.AddFunction("/recent-kifu", "POST", kifuFuncArn, "RecentKifu"),
+		lambdagateway.AddFunction("/same-positions", "POST", kifuFuncArn, "GetSamePositions"),
+		lambdagateway.AddFunction("/list-kifu-revisions", "POST", kifuFuncArn, "ListKifuRevisions"),
+		lambdagateway.AddFunction("/restore-kifu-revision", "POST", kifuFuncArn, "RestoreKifuRevision"),
+		lambdagateway.AddFunction("/list-trash", "POST", kifuFuncArn, "ListTrash"),
+		lambdagateway.AddFunction("/restore-kifu", "POST", kifuFuncArn, "RestoreKifu"),
+		lambdagateway.AddFunction("/add-tags", "POST", kifuFuncArn, "AddTags"),
+		lambdagateway.AddFunction("/remove-tags", "POST", kifuFuncArn, "RemoveTags"),
+		lambdagateway.AddFunction("/list-kifu-by-tag", "POST", kifuFuncArn, "ListKifuByTag"),
+		lambdagateway.AddFunction("/share-kifu", "POST", kifuFuncArn, "ShareKifu"),
+		lambdagateway.AddFunction("/unshare-kifu", "POST", kifuFuncArn, "UnshareKifu"),
+		lambdagateway.AddFunction("/create-kifu-link", "POST", kifuFuncArn, "CreateKifuLink"),
+		lambdagateway.AddFunction("/delete-kifu-link", "POST", kifuFuncArn, "DeleteKifuLink"),
+		lambdagateway.AddFunction("/create-team", "POST", kifuFuncArn, "CreateTeam"),
+		lambdagateway.AddFunction("/get-team", "POST", kifuFuncArn, "GetTeam"),
+		lambdagateway.AddFunction("/update-team-members", "POST", kifuFuncArn, "UpdateTeamMembers"),
+		lambdagateway.AddFunction("/accept-team-invitation", "POST", kifuFuncArn, "AcceptTeamInvitation"),
+		lambdagateway.AddFunction("/leave-team", "POST", kifuFuncArn, "LeaveTeam"),
+		lambdagateway.AddFunction("/join-public-pool", "POST", kifuFuncArn, "JoinPublicPool"),
+		lambdagateway.AddFunction("/leave-public-pool", "POST", kifuFuncArn, "LeavePublicPool"),
+		lambdagateway.AddFunction("/search-pattern", "POST", kifuFuncArn, "SearchPattern"),
+		lambdagateway.AddFunction("/kifu-stats", "POST", kifuFuncArn, "GetKifuStats"),
+		lambdagateway.AddFunction("/find-missed-mates", "POST", kifuFuncArn, "FindMissedMates"),
+		lambdagateway.AddFunction("/render-position", "POST", kifuFuncArn, "RenderPosition"),
+		lambdagateway.AddFunction("/export-animation", "POST", kifuFuncArn, "ExportAnimation"),
+		lambdagateway.AddFunction("/admin/bulk-delete-kifu", "POST", kifuFuncArn, "BulkDeleteKifu"),
+		lambdagateway.AddFunction("/admin/list-user-kifu", "POST", kifuFuncArn, "ListUserKifu"),
+		lambdagateway.AddFunction("/admin/reindex-kifu", "POST", kifuFuncArn, "ReindexKifu"),
+
+		// REST API
+		lambdagateway.AddFunction("/kifu", "POST", kifuFuncArn, "PostKifu"),
+		lambdagateway.AddFunction("/kifu?limit={limit:int}&strategy={strategy}", "GET", kifuFuncArn, "RecentKifu"),
+		lambdagateway.AddFunction("/kifu/{kifu_id}?link_token={link_token}", "GET", kifuFuncArn, "GetKifu"),
+		lambdagateway.AddFunction("/kifu/{kifu_id}?version={version:int}&soft={soft:bool}", "DELETE", kifuFuncArn, "DeleteKifu"),
+		lambdagateway.AddFunction("/kifu/{kifu_id}/revisions", "GET", kifuFuncArn, "ListKifuRevisions"),
+		lambdagateway.AddFunction("/kifu/{kifu_id}/steps/{seq:int}/image?format={format}&cell_size={cell_size:int}&link_token={link_token}", "GET", kifuFuncArn, "RenderPosition"),
+		lambdagateway.AddFunction("/tags/{tag}/kifu?limit={limit:int}&page_token={page_token}", "GET", kifuFuncArn, "ListKifuByTag"),
+		lambdagateway.AddFunction("/team/{team_id}", "GET", kifuFuncArn, "GetTeam"),
+		lambdagateway.AddFunction("/team/{team_id}/members", "PATCH", kifuFuncArn, "UpdateTeamMembers"),
+		lambdagateway.AddFunction("/team/{team_id}/invitation", "POST", kifuFuncArn, "AcceptTeamInvitation"),
+		lambdagateway.AddFunction("/team/{team_id}/membership", "DELETE", kifuFuncArn, "LeaveTeam"),
+	}
+}
+
+// FunctionErrorHandler maps the errors of the kifu function to the responses.
+func FunctionErrorHandler(logger lambdagateway.Logger) func(*lambdagateway.LambdaError) error {
+	return func(e *lambdagateway.LambdaError) error {
+		switch e.ErrorType {
+		case "InvalidArgumentError", "ClientError":
+			return lambdagateway.ClientError(400, e.ErrorMessage)
+		case "ForbiddenError":
+			return lambdagateway.ClientError(403, e.ErrorMessage)
+		default:
+			logger.Error("lambda.Invoke", e)
+			return lambdagateway.ServerError()
+		}
+	}
+}
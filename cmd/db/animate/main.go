@@ -0,0 +1,130 @@
+package animate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/google/subcommands"
+
+	"github.com/yunomu/kansousen/lib/db"
+	"github.com/yunomu/kansousen/lib/render"
+)
+
+type Command struct {
+	kifuId   *string
+	start    *int
+	end      *int
+	notes    *bool
+	delay    *int
+	cellSize *int
+	format   *string
+	output   *string
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (c *Command) Name() string     { return "animate" }
+func (c *Command) Synopsis() string { return "Export kifu as animated GIF or frame images" }
+func (c *Command) Usage() string {
+	return `animate -kifu-id <kifu id> [-format gif|png|svg] [-o <file or directory>]
+
+gif: write an animated GIF to the file (default: stdout).
+png, svg: write numbered frames (frame-000.png, ...) to the directory.
+`
+}
+
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.SetOutput(os.Stderr)
+
+	c.kifuId = f.String("kifu-id", "", "Kifu ID")
+	c.start = f.Int("start", 0, "First seq")
+	c.end = f.Int("end", 0, "Last seq (0: last step)")
+	c.notes = f.Bool("notes", false, "Draw notes of the steps")
+	c.delay = f.Int("delay", 1000, "Display time of a frame in milliseconds")
+	c.cellSize = f.Int("cell-size", render.DefaultCellSize, "Size of a square in pixels")
+	c.format = f.String("format", "gif", "Output format (gif|png|svg)")
+	c.output = f.String("o", "", "Output file (gif) or directory (png, svg)")
+}
+
+func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	db := args[0].(func() db.DB)()
+
+	if *c.kifuId == "" {
+		log.Fatalf("kifu-id is required")
+	}
+
+	kifu, steps, _, err := db.GetKifuAndSteps(ctx, *c.kifuId)
+	if err != nil {
+		log.Fatalf("GetKifuAndSteps: %v", err)
+	}
+
+	frames, err := render.Frames(kifu, steps, int32(*c.start), int32(*c.end), *c.notes,
+		render.SetCellSize(*c.cellSize),
+	)
+	if err != nil {
+		log.Fatalf("render.Frames: %v", err)
+	}
+	if len(frames) == 0 {
+		log.Fatalf("no frames in the range")
+	}
+
+	switch *c.format {
+	case "gif":
+		var w io.Writer = os.Stdout
+		if *c.output != "" {
+			file, err := os.Create(*c.output)
+			if err != nil {
+				log.Fatalf("Create: %v", err)
+			}
+			defer file.Close()
+			w = file
+		}
+		if err := render.WriteGIF(w, frames, *c.delay/10); err != nil {
+			log.Fatalf("WriteGIF: %v", err)
+		}
+	case "png", "svg":
+		dir := *c.output
+		if dir == "" {
+			dir = "."
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			log.Fatalf("MkdirAll: %v", err)
+		}
+		for i, frame := range frames {
+			name := filepath.Join(dir, fmt.Sprintf("frame-%03d.%s", i, *c.format))
+			if err := writeFrame(name, frame, *c.format); err != nil {
+				log.Fatalf("write %s: %v", name, err)
+			}
+		}
+	default:
+		log.Fatalf("unknown format: %v", *c.format)
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func writeFrame(name string, frame *render.Renderer, format string) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if format == "svg" {
+		err = frame.WriteSVG(file)
+	} else {
+		err = frame.WritePNG(file)
+	}
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,141 @@
+package dedupe
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/google/subcommands"
+
+	"github.com/yunomu/kansousen/lib/db"
+	"github.com/yunomu/kansousen/lib/kifu"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+type Command struct {
+	userId *string
+	dryrun *bool
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (c *Command) Name() string     { return "dedupe" }
+func (c *Command) Synopsis() string { return "Merge kifu which have the same moves" }
+func (c *Command) Usage() string {
+	return `
+`
+}
+
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.SetOutput(os.Stderr)
+
+	c.userId = f.String("user-id", "", "User ID")
+	c.dryrun = f.Bool("dryrun", false, "Dry run")
+}
+
+type versionedKifu struct {
+	kifu    *documentpb.Kifu
+	version int64
+}
+
+// merge copies the tags, the shares and the note of the duplicate to k.
+func merge(k, dup *documentpb.Kifu) {
+	kifu.AddTags(k, dup.GetTags()...)
+
+	shared := make(map[string]bool)
+	for _, share := range k.GetShares() {
+		shared[share.GetUserId()] = true
+	}
+	for _, share := range dup.GetShares() {
+		if !shared[share.GetUserId()] {
+			kifu.SetShare(k, share.GetUserId(), share.GetRole())
+		}
+	}
+
+	if k.GetNote() == "" {
+		k.Note = dup.GetNote()
+	}
+}
+
+func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	table := args[0].(func() db.DB)()
+
+	if *c.userId == "" {
+		log.Fatalf("user-id is required")
+	}
+
+	groups := make(map[string][]*versionedKifu)
+	if err := table.ListKifu(ctx, *c.userId, func(k *documentpb.Kifu, version int64) {
+		if k.GetSfen() == "" {
+			return
+		}
+		groups[k.GetSfen()] = append(groups[k.GetSfen()], &versionedKifu{
+			kifu:    k,
+			version: version,
+		})
+	}); err != nil {
+		log.Fatalf("ListKifu: %v", err)
+	}
+
+	for _, vks := range groups {
+		if len(vks) < 2 {
+			continue
+		}
+
+		// keep the oldest one
+		sort.Slice(vks, func(i, j int) bool {
+			a, b := vks[i].kifu, vks[j].kifu
+			if a.GetCreatedTs() != b.GetCreatedTs() {
+				return a.GetCreatedTs() < b.GetCreatedTs()
+			}
+			return a.GetKifuId() < b.GetKifuId()
+		})
+		keep, dups := vks[0], vks[1:]
+
+		var ids []string
+		for _, dup := range dups {
+			ids = append(ids, dup.kifu.GetKifuId())
+			ids = append(ids, dup.kifu.GetAliases()...)
+		}
+		fmt.Println(keep.kifu.GetKifuId(), "<-", ids)
+
+		if *c.dryrun {
+			continue
+		}
+
+		k, version, err := table.GetKifu(ctx, keep.kifu.GetKifuId())
+		if err != nil {
+			log.Fatalf("GetKifu: %v", err)
+		}
+
+		kifu.AddAliases(k, ids...)
+		for _, dup := range dups {
+			merge(k, dup.kifu)
+		}
+
+		if _, err := table.UpdateKifu(ctx, k, version); err != nil {
+			log.Fatalf("UpdateKifu: %v", err)
+		}
+
+		// the duplicates go to the trash, so their step notes and revisions can still be restored.
+		for _, dup := range dups {
+			if _, err := table.TrashKifu(ctx, dup.kifu.GetKifuId(), dup.version); err != nil {
+				log.Fatalf("TrashKifu: %v", err)
+			}
+		}
+
+		// aliases are put after trashing, otherwise they expire together with the duplicates.
+		for _, id := range ids {
+			if err := table.PutAlias(ctx, id, k.GetKifuId()); err != nil {
+				log.Fatalf("PutAlias: %v", err)
+			}
+		}
+	}
+
+	return subcommands.ExitSuccess
+}
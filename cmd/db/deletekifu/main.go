@@ -3,6 +3,7 @@ package deletekifu
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 
@@ -15,6 +16,7 @@ type Command struct {
 	utf8    *bool
 	kifuId  *string
 	version *int64
+	soft    *bool
 	dryrun  *bool
 }
 
@@ -34,6 +36,7 @@ func (c *Command) SetFlags(f *flag.FlagSet) {
 
 	c.kifuId = f.String("kifu-id", "", "Kifu ID")
 	c.version = f.Int64("version", 0, "Version")
+	c.soft = f.Bool("soft", false, "Move to the trash")
 }
 
 func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
@@ -43,6 +46,17 @@ func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interfac
 		log.Fatalf("kifu-id and version is required")
 	}
 
+	if *c.soft {
+		version, err := db.TrashKifu(ctx, *c.kifuId, *c.version)
+		if err != nil {
+			log.Fatalf("TrashKifu: %v", err)
+		}
+
+		fmt.Println(version)
+
+		return subcommands.ExitSuccess
+	}
+
 	if err := db.DeleteKifu(ctx, *c.kifuId, *c.version); err != nil {
 		log.Fatalf("DeleteKifu: %v", err)
 	}
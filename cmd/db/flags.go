@@ -0,0 +1,55 @@
+package db
+
+import (
+	"flag"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/yunomu/kansousen/lib/db"
+)
+
+// Flags are the flags to connect to DynamoDB, shared by the commands using the table.
+// Region and table default to the stack outputs in config.json.
+type Flags struct {
+	endpoint *string
+	region   *string
+	table    *string
+}
+
+func (fl *Flags) SetFlags(f *flag.FlagSet) {
+	fl.endpoint = f.String("endpoint", "", "Endpoint of DynamoDB")
+	fl.region = f.String("region", "", "Region of DynamoDB (default: Region of config.json)")
+	fl.table = f.String("table", "", "Table name (default: KifuTable of config.json)")
+}
+
+// Table returns the table name of the flag or cfg.
+func (fl *Flags) Table(cfg map[string]string) string {
+	if *fl.table != "" {
+		return *fl.table
+	}
+	return cfg["KifuTable"]
+}
+
+// DBFunc returns the function creating the DB on the first call.
+func (fl *Flags) DBFunc(cfg map[string]string) func() db.DB {
+	var d db.DB
+	return func() db.DB {
+		if d != nil {
+			return d
+		}
+
+		region := cfg["Region"]
+		if *fl.region != "" {
+			region = *fl.region
+		}
+		config := aws.NewConfig().WithRegion(region)
+		if *fl.endpoint != "" {
+			config.WithEndpoint(*fl.endpoint)
+		}
+
+		d = db.NewDynamoDB(dynamodb.New(session.New(), config), fl.Table(cfg))
+		return d
+	}
+}
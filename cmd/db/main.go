@@ -8,25 +8,20 @@ import (
 
 	"github.com/google/subcommands"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
-
-	"github.com/yunomu/kansousen/lib/db"
-
+	"github.com/yunomu/kansousen/cmd/db/animate"
+	"github.com/yunomu/kansousen/cmd/db/dedupe"
 	"github.com/yunomu/kansousen/cmd/db/deletekifu"
 	"github.com/yunomu/kansousen/cmd/db/getkifu"
 	"github.com/yunomu/kansousen/cmd/db/listkifu"
 	"github.com/yunomu/kansousen/cmd/db/putkifu"
 	"github.com/yunomu/kansousen/cmd/db/recentkifu"
+	"github.com/yunomu/kansousen/cmd/db/reindex"
 	"github.com/yunomu/kansousen/cmd/db/samepos"
 )
 
 type Command struct {
-	endpoint *string
-	region   *string
-	table    *string
-	log      *bool
+	dbFlags Flags
+	log     *bool
 
 	commander *subcommands.Commander
 }
@@ -45,9 +40,7 @@ func (c *Command) Usage() string {
 func (c *Command) SetFlags(f *flag.FlagSet) {
 	f.SetOutput(os.Stderr)
 
-	c.endpoint = f.String("endpoint", "", "Endpoint of DynamoDB")
-	c.region = f.String("region", "", "Endpoint of DynamoDB (default: config.json)")
-	c.table = f.String("table", "", "Table name (default: config.json)")
+	c.dbFlags.SetFlags(f)
 	c.log = f.Bool("log", false, "output log")
 
 	commander := subcommands.NewCommander(f, "")
@@ -60,6 +53,9 @@ func (c *Command) SetFlags(f *flag.FlagSet) {
 	commander.Register(listkifu.NewCommand(), "kifu")
 	commander.Register(deletekifu.NewCommand(), "kifu")
 	commander.Register(recentkifu.NewCommand(), "kifu")
+	commander.Register(dedupe.NewCommand(), "kifu")
+	commander.Register(animate.NewCommand(), "kifu")
+	commander.Register(reindex.NewCommand(), "kifu")
 
 	commander.Register(samepos.NewCommand(), "pos")
 
@@ -75,20 +71,5 @@ func (l *logger) Info(function string, message string) {
 func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
 	cfg := args[0].(map[string]string)
 
-	return c.commander.Execute(ctx, func() db.DB {
-		region := cfg["Region"]
-		if *c.region != "" {
-			region = *c.region
-		}
-		config := aws.NewConfig().WithRegion(region)
-
-		if *c.endpoint != "" {
-			config.WithEndpoint(*c.endpoint)
-		}
-
-		return db.NewDynamoDB(
-			dynamodb.New(session.New(), config),
-			*c.table,
-		)
-	})
+	return c.commander.Execute(ctx, c.dbFlags.DBFunc(cfg))
 }
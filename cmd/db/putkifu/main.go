@@ -13,6 +13,7 @@ import (
 
 	"github.com/yunomu/kansousen/lib/db"
 	"github.com/yunomu/kansousen/lib/kifu"
+	"github.com/yunomu/kansousen/lib/senkei"
 )
 
 type Command struct {
@@ -42,6 +43,7 @@ func (c *Command) SetFlags(f *flag.FlagSet) {
 	c.tz = f.String("timezone", "Asia/Tokyo", "TimeZone")
 	c.userId = f.String("user-id", "", "User ID")
 	c.kifuId = f.String("kifu-id", "", "Kifu ID")
+	c.version = f.Int64("version", 0, "Current version (overwrite existing kifu)")
 	c.dryrun = f.Bool("dryrun", false, "Dry run")
 }
 
@@ -70,9 +72,10 @@ func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interfac
 	if err != nil {
 		log.Fatalf("kifu.Parse: %v", err)
 	}
+	senkei.NewClassifier(senkei.DefaultRules()).Apply(kifu, steps)
 
 	if !*c.dryrun {
-		if _, err := db.PutKifu(ctx, kifu, steps, 0); err != nil {
+		if _, err := db.PutKifu(ctx, kifu, steps, *c.version); err != nil {
 			log.Fatalf("PutKifu: %v", err)
 		}
 	}
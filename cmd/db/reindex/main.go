@@ -0,0 +1,97 @@
+package reindex
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/google/subcommands"
+
+	"github.com/yunomu/kansousen/lib/db"
+	"github.com/yunomu/kansousen/lib/senkei"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+type Command struct {
+	userId     *string
+	kifuId     *string
+	strategies *bool
+	dryrun     *bool
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (c *Command) Name() string     { return "reindex" }
+func (c *Command) Synopsis() string { return "Recompute the index keys of kifu" }
+func (c *Command) Usage() string {
+	return `Backfill the index keys, e.g. cpos of the steps put before NormalizedPosition index was added.
+With -strategies, the strategies (戦型) are classified again, e.g. after the rules are changed.
+`
+}
+
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.SetOutput(os.Stderr)
+
+	c.userId = f.String("user-id", "", "User ID")
+	c.kifuId = f.String("kifu-id", "", "Kifu ID")
+	c.strategies = f.Bool("strategies", false, "Classify the strategies again")
+	c.dryrun = f.Bool("dryrun", false, "Dry run")
+}
+
+func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	table := args[0].(func() db.DB)()
+
+	versions := make(map[string]int64)
+	var kifuIds []string
+	switch {
+	case *c.kifuId != "":
+		_, version, err := table.GetKifu(ctx, *c.kifuId)
+		if err != nil {
+			log.Fatalf("GetKifu: %v", err)
+		}
+		versions[*c.kifuId] = version
+		kifuIds = append(kifuIds, *c.kifuId)
+	case *c.userId != "":
+		if err := table.ListKifu(ctx, *c.userId, func(k *documentpb.Kifu, version int64) {
+			versions[k.GetKifuId()] = version
+			kifuIds = append(kifuIds, k.GetKifuId())
+		}); err != nil {
+			log.Fatalf("ListKifu: %v", err)
+		}
+	default:
+		log.Fatalf("user-id or kifu-id is required")
+	}
+
+	classifier := senkei.NewClassifier(senkei.DefaultRules())
+	for _, kifuId := range kifuIds {
+		fmt.Println(kifuId)
+
+		if *c.dryrun {
+			continue
+		}
+
+		version := versions[kifuId]
+		if *c.strategies {
+			k, steps, v, err := table.GetKifuAndSteps(ctx, kifuId)
+			if err != nil {
+				log.Fatalf("GetKifuAndSteps: %v", err)
+			}
+			classifier.Apply(k, steps)
+
+			version, err = table.UpdateKifu(ctx, k, v)
+			if err != nil {
+				log.Fatalf("UpdateKifu: %v", err)
+			}
+		}
+
+		if _, err := table.ReindexKifu(ctx, kifuId, version); err != nil {
+			log.Fatalf("ReindexKifu: %v", err)
+		}
+	}
+
+	return subcommands.ExitSuccess
+}
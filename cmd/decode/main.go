@@ -12,9 +12,14 @@ import (
 	"strings"
 
 	"github.com/google/subcommands"
+
+	"github.com/yunomu/kansousen/lib/jwt"
 )
 
 type Command struct {
+	verify   *string
+	issuer   *string
+	audience *string
 }
 
 func NewCommand() *Command {
@@ -30,6 +35,35 @@ func (c *Command) Usage() string {
 
 func (c *Command) SetFlags(f *flag.FlagSet) {
 	f.SetOutput(os.Stderr)
+
+	c.verify = f.String("verify", "", "Verify the tokens with the JWKS (URL or file)")
+	c.issuer = f.String("issuer", "", "Expected issuer (iss)")
+	c.audience = f.String("audience", "", "Expected audience (aud or client_id)")
+}
+
+func (c *Command) keySet() (jwt.KeySet, error) {
+	if strings.HasPrefix(*c.verify, "http://") || strings.HasPrefix(*c.verify, "https://") {
+		return jwt.NewRemoteKeySet(*c.verify), nil
+	}
+	return jwt.LoadJWKSFile(*c.verify)
+}
+
+func (c *Command) verifyToken(ctx context.Context, keys jwt.KeySet, name, token, use string) {
+	opts := []jwt.VerifierOption{
+		jwt.VerifierSetTokenUse(use),
+	}
+	if *c.issuer != "" {
+		opts = append(opts, jwt.VerifierSetIssuer(*c.issuer))
+	}
+	if *c.audience != "" {
+		opts = append(opts, jwt.VerifierAddAudience(*c.audience))
+	}
+
+	if _, err := jwt.NewVerifier(keys, opts...).Verify(ctx, token); err != nil {
+		log.Printf("%s: verification failed: %v", name, err)
+		return
+	}
+	log.Printf("%s: verified", name)
 }
 
 func fieldDecode(s string) (map[string]interface{}, error) {
@@ -88,7 +122,16 @@ func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interfac
 		log.Fatalf("AuthenticationResult is invalid format")
 	}
 
-	hdr, p, err := jwtDecode(res["IdToken"].(string))
+	idToken, ok := res["IdToken"].(string)
+	if !ok {
+		log.Fatalf("IdToken not found")
+	}
+	accessToken, ok := res["AccessToken"].(string)
+	if !ok {
+		log.Fatalf("AccessToken not found")
+	}
+
+	hdr, p, err := jwtDecode(idToken)
 	if err != nil {
 		log.Fatalf("DecodeError IdToken: %v", err)
 	}
@@ -99,7 +142,7 @@ func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interfac
 		log.Printf("IdToken payload: %v = %v", k, v)
 	}
 
-	hdr, p, err = jwtDecode(res["AccessToken"].(string))
+	hdr, p, err = jwtDecode(accessToken)
 	if err != nil {
 		log.Fatalf("DecodeError AccessToken: %v", err)
 	}
@@ -112,5 +155,14 @@ func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interfac
 
 	log.Printf("RefreshToken: %v", res["RefreshToken"])
 
+	if *c.verify != "" {
+		keys, err := c.keySet()
+		if err != nil {
+			log.Fatalf("load JWKS: %v", err)
+		}
+		c.verifyToken(ctx, keys, "IdToken", idToken, "id")
+		c.verifyToken(ctx, keys, "AccessToken", accessToken, "access")
+	}
+
 	return subcommands.ExitSuccess
 }
@@ -13,7 +13,11 @@ import (
 	"github.com/yunomu/kansousen/cmd/decode"
 	"github.com/yunomu/kansousen/cmd/kifudoc"
 	"github.com/yunomu/kansousen/cmd/logs"
+	"github.com/yunomu/kansousen/cmd/render"
+	"github.com/yunomu/kansousen/cmd/serve"
 	"github.com/yunomu/kansousen/cmd/sfen"
+	"github.com/yunomu/kansousen/cmd/tsume"
+	"github.com/yunomu/kansousen/cmd/view"
 )
 
 var (
@@ -29,6 +33,10 @@ func init() {
 	subcommands.Register(kifudoc.NewCommand(), "")
 	subcommands.Register(db.NewCommand(), "")
 	subcommands.Register(logs.NewCommand(), "")
+	subcommands.Register(tsume.NewCommand(), "")
+	subcommands.Register(render.NewCommand(), "")
+	subcommands.Register(view.NewCommand(), "")
+	subcommands.Register(serve.NewCommand(), "")
 
 	subcommands.Register(subcommands.CommandsCommand(), "other")
 	subcommands.Register(subcommands.FlagsCommand(), "other")
@@ -0,0 +1,119 @@
+package render
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/yunomu/kif"
+
+	"github.com/yunomu/kansousen/lib/kifu"
+	"github.com/yunomu/kansousen/lib/render"
+	"github.com/yunomu/kansousen/lib/shogi"
+)
+
+type Command struct {
+	utf8     *bool
+	tz       *string
+	seq      *int
+	format   *string
+	cellSize *int
+	arrows   *string
+	output   *string
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (c *Command) Name() string     { return "render" }
+func (c *Command) Synopsis() string { return "Render a position of KIF(stdin) to SVG or PNG" }
+func (c *Command) Usage() string {
+	return `render -seq N [-format svg|png] [-arrows 7g7f,8h2b] [-o file] < game.kif
+`
+}
+
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.SetOutput(os.Stderr)
+
+	c.utf8 = f.Bool("utf", false, "Input encoding UTF8")
+	c.tz = f.String("tz", "Asia/Tokyo", "Timezone")
+	c.seq = f.Int("seq", 0, "Seq of the step")
+	c.format = f.String("format", "svg", "Output format (svg|png)")
+	c.cellSize = f.Int("cell-size", render.DefaultCellSize, "Size of a square in pixels")
+	c.arrows = f.String("arrows", "", "Comma separated USI moves drawn as arrows")
+	c.output = f.String("o", "", "Output file (default: stdout)")
+}
+
+func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	loc, err := time.LoadLocation(*c.tz)
+	if err != nil {
+		log.Fatalf("LoadLocation: %v", err)
+	}
+
+	var opts []kif.ParseOption
+	if *c.utf8 {
+		opts = append(opts, kif.ParseEncodingUTF8())
+	}
+
+	p := kifu.NewParser(kif.NewParser(opts...), loc)
+	k, steps, err := p.Parse(os.Stdin, "", "")
+	if err != nil {
+		log.Fatalf("kifu.Parse: %v", err)
+	}
+
+	if *c.seq < 0 || *c.seq >= len(steps) {
+		log.Fatalf("seq is out of range: %d", *c.seq)
+	}
+	step := steps[*c.seq]
+
+	ropts := render.StepOptions(k, step)
+	ropts = append(ropts, render.SetCellSize(*c.cellSize))
+	if *c.arrows != "" {
+		for _, s := range strings.Split(*c.arrows, ",") {
+			m, err := shogi.ParseUSI(s)
+			if err != nil || m.IsDrop() {
+				log.Fatalf("invalid arrow: %v", s)
+			}
+			ropts = append(ropts, render.AddArrow(
+				int32(m.From.X()), int32(m.From.Y()),
+				int32(m.To.X()), int32(m.To.Y()),
+			))
+		}
+	}
+
+	r, err := render.New(step.GetPosition(), ropts...)
+	if err != nil {
+		log.Fatalf("render.New: %v", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if *c.output != "" {
+		file, err := os.Create(*c.output)
+		if err != nil {
+			log.Fatalf("os.Create: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch *c.format {
+	case "svg":
+		err = r.WriteSVG(out)
+	case "png":
+		err = r.WritePNG(out)
+	default:
+		log.Fatalf("unknown format: %v", *c.format)
+	}
+	if err != nil {
+		log.Fatalf("render: %v", err)
+	}
+
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,178 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/yunomu/kansousen/lib/jwt"
+	"github.com/yunomu/kansousen/lib/lambda/lambdagateway"
+	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+
+	"github.com/yunomu/kansousen/api/kifu/route"
+	"github.com/yunomu/kansousen/lambda/kifu/service"
+
+	cmddb "github.com/yunomu/kansousen/cmd/db"
+)
+
+// localFunction is the name of the in-process kifu function.
+const localFunction = "local-kifu"
+
+type Command struct {
+	addr      *string
+	basePath  *string
+	userId    *string
+	groups    *string
+	jwtSecret *string
+	dbFlags   cmddb.Flags
+
+	corsOrigins *string
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (c *Command) Name() string     { return "serve" }
+func (c *Command) Synopsis() string { return "Run the API as a local HTTP server" }
+func (c *Command) Usage() string {
+	return `serve (-user-id <user id> | -jwt-secret <secret>)
+
+The requests are authenticated by the bearer token signed with -jwt-secret (HS256),
+or otherwise treated as the requests of -user-id.
+`
+}
+
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.SetOutput(os.Stderr)
+
+	c.addr = f.String("addr", "localhost:8080", "Listen address")
+	c.basePath = f.String("base-path", "", "Base path of the API")
+	c.userId = f.String("user-id", "", "User ID of the requests without a token")
+	c.groups = f.String("groups", "", "Comma separated groups of -user-id, e.g. admin")
+	c.jwtSecret = f.String("jwt-secret", "", "Secret to verify the HS256 bearer tokens")
+	c.dbFlags.SetFlags(f)
+	c.corsOrigins = f.String("cors-origins", "*", "Comma separated allowed origins of CORS")
+}
+
+type logger struct{}
+
+func (*logger) Error(msg string, err error) {
+	log.Printf("%s: %v", msg, err)
+}
+
+var ErrUnauthorized = errors.New("no user id")
+
+type authorizer struct {
+	userId    string
+	groups    []string
+	jwtSecret []byte
+}
+
+// claims returns the claims of the bearer token, or the claims of the local user if the token is absent.
+func (a *authorizer) claims(r *http.Request) (map[string]string, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || len(a.jwtSecret) == 0 {
+		if a.userId == "" {
+			return nil, ErrUnauthorized
+		}
+		return map[string]string{
+			"sub":                     a.userId,
+			lambdagateway.GroupsClaim: "[" + strings.Join(a.groups, " ") + "]",
+		}, nil
+	}
+
+	payload, err := jwt.VerifyHS256(token, a.jwtSecret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	sub, _ := payload["sub"].(string)
+	if sub == "" {
+		return nil, ErrUnauthorized
+	}
+
+	var groups []string
+	if gs, ok := payload[lambdagateway.GroupsClaim].([]interface{}); ok {
+		for _, g := range gs {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+	return map[string]string{
+		"sub":                     sub,
+		lambdagateway.GroupsClaim: "[" + strings.Join(groups, " ") + "]",
+	}, nil
+}
+
+func withLog(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+		log.Printf("%s %s", r.Method, r.URL.Path)
+	})
+}
+
+func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	cfg := args[0].(map[string]string)
+
+	if c.dbFlags.Table(cfg) == "" {
+		log.Fatalf("table is required")
+	}
+	if *c.userId == "" && *c.jwtSecret == "" {
+		log.Fatalf("user-id or jwt-secret is required")
+	}
+
+	table := c.dbFlags.DBFunc(cfg)()
+
+	h := lambdarpc.NewHandler(service.NewService(table), lambdarpc.WithUnaryInterceptors(
+		lambdarpc.LoggingInterceptor(func(ctx context.Context, l *lambdarpc.CallLog) {
+			if l.Err != nil {
+				log.Printf("%s user=%s %v: %v", l.Method, l.UserId, l.Duration, l.Err)
+			}
+		}),
+		lambdarpc.RecoveryInterceptor(),
+		lambdarpc.ValidationInterceptor(),
+	))
+	if err := h.Init(); err != nil {
+		log.Fatalf("Init: %v", err)
+	}
+
+	cors := lambdagateway.DefaultCORS()
+	cors.AllowOrigins = strings.Split(*c.corsOrigins, ",")
+
+	opts := []lambdagateway.GatewayOption{
+		lambdagateway.WithAPIRequestID(lambdarpc.ApiRequestIdField),
+		lambdagateway.WithClaimSubID(lambdarpc.UserIdField),
+		lambdagateway.WithClaimGroups(lambdarpc.GroupsField),
+		lambdagateway.WithScopes(lambdarpc.ScopesField),
+		lambdagateway.SetBasePath(*c.basePath),
+		lambdagateway.SetLogger(&logger{}),
+		lambdagateway.SetCORS(cors),
+		lambdagateway.SetFunctionErrorHandler(route.FunctionErrorHandler(&logger{})),
+	}
+	opts = append(opts, route.Functions(localFunction)...)
+
+	auth := &authorizer{
+		userId:    *c.userId,
+		groups:    strings.FieldsFunc(*c.groups, func(r rune) bool { return r == ',' }),
+		jwtSecret: []byte(*c.jwtSecret),
+	}
+	gw := lambdagateway.NewGateway(lambdagateway.HandlerInvoker(h), opts...)
+
+	log.Printf("Listen %s", *c.addr)
+	if err := http.ListenAndServe(*c.addr, withLog(lambdagateway.HTTPHandler(gw,
+		lambdagateway.HTTPSetAuthorizer(auth.claims),
+		lambdagateway.HTTPSetLogger(&logger{}),
+	))); err != nil {
+		log.Fatalf("ListenAndServe: %v", err)
+	}
+
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,106 @@
+package tsume
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/yunomu/kif"
+
+	"github.com/yunomu/kansousen/lib/kifu"
+	"github.com/yunomu/kansousen/lib/tsume"
+)
+
+type Command struct {
+	utf8     *bool
+	tz       *string
+	maxPly   *int
+	maxNodes *int
+	format   *string
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (c *Command) Name() string     { return "tsume" }
+func (c *Command) Synopsis() string { return "Extract missed mates from KIF(stdin)" }
+func (c *Command) Usage() string {
+	return `tsume [-max-ply N] [-format kif|bod|sfen] < game.kif
+`
+}
+
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.SetOutput(os.Stderr)
+
+	c.utf8 = f.Bool("utf", false, "Input encoding UTF8")
+	c.tz = f.String("tz", "Asia/Tokyo", "Timezone")
+	c.maxPly = f.Int("max-ply", 7, "Maximum length of the mates in plies")
+	c.maxNodes = f.Int("max-nodes", tsume.DefaultMaxNodes, "Node limit of the search per position")
+	c.format = f.String("format", "kif", "Output format (kif|bod|sfen)")
+}
+
+func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	loc, err := time.LoadLocation(*c.tz)
+	if err != nil {
+		log.Fatalf("LoadLocation: %v", err)
+	}
+
+	in := os.Stdin
+
+	var opts []kif.ParseOption
+	if *c.utf8 {
+		opts = append(opts, kif.ParseEncodingUTF8())
+	}
+
+	p := kifu.NewParser(kif.NewParser(opts...), loc)
+	_, steps, err := p.Parse(in, "", "")
+	if err != nil {
+		log.Fatalf("kifu.Parse: %v", err)
+	}
+
+	missed, err := tsume.FindMissedMates(ctx, steps, *c.maxPly, tsume.SetMaxNodes(*c.maxNodes))
+	if err != nil {
+		log.Fatalf("tsume.FindMissedMates: %v", err)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	for _, m := range missed {
+		header := fmt.Sprintf("# %d手目 %d手詰 (played: %s)", m.Seq, len(m.Mate), m.Played)
+
+		switch *c.format {
+		case "kif":
+			if err := tsume.WriteKIF(out, m.Position, m.Mate, header); err != nil {
+				log.Fatalf("WriteKIF: %v", err)
+			}
+		case "bod":
+			fmt.Fprintln(out, header)
+			if err := tsume.WriteBOD(out, m.Position); err != nil {
+				log.Fatalf("WriteBOD: %v", err)
+			}
+		case "sfen":
+			fmt.Fprintf(out, "%s\t", m.Position.SFEN())
+			for i, mv := range m.Mate {
+				if i > 0 {
+					fmt.Fprint(out, " ")
+				}
+				fmt.Fprint(out, mv.USI())
+			}
+			fmt.Fprintln(out)
+			continue
+		default:
+			log.Fatalf("unknown format: %v", *c.format)
+		}
+		fmt.Fprintln(out)
+	}
+
+	return subcommands.ExitSuccess
+}
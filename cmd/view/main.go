@@ -0,0 +1,338 @@
+package view
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/yunomu/kif"
+
+	"github.com/yunomu/kansousen/lib/db"
+	"github.com/yunomu/kansousen/lib/kifu"
+	"github.com/yunomu/kansousen/lib/render"
+	"github.com/yunomu/kansousen/lib/shogi"
+	"github.com/yunomu/kansousen/lib/tsume"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+
+	cmddb "github.com/yunomu/kansousen/cmd/db"
+)
+
+const help = `commands:
+  n, next [N]   forward N plies (default: 1, also an empty line)
+  p, prev [N]   back N plies
+  j, jump SEQ   jump to the ply
+  first, last   jump to the start or the end
+  l, list       show the moves around the current ply
+  notes         toggle showing the notes
+  s, same       search the same positions as the current ply
+  h, help       show this help
+  q, quit       quit
+`
+
+type Command struct {
+	utf8    *bool
+	tz      *string
+	kifuId  *string
+	userId  *string
+	dbFlags cmddb.Flags
+	steps   *int
+	ansi    *bool
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (c *Command) Name() string     { return "view" }
+func (c *Command) Synopsis() string { return "View and replay a kifu in the terminal" }
+func (c *Command) Usage() string {
+	return `view <KIF file>
+view -kifu-id <kifu id>
+
+` + help
+}
+
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.SetOutput(os.Stderr)
+
+	c.utf8 = f.Bool("utf", false, "Input encoding UTF8")
+	c.tz = f.String("tz", "Asia/Tokyo", "Timezone")
+	c.kifuId = f.String("kifu-id", "", "Load the kifu from DB instead of the file")
+	c.userId = f.String("user-id", "", "User ID for same position search (default: owner of the kifu)")
+	c.dbFlags.SetFlags(f)
+	c.steps = f.Int("steps", 5, "Number of steps shown in same position search")
+	c.ansi = f.Bool("color", true, "Highlight with ANSI escape sequences")
+}
+
+type viewer struct {
+	kifu  *documentpb.Kifu
+	steps []*documentpb.Step
+	moves []string
+
+	cur   int
+	notes bool
+	ansi  bool
+
+	db       func() db.DB
+	userId   string
+	numSteps int
+
+	out io.Writer
+}
+
+// moveNames returns the moves of the steps in KIF notation.
+func moveNames(steps []*documentpb.Step) []string {
+	ret := make([]string, len(steps))
+	var prev *shogi.Move
+	for i, step := range steps {
+		switch {
+		case i == 0:
+			ret[i] = "開始局面"
+			continue
+		case step.GetFinishedStatus() != documentpb.FinishedStatus_NOT_FINISHED:
+			ret[i] = step.GetFinishedStatus().String()
+			continue
+		}
+
+		m, err := shogi.ParseUSI(step.GetSfen())
+		if err != nil {
+			ret[i] = step.GetSfen()
+			continue
+		}
+		p, err := shogi.FromSFEN(steps[i-1].GetPosition())
+		if err != nil {
+			ret[i] = step.GetSfen()
+			continue
+		}
+		mark := "☗"
+		if p.Side == shogi.White {
+			mark = "☖"
+		}
+		ret[i] = mark + tsume.MoveString(p, m, prev)
+		prev = &m
+	}
+	return ret
+}
+
+func (v *viewer) show() {
+	step := v.steps[v.cur]
+
+	r, err := render.New(step.GetPosition(), render.StepOptions(v.kifu, step)...)
+	if err != nil {
+		fmt.Fprintf(v.out, "render.New: %v\n", err)
+		return
+	}
+	fmt.Fprintln(v.out)
+	if err := r.WriteText(v.out, v.ansi); err != nil {
+		log.Fatalf("WriteText: %v", err)
+	}
+
+	fmt.Fprintf(v.out, "%d/%d %s\n", v.cur, len(v.steps)-1, v.moves[v.cur])
+	if v.notes {
+		for _, note := range step.GetNotes() {
+			fmt.Fprintf(v.out, "  %s\n", note)
+		}
+	} else if len(step.GetNotes()) != 0 {
+		fmt.Fprintf(v.out, "  (%d notes)\n", len(step.GetNotes()))
+	}
+}
+
+func (v *viewer) list() {
+	from, to := v.cur-5, v.cur+5
+	if from < 0 {
+		from = 0
+	}
+	if to >= len(v.steps) {
+		to = len(v.steps) - 1
+	}
+	for i := from; i <= to; i++ {
+		mark := " "
+		if i == v.cur {
+			mark = ">"
+		}
+		fmt.Fprintf(v.out, "%s %3d %s\n", mark, i, v.moves[i])
+	}
+}
+
+func (v *viewer) same(ctx context.Context) {
+	if v.db == nil {
+		fmt.Fprintln(v.out, "same position search requires -kifu-id or -user-id")
+		return
+	}
+
+	ps, err := v.db().GetSamePositions(ctx, []string{v.userId}, v.steps[v.cur].GetPosition(),
+		db.GetSamePositionsSetNumStep(int32(v.numSteps)),
+		db.GetSamePositionsAddExcludeKifuId(v.kifu.GetKifuId()),
+	)
+	if err != nil {
+		fmt.Fprintf(v.out, "GetSamePositions: %v\n", err)
+		return
+	}
+	if len(ps) == 0 {
+		fmt.Fprintln(v.out, "no same positions")
+		return
+	}
+	for _, p := range ps {
+		// the first step is the same position.
+		ms := moveNames(p.Steps)
+		if len(ms) != 0 {
+			ms = ms[1:]
+		}
+		fmt.Fprintf(v.out, "%s %d: %s\n", p.KifuId, p.Seq, strings.Join(ms, " "))
+	}
+}
+
+func (v *viewer) move(n int) {
+	v.cur += n
+	if v.cur < 0 {
+		v.cur = 0
+	}
+	if v.cur >= len(v.steps) {
+		v.cur = len(v.steps) - 1
+	}
+}
+
+func count(fields []string) (int, error) {
+	if len(fields) < 2 {
+		return 1, nil
+	}
+	return strconv.Atoi(fields[1])
+}
+
+// run reads the commands from r until EOF or quit.
+func (v *viewer) run(ctx context.Context, r io.Reader) {
+	v.show()
+
+	s := bufio.NewScanner(r)
+	for fmt.Fprint(v.out, "> "); s.Scan(); fmt.Fprint(v.out, "> ") {
+		fields := strings.Fields(s.Text())
+		cmd := "next"
+		if len(fields) != 0 {
+			cmd = fields[0]
+		}
+
+		switch cmd {
+		case "n", "next", "p", "prev":
+			n, err := count(fields)
+			if err != nil {
+				fmt.Fprintf(v.out, "invalid number: %v\n", fields[1])
+				continue
+			}
+			if cmd == "p" || cmd == "prev" {
+				n = -n
+			}
+			v.move(n)
+			v.show()
+		case "j", "jump":
+			if len(fields) < 2 {
+				fmt.Fprintln(v.out, "jump requires SEQ")
+				continue
+			}
+			seq, err := strconv.Atoi(fields[1])
+			if err != nil || seq < 0 || seq >= len(v.steps) {
+				fmt.Fprintf(v.out, "invalid seq: %v\n", fields[1])
+				continue
+			}
+			v.cur = seq
+			v.show()
+		case "first":
+			v.cur = 0
+			v.show()
+		case "last":
+			v.cur = len(v.steps) - 1
+			v.show()
+		case "l", "list":
+			v.list()
+		case "notes":
+			v.notes = !v.notes
+			v.show()
+		case "s", "same":
+			v.same(ctx)
+		case "h", "help":
+			fmt.Fprint(v.out, help)
+		case "q", "quit":
+			return
+		default:
+			fmt.Fprintf(v.out, "unknown command: %v (h for help)\n", cmd)
+		}
+	}
+}
+
+func (c *Command) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	cfg := args[0].(map[string]string)
+
+	var dbFunc func() db.DB
+	if *c.kifuId != "" || *c.userId != "" {
+		dbFunc = c.dbFlags.DBFunc(cfg)
+	}
+
+	var (
+		k     *documentpb.Kifu
+		steps []*documentpb.Step
+	)
+	if *c.kifuId != "" {
+		var err error
+		k, steps, _, err = dbFunc().GetKifuAndSteps(ctx, *c.kifuId)
+		if err != nil {
+			log.Fatalf("GetKifuAndSteps: %v", err)
+		}
+	} else {
+		if f.NArg() != 1 {
+			log.Fatalf("KIF file or kifu-id is required")
+		}
+
+		loc, err := time.LoadLocation(*c.tz)
+		if err != nil {
+			log.Fatalf("LoadLocation: %v", err)
+		}
+
+		file, err := os.Open(f.Arg(0))
+		if err != nil {
+			log.Fatalf("Open: %v", err)
+		}
+		defer file.Close()
+
+		var opts []kif.ParseOption
+		if *c.utf8 {
+			opts = append(opts, kif.ParseEncodingUTF8())
+		}
+
+		p := kifu.NewParser(kif.NewParser(opts...), loc)
+		k, steps, err = p.Parse(file, *c.userId, "")
+		if err != nil {
+			log.Fatalf("kifu.Parse: %v", err)
+		}
+	}
+	if len(steps) == 0 {
+		log.Fatalf("no steps")
+	}
+
+	userId := *c.userId
+	if userId == "" {
+		userId = k.GetUserId()
+	}
+
+	v := &viewer{
+		kifu:     k,
+		steps:    steps,
+		moves:    moveNames(steps),
+		ansi:     *c.ansi,
+		db:       dbFunc,
+		userId:   userId,
+		numSteps: *c.steps,
+		out:      os.Stdout,
+	}
+	v.run(ctx, os.Stdin)
+
+	return subcommands.ExitSuccess
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -14,6 +16,7 @@ import (
 
 	"github.com/yunomu/kansousen/lib/db"
 	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+	"github.com/yunomu/kansousen/lib/senkei"
 
 	"github.com/yunomu/kansousen/lambda/kifu/service"
 )
@@ -46,11 +49,77 @@ func main() {
 		zap.String("table_name", kifuTable),
 	)
 
+	var dbOpts []db.DynamoDBOption
+	if s := os.Getenv("TRASH_RETENTION"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			zap.L().Fatal("ParseDuration", zap.String("key", "TRASH_RETENTION"), zap.Error(err))
+		}
+		dbOpts = append(dbOpts, db.SetTrashRetention(d))
+	}
+
+	var svcOpts []service.ServiceOption
+	if path := os.Getenv("SENKEI_RULES"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			zap.L().Fatal("Open", zap.String("key", "SENKEI_RULES"), zap.Error(err))
+		}
+		rules, err := senkei.LoadRules(f)
+		f.Close()
+		if err != nil {
+			zap.L().Fatal("LoadRules", zap.String("path", path), zap.Error(err))
+		}
+		svcOpts = append(svcOpts, service.WithClassifier(senkei.NewClassifier(rules, senkei.DefaultRules())))
+	}
+
 	dynamodb := dynamodb.New(session, aws.NewConfig().WithRegion(region))
-	table := db.NewDynamoDB(dynamodb, kifuTable)
-	svc := service.NewService(table)
+	table := db.NewDynamoDB(dynamodb, kifuTable, dbOpts...)
+	svc := service.NewService(table, svcOpts...)
 
-	h := lambdarpc.NewHandler(svc)
+	interceptors := []lambdarpc.UnaryInterceptor{
+		lambdarpc.LoggingInterceptor(func(ctx context.Context, l *lambdarpc.CallLog) {
+			fields := []zap.Field{
+				zap.String("method", l.Method),
+				zap.String("request_id", l.RequestId),
+				zap.String("api_request_id", l.ApiRequestId),
+				zap.String("user_id", l.UserId),
+				zap.Duration("duration", l.Duration),
+			}
+			if l.Err != nil {
+				zap.L().Error("Call", append(fields, zap.Error(l.Err))...)
+				return
+			}
+			zap.L().Info("Call", fields...)
+		}),
+		lambdarpc.RecoveryInterceptor(),
+		lambdarpc.ValidationInterceptor(),
+	}
+	if s := os.Getenv("REQUEST_TIMEOUT"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			zap.L().Fatal("ParseDuration", zap.String("key", "REQUEST_TIMEOUT"), zap.Error(err))
+		}
+		interceptors = append(interceptors, lambdarpc.TimeoutInterceptor(d))
+	}
+
+	h := lambdarpc.NewHandler(svc, lambdarpc.WithUnaryInterceptors(interceptors...))
+
+	// serve over HTTP instead of the Lambda runtime, e.g. in a container.
+	// the client context is trusted, so only the gateway sharing HTTP_SECRET must reach it.
+	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
+		secret := os.Getenv("HTTP_SECRET")
+		if secret == "" {
+			zap.L().Fatal("Getenv", zap.String("key", "HTTP_SECRET"))
+		}
+		if err := h.Init(); err != nil {
+			zap.L().Fatal("Init", zap.Error(err))
+		}
+		zap.L().Info("Listen", zap.String("addr", addr))
+		if err := http.ListenAndServe(addr, lambdarpc.HTTPHandler(h, lambdarpc.HTTPRequireSecret(secret))); err != nil {
+			zap.L().Fatal("ListenAndServe", zap.Error(err))
+		}
+		return
+	}
 
 	lambda.StartHandlerWithContext(ctx, h)
 }
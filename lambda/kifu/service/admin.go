@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yunomu/kansousen/lib/db"
+	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+const (
+	// AdminGroup is the Cognito group of the administrators.
+	AdminGroup = "admin"
+	// AdminScope is the OAuth scope of the administrative clients.
+	AdminScope = "kansousen/admin"
+
+	maxBulkDeleteKifu = 1000
+	maxReindexKifu    = 1000
+)
+
+var adminPermission = &lambdarpc.Permission{
+	Groups: []string{AdminGroup},
+	Scopes: []string{AdminScope},
+}
+
+// Permissions restricts the administrative methods, which operate on the kifus of other users.
+func (s *Service) Permissions() map[string]*lambdarpc.Permission {
+	return map[string]*lambdarpc.Permission{
+		"BulkDeleteKifu": adminPermission,
+		"ListUserKifu":   adminPermission,
+		"ReindexKifu":    adminPermission,
+	}
+}
+
+// targetKifu returns the deduplicated kifuIds and all kifus of the user.
+// The versions of the kifus of the user are returned, and -1 for the others.
+func (s *Service) targetKifu(ctx context.Context, kifuIds []string, userId string) ([]string, map[string]int64, error) {
+	versions := map[string]int64{}
+	var ret []string
+	for _, kifuId := range kifuIds {
+		if _, ok := versions[kifuId]; ok {
+			continue
+		}
+		versions[kifuId] = -1
+		ret = append(ret, kifuId)
+	}
+
+	if userId != "" {
+		if err := s.table.ListKifu(ctx, userId, func(kifu *documentpb.Kifu, version int64) {
+			if _, ok := versions[kifu.GetKifuId()]; !ok {
+				ret = append(ret, kifu.GetKifuId())
+			}
+			versions[kifu.GetKifuId()] = version
+		}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return ret, versions, nil
+}
+
+var errKifuNotFound = errors.New("kifu not found")
+
+// kifuVersion returns the current version of the kifu including trashed one.
+func (s *Service) kifuVersion(ctx context.Context, kifuId string) (int64, error) {
+	kifu, version, err := s.table.GetKifu(ctx, kifuId, db.GetKifuIncludeTrashed())
+	if err != nil {
+		return 0, err
+	}
+	// GetKifu returns the empty kifu if it does not exist.
+	if kifu.GetKifuId() == "" {
+		return 0, errKifuNotFound
+	}
+	return version, nil
+}
+
+// ListUserKifu returns the recent kifus of any user.
+func (s *Service) ListUserKifu(ctx context.Context, req *kifupb.ListUserKifuRequest) (*kifupb.ListUserKifuResponse, error) {
+	kifus, err := s.table.GetRecentKifu(ctx, req.GetUserId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.GetRecentKifu",
+			Err:     err,
+		}
+	}
+
+	var ret []*kifupb.RecentKifuResponse_Kifu
+	for _, kifu := range kifus {
+		ret = append(ret, toRecentKifu(kifu))
+	}
+
+	return &kifupb.ListUserKifuResponse{
+		Kifus: ret,
+	}, nil
+}
+
+// ReindexKifu recomputes the index keys of the kifus regardless of the owners.
+func (s *Service) ReindexKifu(ctx context.Context, req *kifupb.ReindexKifuRequest) (*kifupb.ReindexKifuResponse, error) {
+	kifuIds, versions, err := s.targetKifu(ctx, req.GetKifuIds(), req.GetUserId())
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.ListKifu",
+			Err:     err,
+		}
+	}
+
+	if len(kifuIds) > maxReindexKifu {
+		return nil, &lambdarpc.ClientError{
+			Message: "too many kifus",
+		}
+	}
+
+	res := &kifupb.ReindexKifuResponse{}
+	fail := func(kifuId string, err error) {
+		res.Failures = append(res.Failures, &kifupb.ReindexKifuResponse_Failure{
+			KifuId:  kifuId,
+			Message: err.Error(),
+		})
+	}
+	for _, kifuId := range kifuIds {
+		version := versions[kifuId]
+		if version < 0 {
+			v, err := s.kifuVersion(ctx, kifuId)
+			if err != nil {
+				fail(kifuId, err)
+				continue
+			}
+			version = v
+		}
+
+		if _, err := s.table.ReindexKifu(ctx, kifuId, version); err != nil {
+			fail(kifuId, err)
+			continue
+		}
+		res.ReindexedKifuIds = append(res.ReindexedKifuIds, kifuId)
+	}
+
+	return res, nil
+}
+
+// BulkDeleteKifu deletes the kifus regardless of the owners.
+func (s *Service) BulkDeleteKifu(ctx context.Context, req *kifupb.BulkDeleteKifuRequest) (*kifupb.BulkDeleteKifuResponse, error) {
+	kifuIds, versions, err := s.targetKifu(ctx, req.GetKifuIds(), req.GetUserId())
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.ListKifu",
+			Err:     err,
+		}
+	}
+
+	if len(kifuIds) > maxBulkDeleteKifu {
+		return nil, &lambdarpc.ClientError{
+			Message: "too many kifus",
+		}
+	}
+
+	res := &kifupb.BulkDeleteKifuResponse{}
+	fail := func(kifuId string, err error) {
+		res.Failures = append(res.Failures, &kifupb.BulkDeleteKifuResponse_Failure{
+			KifuId:  kifuId,
+			Message: err.Error(),
+		})
+	}
+	for _, kifuId := range kifuIds {
+		version := versions[kifuId]
+		if version < 0 {
+			v, err := s.kifuVersion(ctx, kifuId)
+			if err != nil {
+				fail(kifuId, err)
+				continue
+			}
+			version = v
+		}
+
+		var err error
+		if req.GetSoft() {
+			_, err = s.table.TrashKifu(ctx, kifuId, version)
+		} else {
+			err = s.table.DeleteKifu(ctx, kifuId, version)
+		}
+		if err != nil {
+			fail(kifuId, err)
+			continue
+		}
+		res.DeletedKifuIds = append(res.DeletedKifuIds, kifuId)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"github.com/yunomu/kansousen/lib/impasse"
+	"github.com/yunomu/kansousen/lib/shogi"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+// setImpasse sets the entering king points if the game ended in the entering king situation.
+func setImpasse(res *kifupb.GetKifuResponse, steps []*documentpb.Step) error {
+	ok, err := impasse.EndsInImpasse(steps)
+	if err != nil || !ok {
+		return err
+	}
+
+	points, err := impasse.Timeline(steps)
+	if err != nil {
+		return err
+	}
+	for _, pt := range points {
+		res.ImpassePoints = append(res.ImpassePoints, &kifupb.GetKifuResponse_ImpassePoints{
+			Seq:          pt.Seq,
+			BlackEntered: pt.Black.KingEntered,
+			BlackPieces:  int32(pt.Black.Pieces),
+			BlackPoints:  int32(pt.Black.Points),
+			BlackTotal:   int32(pt.Black.Total),
+			WhiteEntered: pt.White.KingEntered,
+			WhitePieces:  int32(pt.White.Pieces),
+			WhitePoints:  int32(pt.White.Points),
+			WhiteTotal:   int32(pt.White.Total),
+			Declarable:   pt.Declarable,
+		})
+	}
+
+	p, err := shogi.FromSFEN(steps[len(steps)-1].GetPosition())
+	if err != nil {
+		return err
+	}
+	if r := impasse.Judge24(p); r != impasse.ResultNone {
+		res.ImpasseResult = r.String()
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+	"github.com/yunomu/kansousen/lib/pattern"
+	"github.com/yunomu/kansousen/lib/position"
+	"github.com/yunomu/kansousen/lib/senkei"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+const (
+	defaultSearchPatternLimit = 20
+	// maxSearchPatternScan is the maximum number of kifus whose steps are read in a request.
+	maxSearchPatternScan = 200
+)
+
+// patternCursor is the last searched kifu, in the order of searchOrder.
+type patternCursor struct {
+	createdTs int64
+	kifuId    string
+}
+
+func (c *patternCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", c.createdTs, c.kifuId)))
+}
+
+func decodePatternCursor(token string) (*patternCursor, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := strings.SplitN(string(bs), ":", 2)
+	if len(fs) != 2 {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	ts, err := strconv.ParseInt(fs[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &patternCursor{createdTs: ts, kifuId: fs[1]}, nil
+}
+
+// searchOrder reports whether kifu a is searched before b, the most recent first.
+func searchOrder(aTs int64, aId string, bTs int64, bId string) bool {
+	if aTs != bTs {
+		return aTs > bTs
+	}
+	return aId < bId
+}
+
+func patternFromRequest(req *kifupb.SearchPatternRequest) *pattern.Pattern {
+	pat := &pattern.Pattern{
+		Hands: make(map[string]int),
+	}
+	for _, sq := range req.GetSquares() {
+		pat.Squares = append(pat.Squares, &pattern.Square{
+			X:     sq.GetX(),
+			Y:     sq.GetY(),
+			Piece: sq.GetPiece(),
+		})
+	}
+	for _, h := range req.GetHands() {
+		pat.Hands[h.GetPiece()] = int(h.GetMin())
+	}
+	return pat
+}
+
+// matchSteps returns the first step which matches one of the patterns and the index of the pattern.
+func matchSteps(pats []*pattern.Pattern, steps []*documentpb.Step) (*documentpb.Step, int) {
+	for _, step := range steps {
+		var pos *position.Position
+		for i, pat := range pats {
+			if !pat.MayMatch(step.GetFeatures()) {
+				continue
+			}
+
+			if pos == nil {
+				p, err := position.Parse(step.GetPosition())
+				if err != nil {
+					break
+				}
+				pos = p
+			}
+
+			if pat.Match(pos) {
+				return step, i
+			}
+		}
+	}
+
+	return nil, -1
+}
+
+// SearchPattern finds the caller's kifus in which the partial board appeared.
+func (s *Service) SearchPattern(ctx context.Context, req *kifupb.SearchPatternRequest) (*kifupb.SearchPatternResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+	if userId == "" {
+		return nil, &lambdarpc.ClientError{
+			Message: "user-id is not found",
+		}
+	}
+
+	pat := patternFromRequest(req)
+	if err := pat.Validate(); err != nil {
+		return nil, &lambdarpc.ClientError{
+			Message: "invalid pattern",
+			Err:     err,
+		}
+	}
+	pats := []*pattern.Pattern{pat}
+	if req.GetEitherSide() {
+		pats = append(pats, pat.ColorFlipped())
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultSearchPatternLimit
+	}
+
+	var candidates []*documentpb.Kifu
+	if err := s.table.ListKifu(ctx, userId, func(kifu *documentpb.Kifu, _ int64) {
+		if req.GetStrategy() != "" && !senkei.HasStrategy(kifu, req.GetStrategy()) {
+			return
+		}
+
+		for _, pat := range pats {
+			if pat.MayMatch(kifu.GetFeatures()) {
+				candidates = append(candidates, kifu)
+				return
+			}
+		}
+	}); err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.ListKifu",
+			Err:     err,
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		return searchOrder(a.GetCreatedTs(), a.GetKifuId(), b.GetCreatedTs(), b.GetKifuId())
+	})
+
+	start := 0
+	if token := req.GetPageToken(); token != "" {
+		cur, err := decodePatternCursor(token)
+		if err != nil {
+			return nil, &lambdarpc.ClientError{
+				Message: "invalid page token",
+				Err:     err,
+			}
+		}
+		start = sort.Search(len(candidates), func(i int) bool {
+			k := candidates[i]
+			return searchOrder(cur.createdTs, cur.kifuId, k.GetCreatedTs(), k.GetKifuId())
+		})
+	}
+
+	var matches []*kifupb.SearchPatternResponse_Match
+	var next string
+	for i := start; i < len(candidates); i++ {
+		if len(matches) == limit || i-start == maxSearchPatternScan {
+			last := candidates[i-1]
+			next = (&patternCursor{createdTs: last.GetCreatedTs(), kifuId: last.GetKifuId()}).encode()
+			break
+		}
+
+		kifu := candidates[i]
+		_, steps, _, err := s.table.GetKifuAndSteps(ctx, kifu.GetKifuId())
+		if err != nil {
+			return nil, &lambdarpc.InternalError{
+				Message: "db.GetKifuAndSteps",
+				Err:     err,
+			}
+		}
+
+		step, n := matchSteps(pats, steps)
+		if step == nil {
+			continue
+		}
+
+		rk := toRecentKifu(kifu)
+		matches = append(matches, &kifupb.SearchPatternResponse_Match{
+			KifuId:       kifu.GetKifuId(),
+			Seq:          step.GetSeq(),
+			ColorFlipped: n == 1,
+
+			StartTs:       kifu.GetStartTs(),
+			GameName:      kifu.GetGameName(),
+			FirstPlayers:  rk.GetFirstPlayers(),
+			SecondPlayers: rk.GetSecondPlayers(),
+		})
+	}
+
+	return &kifupb.SearchPatternResponse{
+		Matches:       matches,
+		NextPageToken: next,
+	}, nil
+}
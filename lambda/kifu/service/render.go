@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/yunomu/kansousen/lib/db"
+	libkifu "github.com/yunomu/kansousen/lib/kifu"
+	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+	"github.com/yunomu/kansousen/lib/render"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+const (
+	maxRenderCellSize = 200
+
+	defaultAnimationDelayMs = 1000
+	maxAnimationFrames      = 200
+)
+
+// getReadableKifu returns the kifu and the steps which the caller can read.
+func (s *Service) getReadableKifu(ctx context.Context, kifuId, linkToken string) (*documentpb.Kifu, []*documentpb.Step, error) {
+	kifu, steps, _, err := s.table.GetKifuAndSteps(ctx, kifuId)
+	if err == db.ErrTrashed {
+		return nil, nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, nil, &lambdarpc.InternalError{
+			Message: "db.GetKifuAndSteps",
+			Err:     err,
+		}
+	}
+	if kifu == nil || libkifu.GetAccess(kifu, lambdarpc.GetUserId(ctx), linkToken) == libkifu.AccessNone {
+		return nil, nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+		}
+	}
+
+	return kifu, steps, nil
+}
+
+func (s *Service) RenderPosition(ctx context.Context, req *kifupb.RenderPositionRequest) (*kifupb.RenderPositionResponse, error) {
+	if n := req.GetCellSize(); n < 0 || n > maxRenderCellSize {
+		return nil, &lambdarpc.ClientError{
+			Message: "cell_size is out of range",
+		}
+	}
+
+	kifu, steps, err := s.getReadableKifu(ctx, req.GetKifuId(), req.GetLinkToken())
+	if err != nil {
+		return nil, err
+	}
+
+	var step *documentpb.Step
+	for _, st := range steps {
+		if st.GetSeq() == req.GetSeq() {
+			step = st
+			break
+		}
+	}
+	if step == nil {
+		return nil, &lambdarpc.ClientError{
+			Message: "step not found",
+		}
+	}
+
+	opts := render.StepOptions(kifu, step)
+	if n := req.GetCellSize(); n != 0 {
+		opts = append(opts, render.SetCellSize(int(n)))
+	}
+	for _, a := range req.GetArrows() {
+		opts = append(opts, render.AddArrow(a.GetFrom().GetX(), a.GetFrom().GetY(), a.GetTo().GetX(), a.GetTo().GetY()))
+	}
+
+	r, err := render.New(step.GetPosition(), opts...)
+	if errors.Is(err, render.ErrInvalidSquare) {
+		return nil, &lambdarpc.ClientError{
+			Message: "invalid arrow",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "render.New",
+			Err:     err,
+		}
+	}
+
+	var buf bytes.Buffer
+	var contentType string
+	switch req.GetFormat() {
+	case "", "SVG":
+		contentType = "image/svg+xml"
+		err = r.WriteSVG(&buf)
+	case "PNG":
+		contentType = "image/png"
+		err = r.WritePNG(&buf)
+	default:
+		return nil, &lambdarpc.ClientError{
+			Message: "UnknownFormatError",
+		}
+	}
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "render",
+			Err:     err,
+		}
+	}
+
+	return &kifupb.RenderPositionResponse{
+		ContentType: contentType,
+		Data:        base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+func (s *Service) ExportAnimation(ctx context.Context, req *kifupb.ExportAnimationRequest) (*kifupb.ExportAnimationResponse, error) {
+	if n := req.GetCellSize(); n < 0 || n > maxRenderCellSize {
+		return nil, &lambdarpc.ClientError{
+			Message: "cell_size is out of range",
+		}
+	}
+	delay := req.GetDelayMs()
+	if delay == 0 {
+		delay = defaultAnimationDelayMs
+	}
+	if delay < 0 {
+		return nil, &lambdarpc.ClientError{
+			Message: "delay_ms is out of range",
+		}
+	}
+
+	kifu, steps, err := s.getReadableKifu(ctx, req.GetKifuId(), req.GetLinkToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if n := render.FrameCount(steps, req.GetStartSeq(), req.GetEndSeq()); n == 0 || n > maxAnimationFrames {
+		return nil, &lambdarpc.ClientError{
+			Message: "the number of the frames is out of range",
+		}
+	}
+
+	var opts []render.Option
+	if n := req.GetCellSize(); n != 0 {
+		opts = append(opts, render.SetCellSize(int(n)))
+	}
+	frames, err := render.Frames(kifu, steps, req.GetStartSeq(), req.GetEndSeq(), req.GetNotes(), opts...)
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "render.Frames",
+			Err:     err,
+		}
+	}
+
+	res := &kifupb.ExportAnimationResponse{}
+	var buf bytes.Buffer
+	switch req.GetFormat() {
+	case "", "GIF":
+		res.ContentType = "image/gif"
+		// GIF delay is in 1/100 seconds. 0 is played as fast as possible.
+		cs := int((delay + 5) / 10)
+		if cs < 1 {
+			cs = 1
+		}
+		if err := render.WriteGIF(&buf, frames, cs); err != nil {
+			return nil, &lambdarpc.InternalError{
+				Message: "render.WriteGIF",
+				Err:     err,
+			}
+		}
+		res.Data = base64.StdEncoding.EncodeToString(buf.Bytes())
+	case "PNG", "SVG":
+		res.ContentType = "image/png"
+		if req.GetFormat() == "SVG" {
+			res.ContentType = "image/svg+xml"
+		}
+		for _, f := range frames {
+			buf.Reset()
+			if req.GetFormat() == "SVG" {
+				err = f.WriteSVG(&buf)
+			} else {
+				err = f.WritePNG(&buf)
+			}
+			if err != nil {
+				return nil, &lambdarpc.InternalError{
+					Message: "render",
+					Err:     err,
+				}
+			}
+			res.Frames = append(res.Frames, base64.StdEncoding.EncodeToString(buf.Bytes()))
+		}
+	default:
+		return nil, &lambdarpc.ClientError{
+			Message: "UnknownFormatError",
+		}
+	}
+
+	return res, nil
+}
@@ -2,6 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,6 +17,9 @@ import (
 	"github.com/yunomu/kansousen/lib/db"
 	libkifu "github.com/yunomu/kansousen/lib/kifu"
 	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+	"github.com/yunomu/kansousen/lib/position"
+	"github.com/yunomu/kansousen/lib/revision"
+	"github.com/yunomu/kansousen/lib/senkei"
 	documentpb "github.com/yunomu/kansousen/proto/document"
 	kifupb "github.com/yunomu/kansousen/proto/kifu"
 )
@@ -22,49 +30,153 @@ type KifuServiceError interface {
 }
 
 type Service struct {
-	table db.DB
+	table      db.DB
+	classifier *senkei.Classifier
 }
 
-func NewService(table db.DB) *Service {
-	return &Service{
+type ServiceOption func(*Service)
+
+// WithClassifier sets the opening classifier. The default is the built-in rules.
+func WithClassifier(c *senkei.Classifier) ServiceOption {
+	return func(s *Service) {
+		s.classifier = c
+	}
+}
+
+func NewService(table db.DB, opts ...ServiceOption) *Service {
+	s := &Service{
 		table: table,
 	}
+	for _, f := range opts {
+		f(s)
+	}
+	if s.classifier == nil {
+		s.classifier = senkei.NewClassifier(senkei.DefaultRules())
+	}
+
+	return s
+}
+
+func toRecentKifu(kifu *documentpb.Kifu) *kifupb.RecentKifuResponse_Kifu {
+	var firstPlayers, secondPlayers []string
+	for _, player := range kifu.Players {
+		switch player.Order {
+		case documentpb.Player_BLACK:
+			firstPlayers = append(firstPlayers, player.GetName())
+		case documentpb.Player_WHITE:
+			secondPlayers = append(secondPlayers, player.GetName())
+		}
+	}
+
+	return &kifupb.RecentKifuResponse_Kifu{
+		UserId:  kifu.GetUserId(),
+		KifuId:  kifu.GetKifuId(),
+		StartTs: kifu.GetStartTs(),
+
+		Handicap:      kifu.GetHandicap().String(),
+		GameName:      kifu.GetGameName(),
+		FirstPlayers:  firstPlayers,
+		SecondPlayers: secondPlayers,
+		Note:          kifu.GetNote(),
+		Tags:          kifu.GetTags(),
+
+		BlackStrategies: kifu.GetBlackStrategies(),
+		WhiteStrategies: kifu.GetWhiteStrategies(),
+		GameStrategies:  kifu.GetGameStrategies(),
+	}
+}
+
+func hasTags(kifu *documentpb.Kifu, tags []string) bool {
+	exists := make(map[string]struct{})
+	for _, tag := range kifu.GetTags() {
+		exists[tag] = struct{}{}
+	}
+	for _, tag := range tags {
+		if _, ok := exists[tag]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// recentTaggedKifu returns kifus which have all of the tags, newest first.
+func (s *Service) recentTaggedKifu(
+	ctx context.Context,
+	userId string,
+	tags []string,
+	limit int,
+	filter func(*documentpb.Kifu) bool,
+) ([]*documentpb.Kifu, error) {
+	var ret []*documentpb.Kifu
+	var token string
+	for {
+		kifus, next, err := s.table.ListKifuByTag(ctx, userId, tags[0], limit, token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, kifu := range kifus {
+			if !hasTags(kifu, tags[1:]) || !filter(kifu) {
+				continue
+			}
+
+			ret = append(ret, kifu)
+			if len(ret) == limit {
+				return ret, nil
+			}
+		}
+
+		if next == "" {
+			return ret, nil
+		}
+		token = next
+	}
 }
 
 func (s *Service) RecentKifu(ctx context.Context, req *kifupb.RecentKifuRequest) (*kifupb.RecentKifuResponse, error) {
 	userId := lambdarpc.GetUserId(ctx)
 
-	kifus, err := s.table.GetRecentKifu(ctx, userId, int(req.GetLimit()))
-	if err != nil {
-		return nil, &lambdarpc.InternalError{
-			Message: "db.GetRecentKifu",
-			Err:     err,
+	var tags []string
+	for _, tag := range req.GetTags() {
+		if tag := libkifu.NormalizeTag(tag); tag != "" {
+			tags = append(tags, tag)
 		}
 	}
 
-	var ret []*kifupb.RecentKifuResponse_Kifu
-	for _, kifu := range kifus {
-		var firstPlayers, secondPlayers []string
-		for _, player := range kifu.Players {
-			switch player.Order {
-			case documentpb.Player_BLACK:
-				firstPlayers = append(firstPlayers, player.GetName())
-			case documentpb.Player_WHITE:
-				secondPlayers = append(secondPlayers, player.GetName())
-			}
+	// the strategy is filtered before the limit is applied.
+	filter := func(kifu *documentpb.Kifu) bool {
+		return req.GetStrategy() == "" || senkei.HasStrategy(kifu, req.GetStrategy())
+	}
+
+	var kifus []*documentpb.Kifu
+	if len(tags) != 0 {
+		limit := int(req.GetLimit())
+		if limit <= 0 {
+			limit = defaultTagListLimit
 		}
 
-		ret = append(ret, &kifupb.RecentKifuResponse_Kifu{
-			UserId:  kifu.GetUserId(),
-			KifuId:  kifu.GetKifuId(),
-			StartTs: kifu.GetStartTs(),
+		ks, err := s.recentTaggedKifu(ctx, userId, tags, limit, filter)
+		if err != nil {
+			return nil, &lambdarpc.InternalError{
+				Message: "db.ListKifuByTag",
+				Err:     err,
+			}
+		}
+		kifus = ks
+	} else {
+		ks, err := s.table.GetRecentKifu(ctx, userId, int(req.GetLimit()), db.GetRecentKifuSetFilter(filter))
+		if err != nil {
+			return nil, &lambdarpc.InternalError{
+				Message: "db.GetRecentKifu",
+				Err:     err,
+			}
+		}
+		kifus = ks
+	}
 
-			Handicap:      kifu.GetHandicap().String(),
-			GameName:      kifu.GetGameName(),
-			FirstPlayers:  firstPlayers,
-			SecondPlayers: secondPlayers,
-			Note:          kifu.GetNote(),
-		})
+	var ret []*kifupb.RecentKifuResponse_Kifu
+	for _, kifu := range kifus {
+		ret = append(ret, toRecentKifu(kifu))
 	}
 
 	return &kifupb.RecentKifuResponse{
@@ -138,12 +250,80 @@ func (s *Service) PostKifu(
 	parser := libkifu.NewParser(kif.NewParser(parseOptions...), loc)
 
 	kifu, steps, err := parser.Parse(strings.NewReader(req.Payload), userId, kifuUUID.String())
-	if err != nil {
+	var illegal *libkifu.IllegalMoveError
+	if errors.As(err, &illegal) {
+		return nil, &lambdarpc.ClientError{
+			Message: "illegal move",
+			Err:     illegal,
+		}
+	} else if err != nil {
 		return nil, &lambdarpc.ClientError{
 			Message: "kif parse error",
 			Err:     err,
 		}
 	}
+	kifu.CreatedTs = time.Now().Unix()
+	s.classifier.Apply(kifu, steps)
+
+	if policy := req.GetDuplicatePolicy(); policy != kifupb.PostKifuRequest_CREATE && kifu.GetSfen() != "" {
+		existing, version, err := s.findDuplicate(ctx, userId, kifu.GetSfen())
+		if err != nil {
+			return nil, &lambdarpc.InternalError{
+				Message: "findDuplicate",
+				Err:     err,
+			}
+		}
+
+		if existing != nil {
+			existingId := existing.GetKifuId()
+			switch policy {
+			case kifupb.PostKifuRequest_REJECT:
+				return nil, &lambdarpc.ClientError{
+					Message: "DuplicateKifuError",
+					Err:     fmt.Errorf("kifu_id=%s", existingId),
+				}
+			case kifupb.PostKifuRequest_RETURN_EXISTING:
+				return &kifupb.PostKifuResponse{
+					KifuId:     existingId,
+					Version:    version,
+					Duplicated: true,
+				}, nil
+			case kifupb.PostKifuRequest_ALIAS:
+				libkifu.AddAliases(existing, kifuUUID.String())
+
+				version, err = s.table.UpdateKifu(ctx, existing, version)
+				if err == db.ErrLockError {
+					return nil, &lambdarpc.ClientError{
+						Message: "kifu was updated",
+						Err:     err,
+					}
+				} else if err != nil {
+					return nil, &lambdarpc.InternalError{
+						Message: "db.UpdateKifu",
+						Err:     err,
+					}
+				}
+
+				// GetKifu resolves the alias, so the returned id can be used too.
+				if err := s.table.PutAlias(ctx, kifuUUID.String(), existingId); err != nil {
+					return nil, &lambdarpc.InternalError{
+						Message: "db.PutAlias",
+						Err:     err,
+					}
+				}
+
+				return &kifupb.PostKifuResponse{
+					KifuId:     existingId,
+					Version:    version,
+					Duplicated: true,
+				}, nil
+			default:
+				return nil, &lambdarpc.ClientError{
+					Message: "UnknownDuplicatePolicyError",
+				}
+			}
+		}
+	}
 
 	version, err := s.table.PutKifu(ctx, kifu, steps, 0)
 	if err != nil {
@@ -159,7 +339,66 @@ func (s *Service) PostKifu(
 	}, nil
 }
 
+// findDuplicate returns the user's kifu which has the same moves and its version.
+// It returns nil if not found.
+func (s *Service) findDuplicate(ctx context.Context, userId, sfen string) (*documentpb.Kifu, int64, error) {
+	uks, err := s.table.GetKifuIdsBySfen(ctx, sfen, db.GetKifuIdsBySfenSetUserId(userId))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(uks) == 0 {
+		return nil, 0, nil
+	}
+
+	// the index is eventually consistent, so the kifu may be already deleted.
+	kifu, version, err := s.table.GetKifu(ctx, uks[0].KifuId)
+	if err == db.ErrTrashed {
+		return nil, 0, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+	if kifu.GetKifuId() == "" {
+		return nil, 0, nil
+	}
+
+	return kifu, version, nil
+}
+
 func (s *Service) DeleteKifu(ctx context.Context, req *kifupb.DeleteKifuRequest) (*kifupb.DeleteKifuResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	kifu, _, err := s.table.GetKifu(ctx, req.GetKifuId(), db.GetKifuIncludeTrashed())
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.GetKifu",
+			Err:     err,
+		}
+	}
+	if kifu.GetUserId() != userId {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+		}
+	}
+
+	if req.GetSoft() {
+		version, err := s.table.TrashKifu(ctx, req.GetKifuId(), req.GetVersion())
+		if err == db.ErrLockError {
+			return nil, &lambdarpc.ClientError{
+				Message: "kifu was updated",
+				Err:     err,
+			}
+		} else if err != nil {
+			return nil, &lambdarpc.InternalError{
+				Message: "db.TrashKifu",
+				Err:     err,
+			}
+		}
+
+		return &kifupb.DeleteKifuResponse{
+			Version: version,
+		}, nil
+	}
+
 	if err := s.table.DeleteKifu(ctx, req.GetKifuId(), req.GetVersion()); err != nil {
 		return nil, &lambdarpc.InternalError{
 			Message: "db.DeleteKifu",
@@ -170,17 +409,124 @@ func (s *Service) DeleteKifu(ctx context.Context, req *kifupb.DeleteKifuRequest)
 	return &kifupb.DeleteKifuResponse{}, nil
 }
 
+func (s *Service) ListTrash(ctx context.Context, req *kifupb.ListTrashRequest) (*kifupb.ListTrashResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	tks, err := s.table.ListTrash(ctx, userId)
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.ListTrash",
+			Err:     err,
+		}
+	}
+
+	var ret []*kifupb.ListTrashResponse_Kifu
+	for _, tk := range tks {
+		kifu := tk.Kifu
+
+		var firstPlayers, secondPlayers []string
+		for _, player := range kifu.Players {
+			switch player.Order {
+			case documentpb.Player_BLACK:
+				firstPlayers = append(firstPlayers, player.GetName())
+			case documentpb.Player_WHITE:
+				secondPlayers = append(secondPlayers, player.GetName())
+			}
+		}
+
+		ret = append(ret, &kifupb.ListTrashResponse_Kifu{
+			UserId:  kifu.GetUserId(),
+			KifuId:  kifu.GetKifuId(),
+			StartTs: kifu.GetStartTs(),
+
+			Handicap:      kifu.GetHandicap().String(),
+			GameName:      kifu.GetGameName(),
+			FirstPlayers:  firstPlayers,
+			SecondPlayers: secondPlayers,
+			Note:          kifu.GetNote(),
+			Version:       tk.Version,
+			TrashedTs:     tk.TrashedTs,
+			PurgeTs:       tk.PurgeTs,
+		})
+	}
+
+	return &kifupb.ListTrashResponse{
+		Kifus: ret,
+	}, nil
+}
+
+func (s *Service) RestoreKifu(ctx context.Context, req *kifupb.RestoreKifuRequest) (*kifupb.RestoreKifuResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	kifu, _, err := s.table.GetKifu(ctx, req.GetKifuId(), db.GetKifuIncludeTrashed())
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.GetKifu",
+			Err:     err,
+		}
+	}
+	if kifu.GetUserId() != userId {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+		}
+	}
+
+	version, err := s.table.RestoreKifu(ctx, req.GetKifuId(), req.GetVersion())
+	if err == db.ErrEmpty {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+			Err:     err,
+		}
+	} else if err == db.ErrLockError {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu was updated",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.RestoreKifu",
+			Err:     err,
+		}
+	}
+
+	return &kifupb.RestoreKifuResponse{
+		Version: version,
+	}, nil
+}
+
 func (s *Service) GetKifu(ctx context.Context, req *kifupb.GetKifuRequest) (*kifupb.GetKifuResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
 	kifu, steps, version, err := s.table.GetKifuAndSteps(ctx, req.GetKifuId())
-	if err != nil {
+	if err == db.ErrTrashed {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+			Err:     err,
+		}
+	} else if err != nil {
 		return nil, &lambdarpc.InternalError{
 			Message: "db.GetKifuAndSteps",
 			Err:     err,
 		}
 	}
+	access := libkifu.GetAccess(kifu, userId, req.GetLinkToken())
+	if kifu == nil || access == libkifu.AccessNone {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+		}
+	}
 
 	var resSteps []*kifupb.GetKifuResponse_Step
+	var castles []*kifupb.GetKifuResponse_Castle
 	for _, step := range steps {
+		for _, c := range step.GetCastles() {
+			castles = append(castles, &kifupb.GetKifuResponse_Castle{
+				Seq:    step.GetSeq(),
+				Player: c.GetPlayer().String(),
+				Name:   c.GetName(),
+			})
+		}
+
 		resStep := &kifupb.GetKifuResponse_Step{
 			Seq:          step.GetSeq(),
 			Position:     step.GetPosition(),
@@ -234,7 +580,7 @@ func (s *Service) GetKifu(ctx context.Context, req *kifupb.GetKifuRequest) (*kif
 		})
 	}
 
-	return &kifupb.GetKifuResponse{
+	res := &kifupb.GetKifuResponse{
 		UserId: kifu.GetUserId(),
 		KifuId: kifu.GetKifuId(),
 
@@ -250,7 +596,41 @@ func (s *Service) GetKifu(ctx context.Context, req *kifupb.GetKifuRequest) (*kif
 		Steps:         resSteps,
 		Note:          kifu.GetNote(),
 		Version:       version,
-	}, nil
+		Tags:          kifu.GetTags(),
+		Access:        access.String(),
+
+		BlackStrategies: kifu.GetBlackStrategies(),
+		WhiteStrategies: kifu.GetWhiteStrategies(),
+		GameStrategies:  kifu.GetGameStrategies(),
+		Castles:         castles,
+		Warnings:        kifu.GetWarnings(),
+	}
+	if rep := kifu.GetRepetition(); rep != nil {
+		res.Repetition = &kifupb.GetKifuResponse_Repetition{
+			Result: rep.GetResult().String(),
+			Seq:    rep.GetSeq(),
+		}
+		if rep.GetResult() == documentpb.Repetition_PERPETUAL_CHECK {
+			res.Repetition.CheckingPlayer = rep.GetCheckingPlayer().String()
+		}
+	}
+	if err := setImpasse(res, steps); err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "setImpasse",
+			Err:     err,
+		}
+	}
+	if access == libkifu.AccessOwner {
+		for _, share := range kifu.GetShares() {
+			res.Shares = append(res.Shares, &kifupb.Share{
+				UserId: share.GetUserId(),
+				Role:   share.GetRole().String(),
+			})
+		}
+		res.LinkToken = kifu.GetLinkToken()
+	}
+
+	return res, nil
 }
 
 func (s *Service) GetSamePositions(ctx context.Context, req *kifupb.GetSamePositionsRequest) (*kifupb.GetSamePositionsResponse, error) {
@@ -261,12 +641,61 @@ func (s *Service) GetSamePositions(ctx context.Context, req *kifupb.GetSamePosit
 		}
 	}
 
-	pss, err := s.table.GetSamePositions(ctx,
-		[]string{userId},
-		req.GetPosition(),
-		db.GetSamePositionsSetNumStep(req.GetSteps()),
+	userIds := []string{userId}
+	var accessOpts []libkifu.AccessOption
+	if req.GetTeamId() != "" {
+		t, _, err := s.getTeam(ctx, req.GetTeamId(), userId)
+		if err != nil {
+			return nil, err
+		}
+		userIds = t.GetMemberUserIds()
+		accessOpts = append(accessOpts, libkifu.AccessViaTeam(t))
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultSamePositionsLimit
+	}
+
+	pos, err := position.Parse(req.GetPosition())
+	if err != nil {
+		return nil, &lambdarpc.ClientError{
+			Message: "invalid position",
+			Err:     err,
+		}
+	}
+	// positions are stored with the move number 1
+	query := pos.String() + " 1"
+
+	var normOpt position.Option
+	if req.GetMatch() == kifupb.GetSamePositionsRequest_NORMALIZED {
+		if req.GetMirror() {
+			normOpt |= position.Mirror
+		}
+		if req.GetColorFlip() {
+			normOpt |= position.ColorFlip
+		}
+	}
+
+	opts := []db.GetSamePositionsOption{
 		db.GetSamePositionsAddExcludeKifuIds(req.GetExcludeKifuIds()),
-	)
+		db.GetSamePositionsSetLimit(limit),
+		db.GetSamePositionsSetNormalized(normOpt != 0),
+		db.GetSamePositionsSetFilter(func(kifu *documentpb.Kifu) bool {
+			if libkifu.GetAccess(kifu, userId, "", accessOpts...) == libkifu.AccessNone {
+				return false
+			}
+			if req.GetStrategy() != "" && !senkei.HasStrategy(kifu, req.GetStrategy()) {
+				return false
+			}
+			return true
+		}),
+	}
+	if req.GetSteps() > 0 {
+		opts = append(opts, db.GetSamePositionsSetNumStep(req.GetSteps()))
+	}
+
+	pss, err := s.table.GetSamePositions(ctx, userIds, query, opts...)
 	if err != nil {
 		return nil, &lambdarpc.InternalError{
 			Message: "GetSamePositions",
@@ -275,41 +704,519 @@ func (s *Service) GetSamePositions(ctx context.Context, req *kifupb.GetSamePosit
 	}
 
 	var kifus []*kifupb.GetSamePositionsResponse_Kifu
+	var matches []*samePosition
 	for _, ps := range pss {
-		var steps []*kifupb.GetSamePositionsResponse_Step
-		for _, step := range ps.Steps {
-			var src, dst *kifupb.Pos
-			if step.GetDst() != nil {
-				dst = &kifupb.Pos{
-					X: step.Dst.X,
-					Y: step.Dst.Y,
+		m := &samePosition{Position: ps}
+		if normOpt != 0 {
+			var ok bool
+			for _, step := range ps.Steps {
+				if step.GetSeq() == ps.Seq {
+					m.mirrored, m.flipped, ok = position.Relation(step.GetPosition(), query, normOpt)
+					break
 				}
 			}
-			if step.GetSrc() != nil {
-				src = &kifupb.Pos{
-					X: step.Src.X,
-					Y: step.Src.Y,
-				}
+			if !ok {
+				continue
 			}
-			steps = append(steps, &kifupb.GetSamePositionsResponse_Step{
-				Seq:            step.GetSeq(),
-				Dst:            dst,
-				Src:            src,
-				Piece:          kifupb.Piece_Id(step.GetPiece()),
-				Promoted:       step.Promote,
-				FinishedStatus: kifupb.FinishedStatus_Id(step.GetFinishedStatus()),
-			})
+		}
+		matches = append(matches, m)
+
+		var steps []*kifupb.GetSamePositionsResponse_Step
+		for _, step := range ps.Steps {
+			steps = append(steps, toSamePositionsStep(step))
 		}
 
 		kifus = append(kifus, &kifupb.GetSamePositionsResponse_Kifu{
-			UserId: ps.UserId,
-			KifuId: ps.KifuId,
-			Steps:  steps,
+			UserId:       ps.UserId,
+			KifuId:       ps.KifuId,
+			Seq:          ps.Seq,
+			Steps:        steps,
+			Mirrored:     m.mirrored,
+			ColorFlipped: m.flipped,
 		})
 	}
 
 	return &kifupb.GetSamePositionsResponse{
-		Position: req.GetPosition(),
-		Kifus:    kifus,
+		Position:      req.GetPosition(),
+		Kifus:         kifus,
+		Continuations: continuations(matches),
+	}, nil
+}
+
+type samePosition struct {
+	*db.Position
+
+	// transformations from the kifu to the requested position.
+	mirrored bool
+	flipped  bool
+}
+
+// transformPos converts pos of the kifu to the requested position.
+func (m *samePosition) transformPos(pos *kifupb.Pos) {
+	if pos == nil || pos.X == 0 {
+		return
+	}
+
+	if m.mirrored != m.flipped {
+		pos.X = 10 - pos.X
+	}
+	if m.flipped {
+		pos.Y = 10 - pos.Y
+	}
+}
+
+const defaultSamePositionsLimit = 100
+
+func toSamePositionsStep(step *documentpb.Step) *kifupb.GetSamePositionsResponse_Step {
+	var src, dst *kifupb.Pos
+	if step.GetDst() != nil {
+		dst = &kifupb.Pos{
+			X: step.Dst.X,
+			Y: step.Dst.Y,
+		}
+	}
+	if step.GetSrc() != nil {
+		src = &kifupb.Pos{
+			X: step.Src.X,
+			Y: step.Src.Y,
+		}
+	}
+	return &kifupb.GetSamePositionsResponse_Step{
+		Seq:            step.GetSeq(),
+		Dst:            dst,
+		Src:            src,
+		Piece:          kifupb.Piece_Id(step.GetPiece()),
+		Promoted:       step.Promote,
+		FinishedStatus: kifupb.FinishedStatus_Id(step.GetFinishedStatus()),
+	}
+}
+
+// continuations groups the next moves from the position.
+func continuations(pss []*samePosition) []*kifupb.GetSamePositionsResponse_Continuation {
+	var ret []*kifupb.GetSamePositionsResponse_Continuation
+	idx := make(map[string]int)
+	users := make(map[string]map[string]struct{})
+	for _, ps := range pss {
+		var next *documentpb.Step
+		for _, step := range ps.Steps {
+			if step.GetSeq() == ps.Seq+1 {
+				next = step
+				break
+			}
+		}
+		if next == nil {
+			continue
+		}
+
+		move := toSamePositionsStep(next)
+		move.Seq = 0
+		ps.transformPos(move.Src)
+		ps.transformPos(move.Dst)
+
+		key := fmt.Sprintf("%v:%v:%v:%v:%v",
+			move.GetSrc(), move.GetDst(), move.GetPiece(), move.GetPromoted(), move.GetFinishedStatus())
+		i, ok := idx[key]
+		if !ok {
+			i = len(ret)
+			idx[key] = i
+			users[key] = make(map[string]struct{})
+			ret = append(ret, &kifupb.GetSamePositionsResponse_Continuation{
+				Move: move,
+			})
+		}
+
+		c := ret[i]
+		c.Count++
+		if _, ok := users[key][ps.UserId]; !ok {
+			users[key][ps.UserId] = struct{}{}
+			c.UserIds = append(c.UserIds, ps.UserId)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].GetCount() > ret[j].GetCount()
+	})
+
+	return ret
+}
+
+func (s *Service) ListKifuRevisions(ctx context.Context, req *kifupb.ListKifuRevisionsRequest) (*kifupb.ListKifuRevisionsResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	kifu, steps, version, err := s.table.GetKifuAndSteps(ctx, req.GetKifuId())
+	if err == db.ErrTrashed {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.GetKifuAndSteps",
+			Err:     err,
+		}
+	}
+	if kifu == nil || kifu.GetUserId() != userId {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+		}
+	}
+
+	revs, err := s.table.ListKifuRevisions(ctx, req.GetKifuId())
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.ListKifuRevisions",
+			Err:     err,
+		}
+	}
+
+	next := revision.FromKifu(kifu, steps, version)
+	var ret []*kifupb.ListKifuRevisionsResponse_Revision
+	for _, rev := range revs {
+		var changes []*kifupb.ListKifuRevisionsResponse_Change
+		for _, c := range revision.Diff(rev, next) {
+			changes = append(changes, &kifupb.ListKifuRevisionsResponse_Change{
+				Field:    c.Field,
+				Seq:      c.Seq,
+				OldValue: c.OldValue,
+				NewValue: c.NewValue,
+			})
+		}
+
+		ret = append(ret, &kifupb.ListKifuRevisionsResponse_Revision{
+			Version:   rev.GetVersion(),
+			RevisedTs: rev.GetRevisedTs(),
+			Changes:   changes,
+		})
+
+		next = rev
+	}
+
+	return &kifupb.ListKifuRevisionsResponse{
+		Revisions: ret,
+		Version:   version,
+	}, nil
+}
+
+func (s *Service) RestoreKifuRevision(ctx context.Context, req *kifupb.RestoreKifuRevisionRequest) (*kifupb.RestoreKifuRevisionResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	kifu, steps, _, err := s.table.GetKifuAndSteps(ctx, req.GetKifuId())
+	if err == db.ErrTrashed {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.GetKifuAndSteps",
+			Err:     err,
+		}
+	}
+	if kifu == nil || kifu.GetUserId() != userId {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu not found",
+		}
+	}
+
+	rev, err := s.table.GetKifuRevision(ctx, req.GetKifuId(), req.GetRevision())
+	if err == db.ErrEmpty {
+		return nil, &lambdarpc.ClientError{
+			Message: "revision not found",
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.GetKifuRevision",
+			Err:     err,
+		}
+	}
+
+	restored := revision.Apply(rev, kifu, steps)
+
+	version, err := s.table.PutKifu(ctx, restored, steps, req.GetVersion())
+	if err == db.ErrLockError {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu was updated",
+			Err:     err,
+		}
+	} else if err == db.ErrTrashed {
+		return nil, &lambdarpc.ClientError{
+			Message: "kifu is trashed",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.PutKifu",
+			Err:     err,
+		}
+	}
+
+	return &kifupb.RestoreKifuRevisionResponse{
+		Version: version,
+	}, nil
+}
+
+const defaultTagListLimit = 20
+
+// updateKifu applies f to the kifu owned by the user. Steps are not changed.
+func (s *Service) updateKifu(
+	ctx context.Context,
+	kifuId string,
+	version int64,
+	f func(*documentpb.Kifu) error,
+) (int64, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	kifu, _, err := s.table.GetKifu(ctx, kifuId)
+	if err == db.ErrEmpty || err == db.ErrTrashed {
+		return 0, &lambdarpc.ClientError{
+			Message: "kifu not found",
+			Err:     err,
+		}
+	} else if err != nil {
+		return 0, &lambdarpc.InternalError{
+			Message: "db.GetKifu",
+			Err:     err,
+		}
+	}
+	if kifu.GetUserId() != userId {
+		return 0, &lambdarpc.ClientError{
+			Message: "kifu not found",
+		}
+	}
+
+	if err := f(kifu); err != nil {
+		return 0, err
+	}
+
+	newVersion, err := s.table.UpdateKifu(ctx, kifu, version)
+	switch err {
+	case nil:
+		return newVersion, nil
+	case db.ErrLockError:
+		return 0, &lambdarpc.ClientError{
+			Message: "kifu was updated",
+			Err:     err,
+		}
+	case db.ErrEmpty, db.ErrTrashed:
+		return 0, &lambdarpc.ClientError{
+			Message: "kifu not found",
+			Err:     err,
+		}
+	default:
+		return 0, &lambdarpc.InternalError{
+			Message: "db.UpdateKifu",
+			Err:     err,
+		}
+	}
+}
+
+func (s *Service) AddTags(ctx context.Context, req *kifupb.AddTagsRequest) (*kifupb.AddTagsResponse, error) {
+	version, err := s.updateKifu(ctx, req.GetKifuId(), req.GetVersion(), func(kifu *documentpb.Kifu) error {
+		libkifu.AddTags(kifu, req.GetTags()...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.AddTagsResponse{
+		Version: version,
+	}, nil
+}
+
+func (s *Service) RemoveTags(ctx context.Context, req *kifupb.RemoveTagsRequest) (*kifupb.RemoveTagsResponse, error) {
+	version, err := s.updateKifu(ctx, req.GetKifuId(), req.GetVersion(), func(kifu *documentpb.Kifu) error {
+		libkifu.RemoveTags(kifu, req.GetTags()...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.RemoveTagsResponse{
+		Version: version,
+	}, nil
+}
+
+func (s *Service) ListKifuByTag(ctx context.Context, req *kifupb.ListKifuByTagRequest) (*kifupb.ListKifuByTagResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	tag := libkifu.NormalizeTag(req.GetTag())
+	if tag == "" {
+		return nil, &lambdarpc.ClientError{
+			Message: "tag is required",
+		}
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultTagListLimit
+	}
+
+	kifus, next, err := s.table.ListKifuByTag(ctx, userId, tag, limit, req.GetPageToken())
+	if _, ok := err.(*db.ErrInvalidValue); ok {
+		return nil, &lambdarpc.ClientError{
+			Message: "invalid page_token",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.ListKifuByTag",
+			Err:     err,
+		}
+	}
+
+	var ret []*kifupb.RecentKifuResponse_Kifu
+	for _, kifu := range kifus {
+		ret = append(ret, toRecentKifu(kifu))
+	}
+
+	return &kifupb.ListKifuByTagResponse{
+		Kifus:         ret,
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *Service) ShareKifu(ctx context.Context, req *kifupb.ShareKifuRequest) (*kifupb.ShareKifuResponse, error) {
+	role, ok := documentpb.Share_Role_value[req.GetRole()]
+	if !ok {
+		return nil, &lambdarpc.ClientError{
+			Message: "invalid role",
+		}
+	}
+
+	version, err := s.updateKifu(ctx, req.GetKifuId(), req.GetVersion(), func(kifu *documentpb.Kifu) error {
+		if req.GetUserId() == "" || req.GetUserId() == kifu.GetUserId() {
+			return &lambdarpc.ClientError{
+				Message: "invalid user_id",
+			}
+		}
+
+		libkifu.SetShare(kifu, req.GetUserId(), documentpb.Share_Role(role))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.ShareKifuResponse{
+		Version: version,
+	}, nil
+}
+
+func (s *Service) UnshareKifu(ctx context.Context, req *kifupb.UnshareKifuRequest) (*kifupb.UnshareKifuResponse, error) {
+	version, err := s.updateKifu(ctx, req.GetKifuId(), req.GetVersion(), func(kifu *documentpb.Kifu) error {
+		libkifu.RemoveShare(kifu, req.GetUserId())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.UnshareKifuResponse{
+		Version: version,
+	}, nil
+}
+
+func newLinkToken() (string, error) {
+	bs := make([]byte, 24)
+	if _, err := rand.Read(bs); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bs), nil
+}
+
+// CreateKifuLink publishes the kifu. The previous link is revoked.
+func (s *Service) CreateKifuLink(ctx context.Context, req *kifupb.CreateKifuLinkRequest) (*kifupb.CreateKifuLinkResponse, error) {
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "newLinkToken",
+			Err:     err,
+		}
+	}
+
+	version, err := s.updateKifu(ctx, req.GetKifuId(), req.GetVersion(), func(kifu *documentpb.Kifu) error {
+		kifu.LinkToken = token
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.CreateKifuLinkResponse{
+		LinkToken: token,
+		Version:   version,
+	}, nil
+}
+
+func (s *Service) DeleteKifuLink(ctx context.Context, req *kifupb.DeleteKifuLinkRequest) (*kifupb.DeleteKifuLinkResponse, error) {
+	version, err := s.updateKifu(ctx, req.GetKifuId(), req.GetVersion(), func(kifu *documentpb.Kifu) error {
+		kifu.LinkToken = ""
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.DeleteKifuLinkResponse{
+		Version: version,
+	}, nil
+}
+
+// GetKifuStats counts the strategies of the caller's kifus.
+func (s *Service) GetKifuStats(ctx context.Context, req *kifupb.GetKifuStatsRequest) (*kifupb.GetKifuStatsResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+	if userId == "" {
+		return nil, &lambdarpc.ClientError{
+			Message: "user-id is not found",
+		}
+	}
+
+	var total int32
+	var stats []*kifupb.GetKifuStatsResponse_Strategy
+	idx := make(map[string]*kifupb.GetKifuStatsResponse_Strategy)
+	get := func(name string) *kifupb.GetKifuStatsResponse_Strategy {
+		st, ok := idx[name]
+		if !ok {
+			st = &kifupb.GetKifuStatsResponse_Strategy{
+				Name: name,
+			}
+			idx[name] = st
+			stats = append(stats, st)
+		}
+		return st
+	}
+	if err := s.table.ListKifu(ctx, userId, func(kifu *documentpb.Kifu, _ int64) {
+		if req.GetStrategy() != "" && !senkei.HasStrategy(kifu, req.GetStrategy()) {
+			return
+		}
+
+		total++
+		for _, name := range kifu.GetBlackStrategies() {
+			get(name).BlackCount++
+		}
+		for _, name := range kifu.GetWhiteStrategies() {
+			get(name).WhiteCount++
+		}
+		for _, name := range kifu.GetGameStrategies() {
+			get(name).GameCount++
+		}
+	}); err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.ListKifu",
+			Err:     err,
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		return a.BlackCount+a.WhiteCount+a.GameCount > b.BlackCount+b.WhiteCount+b.GameCount
+	})
+
+	return &kifupb.GetKifuStatsResponse{
+		Total:      total,
+		Strategies: stats,
 	}, nil
 }
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yunomu/kansousen/lib/db"
+	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+	"github.com/yunomu/kansousen/lib/team"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+func (s *Service) CreateTeam(ctx context.Context, req *kifupb.CreateTeamRequest) (*kifupb.CreateTeamResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+	if userId == "" {
+		return nil, &lambdarpc.ClientError{
+			Message: "user-id is not found",
+		}
+	}
+
+	teamUUID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "uuid.NewRandom",
+			Err:     err,
+		}
+	}
+
+	t := &documentpb.Team{
+		TeamId:        teamUUID.String(),
+		Name:          req.GetName(),
+		OwnerUserId:   userId,
+		MemberUserIds: []string{userId},
+		CreatedTs:     time.Now().Unix(),
+	}
+	team.Invite(t, req.GetInviteUserIds()...)
+
+	version, err := s.table.PutTeam(ctx, t, 0)
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.PutTeam",
+			Err:     err,
+		}
+	}
+
+	return &kifupb.CreateTeamResponse{
+		TeamId:  t.GetTeamId(),
+		Version: version,
+	}, nil
+}
+
+// getTeam returns the team which the user is a member of.
+func (s *Service) getTeam(ctx context.Context, teamId, userId string) (*documentpb.Team, int64, error) {
+	t, version, err := s.table.GetTeam(ctx, teamId)
+	if err == db.ErrEmpty {
+		return nil, 0, &lambdarpc.ClientError{
+			Message: "team not found",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, 0, &lambdarpc.InternalError{
+			Message: "db.GetTeam",
+			Err:     err,
+		}
+	}
+	if !team.IsMember(t, userId) {
+		return nil, 0, &lambdarpc.ClientError{
+			Message: "team not found",
+		}
+	}
+
+	return t, version, nil
+}
+
+func (s *Service) GetTeam(ctx context.Context, req *kifupb.GetTeamRequest) (*kifupb.GetTeamResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	if req.GetTeamId() == team.PublicPoolId {
+		return nil, &lambdarpc.ClientError{
+			Message: "members of the public pool are not listed",
+		}
+	}
+
+	t, version, err := s.getTeam(ctx, req.GetTeamId(), userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.GetTeamResponse{
+		TeamId:         t.GetTeamId(),
+		Name:           t.GetName(),
+		OwnerUserId:    t.GetOwnerUserId(),
+		MemberUserIds:  t.GetMemberUserIds(),
+		CreatedTs:      t.GetCreatedTs(),
+		Version:        version,
+		InvitedUserIds: t.GetInvitedUserIds(),
+	}, nil
+}
+
+func (s *Service) UpdateTeamMembers(ctx context.Context, req *kifupb.UpdateTeamMembersRequest) (*kifupb.UpdateTeamMembersResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	if req.GetTeamId() == team.PublicPoolId {
+		return nil, &lambdarpc.ClientError{
+			Message: "use JoinPublicPool or LeavePublicPool",
+		}
+	}
+
+	t, _, err := s.getTeam(ctx, req.GetTeamId(), userId)
+	if err != nil {
+		return nil, err
+	}
+	if t.GetOwnerUserId() != userId {
+		return nil, &lambdarpc.ClientError{
+			Message: "only the owner can update members",
+		}
+	}
+
+	team.Invite(t, req.GetInviteUserIds()...)
+	team.RemoveMembers(t, req.GetRemoveUserIds()...)
+
+	version, err := s.table.PutTeam(ctx, t, req.GetVersion())
+	if err == db.ErrLockError {
+		return nil, &lambdarpc.ClientError{
+			Message: "team was updated",
+			Err:     err,
+		}
+	} else if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "db.PutTeam",
+			Err:     err,
+		}
+	}
+
+	return &kifupb.UpdateTeamMembersResponse{
+		Version: version,
+	}, nil
+}
+
+const teamUpdateRetry = 3
+
+// updateTeam applies f to the team and puts it, retrying on the conflicts.
+func (s *Service) updateTeam(ctx context.Context, teamId string, f func(*documentpb.Team) error) (int64, error) {
+	var version int64
+	var err error
+	for i := 0; i < teamUpdateRetry; i++ {
+		t, v, gerr := s.table.GetTeam(ctx, teamId)
+		if gerr == db.ErrEmpty {
+			return 0, &lambdarpc.ClientError{
+				Message: "team not found",
+				Err:     gerr,
+			}
+		} else if gerr != nil {
+			return 0, &lambdarpc.InternalError{
+				Message: "db.GetTeam",
+				Err:     gerr,
+			}
+		}
+
+		if err := f(t); err != nil {
+			return 0, err
+		}
+
+		version, err = s.table.PutTeam(ctx, t, v)
+		if err != db.ErrLockError {
+			break
+		}
+	}
+	if err != nil {
+		return 0, &lambdarpc.InternalError{
+			Message: "db.PutTeam",
+			Err:     err,
+		}
+	}
+
+	return version, nil
+}
+
+// AcceptTeamInvitation makes the caller a member of the team which invited the caller.
+// The kifus of a member are searched by the other members.
+func (s *Service) AcceptTeamInvitation(ctx context.Context, req *kifupb.AcceptTeamInvitationRequest) (*kifupb.AcceptTeamInvitationResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	version, err := s.updateTeam(ctx, req.GetTeamId(), func(t *documentpb.Team) error {
+		if !team.Accept(t, userId) {
+			return &lambdarpc.ClientError{
+				Message: "invitation not found",
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kifupb.AcceptTeamInvitationResponse{
+		Version: version,
+	}, nil
+}
+
+func (s *Service) LeaveTeam(ctx context.Context, req *kifupb.LeaveTeamRequest) (*kifupb.LeaveTeamResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	if req.GetTeamId() == team.PublicPoolId {
+		return nil, &lambdarpc.ClientError{
+			Message: "use LeavePublicPool",
+		}
+	}
+
+	if _, err := s.updateTeam(ctx, req.GetTeamId(), func(t *documentpb.Team) error {
+		if !team.IsMember(t, userId) && !team.IsInvited(t, userId) {
+			return &lambdarpc.ClientError{
+				Message: "team not found",
+			}
+		}
+		if t.GetOwnerUserId() == userId {
+			return &lambdarpc.ClientError{
+				Message: "the owner cannot leave the team",
+			}
+		}
+		team.RemoveMembers(t, userId)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &kifupb.LeaveTeamResponse{}, nil
+}
+
+func (s *Service) updatePublicPool(ctx context.Context, f func(*documentpb.Team)) error {
+	var err error
+	for i := 0; i < teamUpdateRetry; i++ {
+		t, version, gerr := s.table.GetTeam(ctx, team.PublicPoolId)
+		if gerr == db.ErrEmpty {
+			t = &documentpb.Team{
+				TeamId:    team.PublicPoolId,
+				Name:      team.PublicPoolId,
+				CreatedTs: time.Now().Unix(),
+			}
+		} else if gerr != nil {
+			return &lambdarpc.InternalError{
+				Message: "db.GetTeam",
+				Err:     gerr,
+			}
+		}
+
+		f(t)
+
+		_, err = s.table.PutTeam(ctx, t, version)
+		if err != db.ErrLockError {
+			break
+		}
+	}
+	if err != nil {
+		return &lambdarpc.InternalError{
+			Message: "db.PutTeam",
+			Err:     err,
+		}
+	}
+
+	return nil
+}
+
+// JoinPublicPool opts the caller's kifus into the public pool.
+// Members of the pool can search positions across the kifus of each other.
+func (s *Service) JoinPublicPool(ctx context.Context, req *kifupb.JoinPublicPoolRequest) (*kifupb.JoinPublicPoolResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+	if userId == "" {
+		return nil, &lambdarpc.ClientError{
+			Message: "user-id is not found",
+		}
+	}
+
+	if err := s.updatePublicPool(ctx, func(t *documentpb.Team) {
+		team.AddMembers(t, userId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &kifupb.JoinPublicPoolResponse{}, nil
+}
+
+func (s *Service) LeavePublicPool(ctx context.Context, req *kifupb.LeavePublicPoolRequest) (*kifupb.LeavePublicPoolResponse, error) {
+	userId := lambdarpc.GetUserId(ctx)
+
+	if err := s.updatePublicPool(ctx, func(t *documentpb.Team) {
+		team.RemoveMembers(t, userId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &kifupb.LeavePublicPoolResponse{}, nil
+}
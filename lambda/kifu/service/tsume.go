@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yunomu/kansousen/lib/lambda/lambdarpc"
+	"github.com/yunomu/kansousen/lib/tsume"
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+const (
+	defaultMissedMatePly = 5
+	maxMissedMatePly     = 9
+
+	// the node limit per position, to finish in the function timeout.
+	missedMateMaxNodes = 5000
+)
+
+// FindMissedMates searches the positions where the side to move had a short mate but didn't play it.
+func (s *Service) FindMissedMates(ctx context.Context, req *kifupb.FindMissedMatesRequest) (*kifupb.FindMissedMatesResponse, error) {
+	maxPly := int(req.GetMaxPly())
+	if maxPly == 0 {
+		maxPly = defaultMissedMatePly
+	}
+	if maxPly < 0 || maxPly > maxMissedMatePly {
+		return nil, &lambdarpc.ClientError{
+			Message: "max_ply is out of range",
+		}
+	}
+
+	_, steps, err := s.getReadableKifu(ctx, req.GetKifuId(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	missed, err := tsume.FindMissedMates(ctx, steps, maxPly, tsume.SetMaxNodes(missedMateMaxNodes))
+	if err != nil {
+		return nil, &lambdarpc.InternalError{
+			Message: "tsume.FindMissedMates",
+			Err:     err,
+		}
+	}
+
+	var ret []*kifupb.FindMissedMatesResponse_MissedMate
+	for _, m := range missed {
+		var moves []string
+		for _, mv := range m.Mate {
+			moves = append(moves, mv.USI())
+		}
+		ret = append(ret, &kifupb.FindMissedMatesResponse_MissedMate{
+			Seq:        m.Seq,
+			Position:   m.Position.SFEN(),
+			MateMoves:  moves,
+			PlayedMove: m.Played,
+		})
+	}
+
+	return &kifupb.FindMissedMatesResponse{
+		MissedMates: ret,
+	}, nil
+}
@@ -0,0 +1,122 @@
+// Package castle detects castles (囲い) in positions.
+package castle
+
+import (
+	"github.com/yunomu/kansousen/lib/pattern"
+	"github.com/yunomu/kansousen/lib/position"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+// Castle is formed when any of the patterns matches. Patterns are written from the black side.
+type Castle struct {
+	Name     string
+	Patterns []*pattern.Pattern
+}
+
+func sq(x, y int32, piece string) *pattern.Square {
+	return &pattern.Square{X: x, Y: y, Piece: piece}
+}
+
+func pat(squares ...*pattern.Square) *pattern.Pattern {
+	return &pattern.Pattern{Squares: squares}
+}
+
+var Castles = []*Castle{
+	{
+		Name: "舟囲い",
+		Patterns: []*pattern.Pattern{
+			pat(sq(7, 8, "K"), sq(6, 9, "G"), sq(5, 8, "G")),
+		},
+	},
+	{
+		Name: "矢倉",
+		Patterns: []*pattern.Pattern{
+			pat(sq(8, 8, "K"), sq(7, 7, "S"), sq(7, 8, "G"), sq(6, 7, "G")),
+		},
+	},
+	{
+		Name: "美濃囲い",
+		Patterns: []*pattern.Pattern{
+			pat(sq(2, 8, "K"), sq(3, 8, "S"), sq(4, 9, "G"), sq(5, 8, "G")),
+		},
+	},
+	{
+		Name: "高美濃",
+		Patterns: []*pattern.Pattern{
+			pat(sq(2, 8, "K"), sq(3, 8, "S"), sq(3, 9, "G"), sq(4, 7, "G")),
+		},
+	},
+	{
+		Name: "銀冠",
+		Patterns: []*pattern.Pattern{
+			pat(sq(2, 8, "K"), sq(2, 7, "S"), sq(3, 8, "G")),
+		},
+	},
+	{
+		Name: "穴熊",
+		Patterns: []*pattern.Pattern{
+			pat(sq(9, 9, "K"), sq(9, 8, "L"), sq(8, 8, "S")),
+			pat(sq(1, 9, "K"), sq(1, 8, "L"), sq(2, 8, "S")),
+		},
+	},
+}
+
+type Detector struct {
+	castles []*Castle
+	flipped [][]*pattern.Pattern
+	formed  map[documentpb.Player_Order]map[string]bool
+}
+
+func NewDetector() *Detector {
+	d := &Detector{
+		castles: Castles,
+		formed: map[documentpb.Player_Order]map[string]bool{
+			documentpb.Player_BLACK: make(map[string]bool),
+			documentpb.Player_WHITE: make(map[string]bool),
+		},
+	}
+	for _, c := range d.castles {
+		var pats []*pattern.Pattern
+		for _, p := range c.Patterns {
+			pats = append(pats, p.ColorFlipped())
+		}
+		d.flipped = append(d.flipped, pats)
+	}
+	return d
+}
+
+func matchAny(pats []*pattern.Pattern, pos *position.Position) bool {
+	for _, p := range pats {
+		if p.Match(pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the castles which are formed for the first time in pos.
+func (d *Detector) Next(pos *position.Position) []*documentpb.Castle {
+	var ret []*documentpb.Castle
+	for i, c := range d.castles {
+		for _, player := range []documentpb.Player_Order{documentpb.Player_BLACK, documentpb.Player_WHITE} {
+			if d.formed[player][c.Name] {
+				continue
+			}
+
+			pats := c.Patterns
+			if player == documentpb.Player_WHITE {
+				pats = d.flipped[i]
+			}
+			if !matchAny(pats, pos) {
+				continue
+			}
+
+			d.formed[player][c.Name] = true
+			ret = append(ret, &documentpb.Castle{
+				Player: player,
+				Name:   c.Name,
+			})
+		}
+	}
+	return ret
+}
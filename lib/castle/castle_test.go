@@ -0,0 +1,39 @@
+package castle
+
+import (
+	"testing"
+
+	"github.com/yunomu/kansousen/lib/position"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func mustParse(t *testing.T, s string) *position.Position {
+	p, err := position.Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%v): %v", s, err)
+	}
+	return p
+}
+
+func TestDetector(t *testing.T) {
+	d := NewDetector()
+
+	// black: 玉2八 銀3八 金4九 金5八
+	mino := mustParse(t, "4k4/9/9/9/9/9/9/4G1SK1/5G3 w - 1")
+	cs := d.Next(mino)
+	if len(cs) != 1 || cs[0].GetPlayer() != documentpb.Player_BLACK || cs[0].GetName() != "美濃囲い" {
+		t.Errorf("mino: %v", cs)
+	}
+
+	// already formed
+	if cs := d.Next(mino); len(cs) != 0 {
+		t.Errorf("mino again: %v", cs)
+	}
+
+	// white: 玉1一 香1二 銀2二
+	anaguma := mustParse(t, "8k/7sl/9/9/9/9/9/4G1SK1/5G3 b - 1")
+	cs = d.Next(anaguma)
+	if len(cs) != 1 || cs[0].GetPlayer() != documentpb.Player_WHITE || cs[0].GetName() != "穴熊" {
+		t.Errorf("anaguma: %v", cs)
+	}
+}
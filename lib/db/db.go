@@ -12,8 +12,36 @@ var (
 	ErrKifuIdIsEmpty   = errors.New("kifu_id is empty")
 	ErrPositionIsEmpty = errors.New("position is empty")
 	ErrLockError       = errors.New("optimistic locking error")
+	ErrTrashed         = errors.New("kifu is trashed")
 )
 
+type getKifuOptions struct {
+	includeTrashed bool
+}
+
+type GetKifuOption func(*getKifuOptions)
+
+// GetKifuIncludeTrashed returns the trashed kifu too, e.g. to restore or purge it.
+func GetKifuIncludeTrashed() GetKifuOption {
+	return func(o *getKifuOptions) {
+		o.includeTrashed = true
+	}
+}
+
+type getRecentKifuOptions struct {
+	filter func(*documentpb.Kifu) bool
+}
+
+type GetRecentKifuOption func(*getRecentKifuOptions)
+
+// GetRecentKifuSetFilter returns only the kifus which f returns true for.
+// The limit is applied after the filter.
+func GetRecentKifuSetFilter(f func(*documentpb.Kifu) bool) GetRecentKifuOption {
+	return func(o *getRecentKifuOptions) {
+		o.filter = f
+	}
+}
+
 type getStepsOptions struct {
 	start int32
 	end   int32
@@ -31,12 +59,17 @@ func GetStepsSetRange(start, end int32) GetStepsOption {
 type Position struct {
 	UserId string
 	KifuId string
-	Steps  []*documentpb.Step
+	// Seq is the sequence number of the step which reached the position.
+	Seq   int32
+	Steps []*documentpb.Step
 }
 
 type getSamePositionsOptions struct {
 	numStep        int32
 	excludeKifuIds []string
+	limit          int
+	normalized     bool
+	filter         func(*documentpb.Kifu) bool
 }
 
 type GetSamePositionsOption func(*getSamePositionsOptions)
@@ -53,12 +86,55 @@ func GetSamePositionsAddExcludeKifuId(kifuId string) GetSamePositionsOption {
 	}
 }
 
+// GetSamePositionsSetLimit sets the maximum number of positions. 0 means unlimited.
+func GetSamePositionsSetLimit(n int) GetSamePositionsOption {
+	return func(o *getSamePositionsOptions) {
+		o.limit = n
+	}
+}
+
+// GetSamePositionsSetNormalized matches positions by the canonical key,
+// which identifies mirrored and color-flipped positions. The caller filters them as needed.
+func GetSamePositionsSetNormalized(b bool) GetSamePositionsOption {
+	return func(o *getSamePositionsOptions) {
+		o.normalized = b
+	}
+}
+
 func GetSamePositionsAddExcludeKifuIds(kifuIds []string) GetSamePositionsOption {
 	return func(o *getSamePositionsOptions) {
 		o.excludeKifuIds = append(o.excludeKifuIds, kifuIds...)
 	}
 }
 
+// GetSamePositionsSetFilter returns only the positions of the kifus which f returns true for.
+// The limit is applied after the filter.
+func GetSamePositionsSetFilter(f func(*documentpb.Kifu) bool) GetSamePositionsOption {
+	return func(o *getSamePositionsOptions) {
+		o.filter = f
+	}
+}
+
+type getKifuIdsBySfenOptions struct {
+	userId string
+}
+
+type GetKifuIdsBySfenOption func(*getKifuIdsBySfenOptions)
+
+// GetKifuIdsBySfenSetUserId limits the result to the kifu of the user.
+func GetKifuIdsBySfenSetUserId(userId string) GetKifuIdsBySfenOption {
+	return func(o *getKifuIdsBySfenOptions) {
+		o.userId = userId
+	}
+}
+
+type TrashedKifu struct {
+	Kifu      *documentpb.Kifu
+	Version   int64
+	TrashedTs int64
+	PurgeTs   int64
+}
+
 type UserKifu struct {
 	UserId string
 	KifuId string
@@ -66,13 +142,26 @@ type UserKifu struct {
 
 type DB interface {
 	PutKifu(ctx context.Context, kifu *documentpb.Kifu, steps []*documentpb.Step, version int64) (int64, error)
-	GetKifu(ctx context.Context, kifuId string) (*documentpb.Kifu, int64, error)
+	UpdateKifu(ctx context.Context, kifu *documentpb.Kifu, version int64) (int64, error)
+	GetKifu(ctx context.Context, kifuId string, options ...GetKifuOption) (*documentpb.Kifu, int64, error)
 	GetKifuAndSteps(ctx context.Context, kifuId string) (*documentpb.Kifu, []*documentpb.Step, int64, error)
+	BatchGetKifu(ctx context.Context, kifuIds []string) (map[string]*documentpb.Kifu, error)
+	PutAlias(ctx context.Context, aliasId, kifuId string) error
 	ListKifu(ctx context.Context, userId string, f func(*documentpb.Kifu, int64)) error
-	GetKifuIdsBySfen(ctx context.Context, sfen string) ([]*UserKifu, error)
+	GetKifuIdsBySfen(ctx context.Context, sfen string, options ...GetKifuIdsBySfenOption) ([]*UserKifu, error)
 	GetSamePositions(ctx context.Context, userIds []string, pos string, options ...GetSamePositionsOption) ([]*Position, error)
-	GetRecentKifu(ctx context.Context, userId string, limit int) ([]*documentpb.Kifu, error)
+	GetRecentKifu(ctx context.Context, userId string, limit int, options ...GetRecentKifuOption) ([]*documentpb.Kifu, error)
 	DeleteKifu(ctx context.Context, kifuId string, version int64) error
+	ListKifuRevisions(ctx context.Context, kifuId string) ([]*documentpb.KifuRevision, error)
+	GetKifuRevision(ctx context.Context, kifuId string, version int64) (*documentpb.KifuRevision, error)
+	TrashKifu(ctx context.Context, kifuId string, version int64) (int64, error)
+	RestoreKifu(ctx context.Context, kifuId string, version int64) (int64, error)
+	ReindexKifu(ctx context.Context, kifuId string, version int64) (int64, error)
+	ListTrash(ctx context.Context, userId string) ([]*TrashedKifu, error)
+	ListKifuByTag(ctx context.Context, userId, tag string, limit int, pageToken string) ([]*documentpb.Kifu, string, error)
+
+	PutTeam(ctx context.Context, team *documentpb.Team, version int64) (int64, error)
+	GetTeam(ctx context.Context, teamId string) (*documentpb.Team, int64, error)
 }
 
 var (
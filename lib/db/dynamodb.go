@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -16,6 +18,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 
+	"github.com/yunomu/kansousen/lib/position"
 	documentpb "github.com/yunomu/kansousen/proto/document"
 )
 
@@ -29,9 +32,21 @@ const (
 	createdTsAttr = "createdTs"
 	sfenAttr      = "sfen"
 	posAttr       = "pos"
-
-	kifuVar       = "KIFU"
-	stepVarPrefix = "STEP:"
+	cposAttr      = "cpos"
+	varAttr       = "var"
+	revisionAttr  = "revision"
+	trashedTsAttr = "trashedTs"
+	ttlAttr       = "ttl"
+	userTagAttr   = "userTag"
+	teamAttr      = "team"
+	aliasOfAttr   = "aliasOf"
+
+	kifuVar           = "KIFU"
+	stepVarPrefix     = "STEP:"
+	revisionVarPrefix = "REV:"
+	tagVarPrefix      = "TAG:"
+	teamVar           = "TEAM"
+	aliasVar          = "ALIAS"
 
 	BatchUnit = 25
 )
@@ -40,10 +55,36 @@ func stepVar(seq int32) string {
 	return fmt.Sprintf("%s%d", stepVarPrefix, seq)
 }
 
+// canonicalPos returns the key of NormalizedPosition index.
+// Empty string is returned for invalid positions so that they are not indexed.
+func canonicalPos(pos string) string {
+	key, _, _, err := position.Canonical(pos, position.Mirror|position.ColorFlip)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
 func isStepVar(s string) bool {
 	return strings.HasPrefix(s, stepVarPrefix)
 }
 
+func revisionVar(version int64) string {
+	return fmt.Sprintf("%s%d", revisionVarPrefix, version)
+}
+
+func isRevisionVar(s string) bool {
+	return strings.HasPrefix(s, revisionVarPrefix)
+}
+
+func tagVar(tag string) string {
+	return tagVarPrefix + tag
+}
+
+func isTagVar(s string) bool {
+	return strings.HasPrefix(s, tagVarPrefix)
+}
+
 type DynamoDBKifuRecord struct {
 	UserId    string `dynamodbav:"userId,omitempty"`
 	KifuId    string `dynamodbav:"kifuId"`
@@ -53,17 +94,25 @@ type DynamoDBKifuRecord struct {
 	Sfen      string `dynamodbav:"sfen,omitempty"`
 	Seq       int32  `dynamodbav:"seq,omitempty"`
 	Pos       string `dynamodbav:"pos,omitempty"`
+	CPos      string `dynamodbav:"cpos,omitempty"`
 	Kifu      []byte `dynamodbav:"kifu,omitempty"`
 	Step      []byte `dynamodbav:"step,omitempty"`
 	Version   int64  `dynamodbav:"version,omitempty"`
 	StepNum   int32  `dynamodbav:"stepNum,omitempty"`
+	Revision  []byte `dynamodbav:"revision,omitempty"`
+	TrashedTs int64  `dynamodbav:"trashedTs,omitempty"`
+	Ttl       int64  `dynamodbav:"ttl,omitempty"`
+	UserTag   string `dynamodbav:"userTag,omitempty"`
+	Team      []byte `dynamodbav:"team,omitempty"`
+	AliasOf   string `dynamodbav:"aliasOf,omitempty"`
 }
 
 type DynamoDB struct {
 	client    *dynamodb.DynamoDB
 	tableName string
 
-	parallelism int
+	parallelism    int
+	trashRetention time.Duration
 }
 
 var _ DB = (*DynamoDB)(nil)
@@ -76,12 +125,20 @@ func SetParallelism(i int) DynamoDBOption {
 	}
 }
 
+// SetTrashRetention sets the period until trashed kifu are purged by DynamoDB TTL.
+func SetTrashRetention(d time.Duration) DynamoDBOption {
+	return func(db *DynamoDB) {
+		db.trashRetention = d
+	}
+}
+
 func NewDynamoDB(client *dynamodb.DynamoDB, tableName string, ops ...DynamoDBOption) *DynamoDB {
 	db := &DynamoDB{
 		client:    client,
 		tableName: tableName,
 
-		parallelism: 2,
+		parallelism:    2,
+		trashRetention: 30 * 24 * time.Hour,
 	}
 	for _, f := range ops {
 		f(db)
@@ -124,16 +181,19 @@ func (db *DynamoDB) batchWrite(
 	for i := 0; i < db.parallelism; i++ {
 		g.Go(func() error {
 			for reqs := range reqsCh {
-				out, err := db.client.BatchWriteItemWithContext(ctx, &dynamodb.BatchWriteItemInput{
-					RequestItems: map[string][]*dynamodb.WriteRequest{
-						db.tableName: reqs,
-					},
-				})
-				if err == nil {
-					return err
+				items := map[string][]*dynamodb.WriteRequest{
+					db.tableName: reqs,
 				}
+				for len(items[db.tableName]) != 0 {
+					out, err := db.client.BatchWriteItemWithContext(ctx, &dynamodb.BatchWriteItemInput{
+						RequestItems: items,
+					})
+					if err != nil {
+						return err
+					}
 
-				var _ = out
+					items = out.UnprocessedItems
+				}
 			}
 
 			return nil
@@ -143,39 +203,26 @@ func (db *DynamoDB) batchWrite(
 	return g.Wait()
 }
 
-func (db *DynamoDB) PutKifu(
+// putKifuRecord puts the KIFU record with optimistic locking.
+// The overwritten record is kept as a revision, and the tag index is updated.
+func (db *DynamoDB) putKifuRecord(
 	ctx context.Context,
+	rec *DynamoDBKifuRecord,
 	kifu *documentpb.Kifu,
-	steps []*documentpb.Step,
 	version int64,
-) (int64, error) {
-	stepNum := int32(len(steps))
-	bs, err := proto.Marshal(kifu)
-	if err != nil {
-		return 0, err
-	}
-	newVersion := time.Now().UnixNano()
-	kifuAv, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
-		UserId:    kifu.GetUserId(),
-		KifuId:    kifu.GetKifuId(),
-		Var:       kifuVar,
-		CreatedTs: kifu.GetCreatedTs(),
-		StartTs:   kifu.GetStartTs(),
-		Sfen:      kifu.GetSfen(),
-		Kifu:      bs,
-		Version:   newVersion,
-		StepNum:   stepNum,
-	})
+) (*DynamoDBKifuRecord, error) {
+	kifuAv, err := dynamodbattribute.MarshalMap(rec)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	out, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
 		TableName: aws.String(db.tableName),
 
-		ConditionExpression: aws.String("attribute_not_exists(#version) OR #version = :version"),
+		ConditionExpression: aws.String("(attribute_not_exists(#version) OR #version = :version) AND attribute_not_exists(#trashedTs)"),
 		ExpressionAttributeNames: map[string]*string{
-			"#version": aws.String(versionAttr),
+			"#version":   aws.String(versionAttr),
+			"#trashedTs": aws.String(trashedTsAttr),
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":version": &dynamodb.AttributeValue{
@@ -190,17 +237,59 @@ func (db *DynamoDB) PutKifu(
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
 			case dynamodb.ErrCodeConditionalCheckFailedException:
-				return 0, ErrLockError
+				// The records of the trashed kifu have the TTL, so it must be restored first.
+				if err := db.conditionError(ctx, rec.KifuId); err == ErrTrashed {
+					return nil, ErrTrashed
+				}
+				return nil, ErrLockError
 			}
 		}
-		return 0, err
+		return nil, err
 	}
 
 	var old DynamoDBKifuRecord
 	if len(out.Attributes) != 0 {
 		if err := dynamodbattribute.UnmarshalMap(out.Attributes, &old); err != nil {
-			return 0, err
+			return nil, err
 		}
+
+		if err := db.putRevision(ctx, &old); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := db.syncTags(ctx, &old, kifu); err != nil {
+		return nil, err
+	}
+
+	return &old, nil
+}
+
+func (db *DynamoDB) PutKifu(
+	ctx context.Context,
+	kifu *documentpb.Kifu,
+	steps []*documentpb.Step,
+	version int64,
+) (int64, error) {
+	stepNum := int32(len(steps))
+	bs, err := proto.Marshal(kifu)
+	if err != nil {
+		return 0, err
+	}
+	newVersion := time.Now().UnixNano()
+	old, err := db.putKifuRecord(ctx, &DynamoDBKifuRecord{
+		UserId:    kifu.GetUserId(),
+		KifuId:    kifu.GetKifuId(),
+		Var:       kifuVar,
+		CreatedTs: kifu.GetCreatedTs(),
+		StartTs:   kifu.GetStartTs(),
+		Sfen:      kifu.GetSfen(),
+		Kifu:      bs,
+		Version:   newVersion,
+		StepNum:   stepNum,
+	}, kifu, version)
+	if err != nil {
+		return 0, err
 	}
 
 	g, ctx := errgroup.WithContext(ctx)
@@ -210,7 +299,7 @@ func (db *DynamoDB) PutKifu(
 		defer close(reqCh)
 
 		for _, step := range steps {
-			bs, err := proto.Marshal(kifu)
+			bs, err := proto.Marshal(step)
 			if err != nil {
 				return err
 			}
@@ -220,8 +309,12 @@ func (db *DynamoDB) PutKifu(
 				Var:    stepVar(step.GetSeq()),
 				Seq:    step.GetSeq(),
 				Pos:    step.GetPosition(),
+				CPos:   canonicalPos(step.GetPosition()),
 				Step:   bs,
 			})
+			if err != nil {
+				return err
+			}
 
 			select {
 			case reqCh <- &dynamodb.WriteRequest{
@@ -232,7 +325,7 @@ func (db *DynamoDB) PutKifu(
 			}
 		}
 
-		for i := stepNum + 1; i <= old.StepNum; i++ {
+		for i := stepNum; i < old.StepNum; i++ {
 			av, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
 				KifuId: old.KifuId,
 				Var:    stepVar(i),
@@ -260,75 +353,606 @@ func (db *DynamoDB) PutKifu(
 	return newVersion, err
 }
 
-func (db *DynamoDB) GetKifu(
-	ctx context.Context,
-	kifuId string,
-) (*documentpb.Kifu, int64, error) {
+func (db *DynamoDB) getStepNotes(ctx context.Context, kifuId string) ([]*documentpb.StepNotes, error) {
+	var ret []*documentpb.StepNotes
+	var rerr error
+	if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		KeyConditionExpression: aws.String("#kifuId = :kifuId AND begins_with(#var, :prefix)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#kifuId": aws.String(kifuIdAttr),
+			"#var":    aws.String(varAttr),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":kifuId": &dynamodb.AttributeValue{S: aws.String(kifuId)},
+			":prefix": &dynamodb.AttributeValue{S: aws.String(stepVarPrefix)},
+		},
+		ProjectionExpression: aws.String(stepAttr),
+	}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+		select {
+		case <-ctx.Done():
+			rerr = ctx.Err()
+			return false
+		default:
+		}
+
+		var records []DynamoDBKifuRecord
+		if err := dynamodbattribute.UnmarshalListOfMaps(out.Items, &records); err != nil {
+			rerr = err
+			return false
+		}
+		for _, r := range records {
+			var step documentpb.Step
+			if err := proto.Unmarshal(r.Step, &step); err != nil {
+				rerr = &ErrInvalidValue{
+					Details: err.Error(),
+				}
+				return false
+			}
+
+			if len(step.GetNotes()) == 0 {
+				continue
+			}
+
+			ret = append(ret, &documentpb.StepNotes{
+				Seq:   step.GetSeq(),
+				Notes: step.GetNotes(),
+			})
+		}
+
+		return true
+	}); err != nil {
+		return nil, err
+	} else if rerr != nil {
+		return nil, rerr
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].GetSeq() < ret[j].GetSeq() })
+
+	return ret, nil
+}
+
+// revisionItem returns the REV record of the overwritten KIFU record with the notes of the steps.
+func (db *DynamoDB) revisionItem(ctx context.Context, old *DynamoDBKifuRecord) (map[string]*dynamodb.AttributeValue, error) {
+	var kifu documentpb.Kifu
+	if err := proto.Unmarshal(old.Kifu, &kifu); err != nil {
+		return nil, &ErrInvalidValue{
+			Details: err.Error(),
+		}
+	}
+
+	stepNotes, err := db.getStepNotes(ctx, old.KifuId)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := proto.Marshal(&documentpb.KifuRevision{
+		KifuId:    old.KifuId,
+		Version:   old.Version,
+		RevisedTs: time.Now().Unix(),
+		Kifu:      &kifu,
+		StepNotes: stepNotes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+		KifuId:   old.KifuId,
+		Var:      revisionVar(old.Version),
+		Version:  old.Version,
+		Revision: bs,
+	})
+}
+
+func (db *DynamoDB) putRevision(ctx context.Context, old *DynamoDBKifuRecord) error {
+	av, err := db.revisionItem(ctx, old)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName),
+		Item:      av,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateKifu updates only the kifu of the KIFU record, e.g. tags and shares. Steps are not changed.
+// The attributes for the indexes are kept. The overwritten record is kept as a revision,
+// which is written in the same transaction as the update.
+func (db *DynamoDB) UpdateKifu(ctx context.Context, kifu *documentpb.Kifu, version int64) (int64, error) {
 	key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
-		KifuId: kifuId,
+		KifuId: kifu.GetKifuId(),
 		Var:    kifuVar,
 	})
 	if err != nil {
-		return nil, 0, err
+		return 0, err
 	}
+
 	out, err := db.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
-		TableName:            aws.String(db.tableName),
-		Key:                  key,
-		ProjectionExpression: aws.String(strings.Join([]string{kifuAttr, versionAttr}, ",")),
+		TableName:      aws.String(db.tableName),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
 	})
 	if err != nil {
-		return nil, 0, err
+		return 0, err
 	}
 
-	record := DynamoDBKifuRecord{}
-	if err := dynamodbattribute.UnmarshalMap(out.Item, &record); err != nil {
-		return nil, 0, err
+	var old DynamoDBKifuRecord
+	if err := dynamodbattribute.UnmarshalMap(out.Item, &old); err != nil {
+		return 0, err
+	}
+	switch {
+	case len(out.Item) == 0:
+		return 0, ErrEmpty
+	case old.TrashedTs != 0:
+		return 0, ErrTrashed
+	case old.Version != version:
+		return 0, ErrLockError
 	}
 
-	var kifu documentpb.Kifu
-	if err := proto.Unmarshal(record.Kifu, &kifu); err != nil {
-		return nil, 0, &ErrInvalidValue{
-			Details: err.Error(),
+	revAv, err := db.revisionItem(ctx, &old)
+	if err != nil {
+		return 0, err
+	}
+
+	bs, err := proto.Marshal(kifu)
+	if err != nil {
+		return 0, err
+	}
+	newVersion := time.Now().UnixNano()
+
+	if _, err := db.client.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Put: &dynamodb.Put{
+					TableName: aws.String(db.tableName),
+					Item:      revAv,
+				},
+			},
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(db.tableName),
+					Key:       key,
+
+					UpdateExpression:    aws.String("SET #kifu = :kifu, #version = :newVersion"),
+					ConditionExpression: aws.String("#version = :version AND attribute_not_exists(#trashedTs)"),
+					ExpressionAttributeNames: map[string]*string{
+						"#kifu":      aws.String(kifuAttr),
+						"#version":   aws.String(versionAttr),
+						"#trashedTs": aws.String(trashedTsAttr),
+					},
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":kifu": &dynamodb.AttributeValue{B: bs},
+						":newVersion": &dynamodb.AttributeValue{
+							N: aws.String(fmt.Sprintf("%d", newVersion)),
+						},
+						":version": &dynamodb.AttributeValue{
+							N: aws.String(fmt.Sprintf("%d", version)),
+						},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case dynamodb.ErrCodeTransactionCanceledException:
+				return 0, db.conditionError(ctx, kifu.GetKifuId())
+			}
 		}
+		return 0, err
 	}
 
-	return &kifu, record.Version, nil
+	if err := db.syncTags(ctx, &old, kifu); err != nil {
+		return 0, err
+	}
+
+	return newVersion, nil
 }
 
-type StepSlice []*documentpb.Step
+// conditionError tells why the conditional write of the KIFU record failed.
+func (db *DynamoDB) conditionError(ctx context.Context, kifuId string) error {
+	_, version, err := db.GetKifu(ctx, kifuId)
+	switch {
+	case err == ErrTrashed:
+		return ErrTrashed
+	case err == nil && version == 0:
+		return ErrEmpty
+	default:
+		return ErrLockError
+	}
+}
 
-func (s StepSlice) Len() int               { return len(s) }
-func (s StepSlice) Less(i int, j int) bool { return s[i].GetSeq() < s[j].GetSeq() }
-func (s StepSlice) Swap(i int, j int)      { s[i], s[j] = s[j], s[i] }
+func userTag(userId, tag string) string {
+	return userId + ":" + tag
+}
+
+func (db *DynamoDB) syncTags(ctx context.Context, old *DynamoDBKifuRecord, kifu *documentpb.Kifu) error {
+	oldTags := make(map[string]struct{})
+	if len(old.Kifu) != 0 {
+		var oldKifu documentpb.Kifu
+		if err := proto.Unmarshal(old.Kifu, &oldKifu); err != nil {
+			return &ErrInvalidValue{
+				Details: err.Error(),
+			}
+		}
+		for _, tag := range oldKifu.GetTags() {
+			oldTags[tag] = struct{}{}
+		}
+	}
+
+	newTags := make(map[string]struct{})
+	for _, tag := range kifu.GetTags() {
+		newTags[tag] = struct{}{}
+	}
+
+	var reqs []*dynamodb.WriteRequest
+	for tag := range newTags {
+		if _, ok := oldTags[tag]; ok {
+			continue
+		}
+
+		av, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+			KifuId:    kifu.GetKifuId(),
+			Var:       tagVar(tag),
+			UserTag:   userTag(kifu.GetUserId(), tag),
+			CreatedTs: kifu.GetCreatedTs(),
+		})
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, &dynamodb.WriteRequest{
+			PutRequest: &dynamodb.PutRequest{Item: av},
+		})
+	}
+	for tag := range oldTags {
+		if _, ok := newTags[tag]; ok {
+			continue
+		}
+
+		key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+			KifuId: kifu.GetKifuId(),
+			Var:    tagVar(tag),
+		})
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, &dynamodb.WriteRequest{
+			DeleteRequest: &dynamodb.DeleteRequest{Key: key},
+		})
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
 
-func (db *DynamoDB) GetKifuAndSteps(
-	ctx context.Context,
-	kifuId string,
-) (*documentpb.Kifu, []*documentpb.Step, int64, error) {
 	g, ctx := errgroup.WithContext(ctx)
 
-	itemsCh := make(chan []map[string]*dynamodb.AttributeValue, db.parallelism)
+	reqCh := make(chan *dynamodb.WriteRequest)
 	g.Go(func() error {
-		defer close(itemsCh)
+		defer close(reqCh)
 
-		var rerr error
-		if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
-			TableName:              aws.String(db.tableName),
-			KeyConditionExpression: aws.String("#kifuId = :kifuId"),
-			ExpressionAttributeNames: map[string]*string{
-				"#kifuId": aws.String(kifuIdAttr),
-			},
-			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-				":kifuId": &dynamodb.AttributeValue{S: aws.String(kifuId)},
-			},
-			ProjectionExpression: aws.String(strings.Join([]string{kifuAttr, versionAttr, stepAttr, seqAttr}, ",")),
-		}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+		for _, req := range reqs {
 			select {
+			case reqCh <- req:
 			case <-ctx.Done():
-				rerr = ctx.Err()
-				return false
-			case itemsCh <- out.Items:
-				return true
+				return ctx.Err()
+			}
+		}
+
+		return nil
+	})
+
+	return db.batchWrite(ctx, g, reqCh)
+}
+
+func encodePageToken(key map[string]*dynamodb.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	bs, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bs), nil
+}
+
+func decodePageToken(token string) (map[string]*dynamodb.AttributeValue, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	bs, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, &ErrInvalidValue{
+			Details: "page token: " + err.Error(),
+		}
+	}
+
+	var key map[string]*dynamodb.AttributeValue
+	if err := json.Unmarshal(bs, &key); err != nil {
+		return nil, &ErrInvalidValue{
+			Details: "page token: " + err.Error(),
+		}
+	}
+
+	return key, nil
+}
+
+func (db *DynamoDB) ListKifuByTag(
+	ctx context.Context,
+	userId, tag string,
+	limit int,
+	pageToken string,
+) ([]*documentpb.Kifu, string, error) {
+	startKey, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var ret []*documentpb.Kifu
+	// trashed kifu are filtered out after the query, so query again until the page is filled.
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(db.tableName),
+			IndexName:              aws.String("Tag"),
+			KeyConditionExpression: aws.String("#userTag = :userTag"),
+			ExpressionAttributeNames: map[string]*string{
+				"#userTag": aws.String(userTagAttr),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":userTag": &dynamodb.AttributeValue{S: aws.String(userTag(userId, tag))},
+			},
+			ScanIndexForward:  aws.Bool(false),
+			ExclusiveStartKey: startKey,
+		}
+		if limit > 0 {
+			in.Limit = aws.Int64(int64(limit - len(ret)))
+		}
+		out, err := db.client.QueryWithContext(ctx, in)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var tagRecs []DynamoDBKifuRecord
+		if err := dynamodbattribute.UnmarshalListOfMaps(out.Items, &tagRecs); err != nil {
+			return nil, "", err
+		}
+
+		var kifuIds []string
+		for _, rec := range tagRecs {
+			kifuIds = append(kifuIds, rec.KifuId)
+		}
+
+		recs, err := db.batchGetKifuRecords(ctx, kifuIds)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, kifuId := range kifuIds {
+			rec, ok := recs[kifuId]
+			if !ok || rec.TrashedTs != 0 {
+				continue
+			}
+
+			var kifu documentpb.Kifu
+			if err := proto.Unmarshal(rec.Kifu, &kifu); err != nil {
+				return nil, "", &ErrInvalidValue{
+					Details: err.Error(),
+				}
+			}
+			ret = append(ret, &kifu)
+		}
+
+		startKey = out.LastEvaluatedKey
+		if len(startKey) == 0 || (limit > 0 && len(ret) >= limit) {
+			break
+		}
+	}
+
+	next, err := encodePageToken(startKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return ret, next, nil
+}
+
+func (db *DynamoDB) batchGetKifuRecords(ctx context.Context, kifuIds []string) (map[string]*DynamoDBKifuRecord, error) {
+	ret := make(map[string]*DynamoDBKifuRecord)
+
+	for len(kifuIds) != 0 {
+		n := len(kifuIds)
+		if n > 100 {
+			n = 100
+		}
+
+		var keys []map[string]*dynamodb.AttributeValue
+		for _, kifuId := range kifuIds[:n] {
+			key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+				KifuId: kifuId,
+				Var:    kifuVar,
+			})
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		kifuIds = kifuIds[n:]
+
+		items := map[string]*dynamodb.KeysAndAttributes{
+			db.tableName: &dynamodb.KeysAndAttributes{
+				Keys: keys,
+				ExpressionAttributeNames: map[string]*string{
+					"#kifuId": aws.String(kifuIdAttr),
+				},
+				ProjectionExpression: aws.String(strings.Join([]string{"#kifuId", kifuAttr, versionAttr, trashedTsAttr}, ",")),
+			},
+		}
+		for len(items) != 0 {
+			out, err := db.client.BatchGetItemWithContext(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: items,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			var recs []*DynamoDBKifuRecord
+			if err := dynamodbattribute.UnmarshalListOfMaps(out.Responses[db.tableName], &recs); err != nil {
+				return nil, err
+			}
+			for _, rec := range recs {
+				ret[rec.KifuId] = rec
+			}
+
+			items = out.UnprocessedKeys
+		}
+	}
+
+	return ret, nil
+}
+
+func (db *DynamoDB) GetKifu(
+	ctx context.Context,
+	kifuId string,
+	options ...GetKifuOption,
+) (*documentpb.Kifu, int64, error) {
+	o := &getKifuOptions{}
+	for _, f := range options {
+		f(o)
+	}
+
+	key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+		KifuId: kifuId,
+		Var:    kifuVar,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	out, err := db.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:            aws.String(db.tableName),
+		Key:                  key,
+		ProjectionExpression: aws.String(strings.Join([]string{kifuAttr, versionAttr, trashedTsAttr}, ",")),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	record := DynamoDBKifuRecord{}
+	if err := dynamodbattribute.UnmarshalMap(out.Item, &record); err != nil {
+		return nil, 0, err
+	}
+	if record.TrashedTs != 0 && !o.includeTrashed {
+		return nil, 0, ErrTrashed
+	}
+
+	var kifu documentpb.Kifu
+	if err := proto.Unmarshal(record.Kifu, &kifu); err != nil {
+		return nil, 0, &ErrInvalidValue{
+			Details: err.Error(),
+		}
+	}
+
+	return &kifu, record.Version, nil
+}
+
+// BatchGetKifu returns the kifus by the ids. Missing and trashed kifus are not contained.
+func (db *DynamoDB) BatchGetKifu(ctx context.Context, kifuIds []string) (map[string]*documentpb.Kifu, error) {
+	// BatchGetItem rejects the duplicated keys
+	seen := make(map[string]struct{})
+	var ids []string
+	for _, kifuId := range kifuIds {
+		if _, ok := seen[kifuId]; ok {
+			continue
+		}
+		seen[kifuId] = struct{}{}
+		ids = append(ids, kifuId)
+	}
+
+	recs, err := db.batchGetKifuRecords(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]*documentpb.Kifu)
+	for kifuId, rec := range recs {
+		if rec.TrashedTs != 0 {
+			continue
+		}
+
+		var kifu documentpb.Kifu
+		if err := proto.Unmarshal(rec.Kifu, &kifu); err != nil {
+			return nil, &ErrInvalidValue{
+				Details: err.Error(),
+			}
+		}
+		ret[kifuId] = &kifu
+	}
+
+	return ret, nil
+}
+
+type StepSlice []*documentpb.Step
+
+func (s StepSlice) Len() int               { return len(s) }
+func (s StepSlice) Less(i int, j int) bool { return s[i].GetSeq() < s[j].GetSeq() }
+func (s StepSlice) Swap(i int, j int)      { s[i], s[j] = s[j], s[i] }
+
+// GetKifuAndSteps returns the kifu and the steps.
+// If kifuId is an alias of another kifu (see PutAlias), the kifu is returned instead.
+func (db *DynamoDB) GetKifuAndSteps(
+	ctx context.Context,
+	kifuId string,
+) (*documentpb.Kifu, []*documentpb.Step, int64, error) {
+	kifu, steps, version, aliasOf, err := db.getKifuAndSteps(ctx, kifuId)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if kifu == nil && aliasOf != "" {
+		kifu, steps, version, _, err = db.getKifuAndSteps(ctx, aliasOf)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	return kifu, steps, version, nil
+}
+
+func (db *DynamoDB) getKifuAndSteps(
+	ctx context.Context,
+	kifuId string,
+) (*documentpb.Kifu, []*documentpb.Step, int64, string, error) {
+	g, ctx := errgroup.WithContext(ctx)
+
+	itemsCh := make(chan []map[string]*dynamodb.AttributeValue, db.parallelism)
+	g.Go(func() error {
+		defer close(itemsCh)
+
+		var rerr error
+		if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(db.tableName),
+			KeyConditionExpression: aws.String("#kifuId = :kifuId"),
+			ExpressionAttributeNames: map[string]*string{
+				"#kifuId": aws.String(kifuIdAttr),
+				"#var":    aws.String(varAttr),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":kifuId": &dynamodb.AttributeValue{S: aws.String(kifuId)},
+			},
+			ProjectionExpression: aws.String(strings.Join([]string{"#var", kifuAttr, versionAttr, stepAttr, seqAttr, trashedTsAttr, aliasOfAttr}, ",")),
+		}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+			select {
+			case <-ctx.Done():
+				rerr = ctx.Err()
+				return false
+			case itemsCh <- out.Items:
+				return true
 			}
 		}); err != nil {
 			return err
@@ -339,6 +963,7 @@ func (db *DynamoDB) GetKifuAndSteps(
 
 	var kifu *documentpb.Kifu
 	var version int64
+	var aliasOf string
 	stepsCh := make(chan []*documentpb.Step, db.parallelism)
 	for i := 0; i < db.parallelism; i++ {
 		g.Go(func() error {
@@ -352,6 +977,10 @@ func (db *DynamoDB) GetKifuAndSteps(
 				for _, rec := range recs {
 					switch {
 					case rec.Var == kifuVar: // Kifu
+						if rec.TrashedTs != 0 {
+							return ErrTrashed
+						}
+
 						var k documentpb.Kifu
 						if err := proto.Unmarshal(rec.Kifu, &k); err != nil {
 							return &ErrInvalidValue{
@@ -370,6 +999,10 @@ func (db *DynamoDB) GetKifuAndSteps(
 						}
 
 						steps = append(steps, &s)
+					case isRevisionVar(rec.Var): // Revision
+					case isTagVar(rec.Var): // Tag
+					case rec.Var == aliasVar: // Alias
+						aliasOf = rec.AliasOf
 					default:
 						return fmt.Errorf("")
 					}
@@ -397,12 +1030,34 @@ func (db *DynamoDB) GetKifuAndSteps(
 	}
 
 	if err := g.Wait(); err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, "", err
 	}
 
 	sort.Sort(StepSlice(steps))
 
-	return kifu, steps, version, nil
+	return kifu, steps, version, aliasOf, nil
+}
+
+// PutAlias makes aliasId refer to the kifu, e.g. for the merged duplicates.
+// The alias is resolved only while no kifu has aliasId.
+func (db *DynamoDB) PutAlias(ctx context.Context, aliasId, kifuId string) error {
+	av, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+		KifuId:  aliasId,
+		Var:     aliasVar,
+		AliasOf: kifuId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName),
+		Item:      av,
+	}); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 type versionedKifu struct {
@@ -420,15 +1075,13 @@ func (db *DynamoDB) ListKifu(ctx context.Context, userId string, f func(kifu *do
 		var rerr error
 		if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
 			TableName:              aws.String(db.tableName),
-			IndexName:              aws.String("User"),
-			KeyConditionExpression: aws.String("#userId = :userId AND #seq = :seq"),
+			IndexName:              aws.String("Created"),
+			KeyConditionExpression: aws.String("#userId = :userId"),
 			ExpressionAttributeNames: map[string]*string{
 				"#userId": aws.String(userIdAttr),
-				"#seq":    aws.String(seqAttr),
 			},
 			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 				":userId": &dynamodb.AttributeValue{S: aws.String(userId)},
-				":seq":    &dynamodb.AttributeValue{N: aws.String("0")},
 			},
 			ProjectionExpression: aws.String(strings.Join([]string{kifuAttr, versionAttr}, ",")),
 		}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
@@ -492,20 +1145,34 @@ func (db *DynamoDB) ListKifu(ctx context.Context, userId string, f func(kifu *do
 	return g.Wait()
 }
 
-func (db *DynamoDB) GetKifuIdsBySfen(ctx context.Context, sfen string) ([]*UserKifu, error) {
+func (db *DynamoDB) GetKifuIdsBySfen(ctx context.Context, sfen string, options ...GetKifuIdsBySfenOption) ([]*UserKifu, error) {
+	o := &getKifuIdsBySfenOptions{}
+	for _, f := range options {
+		f(o)
+	}
+
+	keyCond := "#sfen = :sfen"
+	names := map[string]*string{
+		"#sfen":   aws.String(sfenAttr),
+		"#userId": aws.String(userIdAttr),
+	}
+	values := map[string]*dynamodb.AttributeValue{
+		":sfen": &dynamodb.AttributeValue{S: aws.String(sfen)},
+	}
+	if o.userId != "" {
+		keyCond += " AND #userId = :userId"
+		values[":userId"] = &dynamodb.AttributeValue{S: aws.String(o.userId)}
+	}
+
 	var ret []*UserKifu
 	var rerr error
 	if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(db.tableName),
-		IndexName:              aws.String("Sfen"),
-		KeyConditionExpression: aws.String("#sfen = :sfen"),
-		ExpressionAttributeNames: map[string]*string{
-			"#sfen": aws.String(sfenAttr),
-		},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":sfen": &dynamodb.AttributeValue{S: aws.String(sfen)},
-		},
-		ProjectionExpression: aws.String(strings.Join([]string{kifuIdAttr, userIdAttr}, ",")),
+		TableName:                 aws.String(db.tableName),
+		IndexName:                 aws.String("Sfen"),
+		KeyConditionExpression:    aws.String(keyCond),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+		ProjectionExpression:      aws.String(strings.Join([]string{kifuIdAttr, "#userId"}, ",")),
 	}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
 		select {
 		case <-ctx.Done():
@@ -551,22 +1218,45 @@ func (db *DynamoDB) GetSamePositions(ctx context.Context, userIds []string, pos
 		f(opts)
 	}
 
+	users := make(map[string]struct{})
+	for _, userId := range userIds {
+		users[userId] = struct{}{}
+	}
+	excludes := make(map[string]struct{})
+	for _, kifuId := range opts.excludeKifuIds {
+		excludes[kifuId] = struct{}{}
+	}
+
+	indexName, keyAttr, key := "Position", posAttr, pos
+	if opts.normalized {
+		k, _, _, err := position.Canonical(pos, position.Mirror|position.ColorFlip)
+		if err != nil {
+			return nil, &ErrInvalidValue{
+				Details: "position: " + err.Error(),
+			}
+		}
+		indexName, keyAttr, key = "NormalizedPosition", cposAttr, k
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	stepKeyCh := make(chan *stepKey, db.parallelism)
 	g.Go(func() error {
 		defer close(stepKeyCh)
 
+		// whether the kifu passes the filter
+		accepted := make(map[string]bool)
+		var sent int
 		var rerr error
 		if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
 			TableName:              aws.String(db.tableName),
-			IndexName:              aws.String("Position"),
+			IndexName:              aws.String(indexName),
 			KeyConditionExpression: aws.String("#pos = :pos"),
 			ExpressionAttributeNames: map[string]*string{
-				"#pos": aws.String(posAttr),
+				"#pos": aws.String(keyAttr),
 			},
 			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-				":pos": &dynamodb.AttributeValue{S: aws.String(pos)},
+				":pos": &dynamodb.AttributeValue{S: aws.String(key)},
 			},
 			ProjectionExpression: aws.String(strings.Join([]string{kifuIdAttr, seqAttr, userIdAttr}, ",")),
 		}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
@@ -583,7 +1273,42 @@ func (db *DynamoDB) GetSamePositions(ctx context.Context, userIds []string, pos
 				return false
 			}
 
+			var candidates []DynamoDBKifuRecord
 			for _, r := range records {
+				if _, ok := users[r.UserId]; !ok {
+					continue
+				}
+				if _, ok := excludes[r.KifuId]; ok {
+					continue
+				}
+				candidates = append(candidates, r)
+			}
+
+			if opts.filter != nil {
+				var kifuIds []string
+				for _, r := range candidates {
+					if _, ok := accepted[r.KifuId]; !ok {
+						kifuIds = append(kifuIds, r.KifuId)
+					}
+				}
+				if len(kifuIds) > 0 {
+					kifus, err := db.BatchGetKifu(ctx, kifuIds)
+					if err != nil {
+						rerr = err
+						return false
+					}
+					for _, kifuId := range kifuIds {
+						kifu, ok := kifus[kifuId]
+						accepted[kifuId] = ok && opts.filter(kifu)
+					}
+				}
+			}
+
+			for _, r := range candidates {
+				if opts.filter != nil && !accepted[r.KifuId] {
+					continue
+				}
+
 				select {
 				case stepKeyCh <- &stepKey{
 					kifuId: r.KifuId,
@@ -594,6 +1319,11 @@ func (db *DynamoDB) GetSamePositions(ctx context.Context, userIds []string, pos
 					rerr = ctx.Err()
 					return false
 				}
+
+				sent++
+				if opts.limit > 0 && sent == opts.limit {
+					return false
+				}
 			}
 
 			return true
@@ -608,55 +1338,16 @@ func (db *DynamoDB) GetSamePositions(ctx context.Context, userIds []string, pos
 	for i := 0; i < db.parallelism; i++ {
 		g.Go(func() error {
 			for stepKey := range stepKeyCh {
-				var steps []*documentpb.Step
-				var rerr error
-				if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
-					TableName:              aws.String(db.tableName),
-					KeyConditionExpression: aws.String("#kifuId = :kifuId AND #seq >= :seqStart AND #seq < :seqEnd"),
-					ExpressionAttributeNames: map[string]*string{
-						"#kifuId": aws.String(kifuIdAttr),
-						"#seq":    aws.String(seqAttr),
-					},
-					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-						":kifuId":   &dynamodb.AttributeValue{S: aws.String(stepKey.kifuId)},
-						":seqStart": &dynamodb.AttributeValue{N: aws.String(fmt.Sprintf("%d", stepKey.seq))},
-						":seqEnd":   &dynamodb.AttributeValue{N: aws.String(fmt.Sprintf("%d", int32(stepKey.seq)+opts.numStep))},
-					},
-					ProjectionExpression: aws.String(stepAttr),
-				}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
-					select {
-					case <-ctx.Done():
-						rerr = ctx.Err()
-						return false
-					default:
-					}
-
-					var records []DynamoDBKifuRecord
-					if err := dynamodbattribute.UnmarshalListOfMaps(out.Items, &records); err != nil {
-						rerr = err
-						return false
-					}
-					for _, r := range records {
-						var step documentpb.Step
-						if err := proto.Unmarshal(r.Step, &step); err != nil {
-							rerr = err
-							return false
-						}
-
-						steps = append(steps, &step)
-					}
-
-					return true
-				}); err != nil {
-					return err
-				} else if rerr != nil {
-					return rerr
-				}
+				steps, err := db.getSteps(ctx, stepKey.kifuId, stepKey.seq, stepKey.seq+opts.numStep)
+				if err != nil {
+					return err
+				}
 
 				select {
 				case posCh <- &Position{
 					KifuId: stepKey.kifuId,
 					UserId: stepKey.userId,
+					Seq:    stepKey.seq,
 					Steps:  steps,
 				}:
 				case <-ctx.Done():
@@ -685,10 +1376,81 @@ func (db *DynamoDB) GetSamePositions(ctx context.Context, userIds []string, pos
 	return ret, nil
 }
 
-func (db *DynamoDB) GetRecentKifu(ctx context.Context, userId string, limit int) ([]*documentpb.Kifu, error) {
-	var ret []*documentpb.Kifu
-	var rerr error
-	if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+// getSteps returns steps of the kifu which satisfy start <= seq < end.
+func (db *DynamoDB) getSteps(ctx context.Context, kifuId string, start, end int32) ([]*documentpb.Step, error) {
+	var ret []*documentpb.Step
+	for start < end {
+		n := end - start
+		if n > 100 {
+			n = 100
+		}
+
+		var keys []map[string]*dynamodb.AttributeValue
+		for seq := start; seq < start+n; seq++ {
+			key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+				KifuId: kifuId,
+				Var:    stepVar(seq),
+			})
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		start += n
+
+		items := map[string]*dynamodb.KeysAndAttributes{
+			db.tableName: &dynamodb.KeysAndAttributes{
+				Keys:                 keys,
+				ProjectionExpression: aws.String(stepAttr),
+			},
+		}
+		for len(items) != 0 {
+			out, err := db.client.BatchGetItemWithContext(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: items,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			var records []DynamoDBKifuRecord
+			if err := dynamodbattribute.UnmarshalListOfMaps(out.Responses[db.tableName], &records); err != nil {
+				return nil, err
+			}
+			for _, r := range records {
+				var step documentpb.Step
+				if err := proto.Unmarshal(r.Step, &step); err != nil {
+					return nil, &ErrInvalidValue{
+						Details: err.Error(),
+					}
+				}
+
+				ret = append(ret, &step)
+			}
+
+			items = out.UnprocessedKeys
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].GetSeq() < ret[j].GetSeq()
+	})
+
+	return ret, nil
+}
+
+// GetRecentKifu returns the kifus of the user, the most recently created first. 0 limit means unlimited.
+func (db *DynamoDB) GetRecentKifu(
+	ctx context.Context,
+	userId string,
+	limit int,
+	options ...GetRecentKifuOption,
+) ([]*documentpb.Kifu, error) {
+	o := &getRecentKifuOptions{}
+	for _, f := range options {
+		f(o)
+	}
+
+	in := &dynamodb.QueryInput{
 		TableName:              aws.String(db.tableName),
 		IndexName:              aws.String("Created"),
 		KeyConditionExpression: aws.String("#userId = :userId"),
@@ -700,8 +1462,14 @@ func (db *DynamoDB) GetRecentKifu(ctx context.Context, userId string, limit int)
 		},
 		ProjectionExpression: aws.String(kifuAttr),
 		ScanIndexForward:     aws.Bool(false),
-		Limit:                aws.Int64(int64(limit)),
-	}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+	}
+	if limit > 0 {
+		in.Limit = aws.Int64(int64(limit))
+	}
+
+	var ret []*documentpb.Kifu
+	var rerr error
+	if err := db.client.QueryPagesWithContext(ctx, in, func(out *dynamodb.QueryOutput, lastPage bool) bool {
 		select {
 		case <-ctx.Done():
 			rerr = ctx.Err()
@@ -721,7 +1489,14 @@ func (db *DynamoDB) GetRecentKifu(ctx context.Context, userId string, limit int)
 				return false
 			}
 
+			if o.filter != nil && !o.filter(&kifu) {
+				continue
+			}
+
 			ret = append(ret, &kifu)
+			if len(ret) == limit {
+				return false
+			}
 		}
 
 		return true
@@ -746,7 +1521,7 @@ func (db *DynamoDB) DeleteKifu(ctx context.Context, kifuId string, version int64
 	out, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
 		TableName: aws.String(db.tableName),
 
-		ConditionExpression: aws.String("#version == :version"),
+		ConditionExpression: aws.String("#version = :version"),
 		ExpressionAttributeNames: map[string]*string{
 			"#version": aws.String(versionAttr),
 		},
@@ -768,16 +1543,20 @@ func (db *DynamoDB) DeleteKifu(ctx context.Context, kifuId string, version int64
 		return err
 	}
 
+	if err := db.deleteAliases(ctx, &old); err != nil {
+		return err
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	reqCh := make(chan *dynamodb.WriteRequest)
 	g.Go(func() error {
 		defer close(reqCh)
 
-		for i := int32(1); i <= old.StepNum; i++ {
+		for i := int32(0); i < old.StepNum; i++ {
 			key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
 				KifuId: kifuId,
-				Var:    kifuVar,
+				Var:    stepVar(i),
 			})
 			if err != nil {
 				return err
@@ -793,8 +1572,567 @@ func (db *DynamoDB) DeleteKifu(ctx context.Context, kifuId string, version int64
 
 		}
 
+		revVars, err := db.queryVars(ctx, kifuId, revisionVarPrefix)
+		if err != nil {
+			return err
+		}
+		tagVars, err := db.queryVars(ctx, kifuId, tagVarPrefix)
+		if err != nil {
+			return err
+		}
+		for _, v := range append(revVars, tagVars...) {
+			key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+				KifuId: kifuId,
+				Var:    v,
+			})
+			if err != nil {
+				return err
+			}
+
+			select {
+			case reqCh <- &dynamodb.WriteRequest{
+				DeleteRequest: &dynamodb.DeleteRequest{Key: key},
+			}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
 		return nil
 	})
 
 	return db.batchWrite(ctx, g, reqCh)
 }
+
+// deleteAliases deletes the ALIAS records referring to the deleted kifu.
+// The aliases moved to another kifu, e.g. by db dedupe, are kept.
+func (db *DynamoDB) deleteAliases(ctx context.Context, old *DynamoDBKifuRecord) error {
+	var kifu documentpb.Kifu
+	if err := proto.Unmarshal(old.Kifu, &kifu); err != nil {
+		return &ErrInvalidValue{
+			Details: err.Error(),
+		}
+	}
+
+	for _, aliasId := range kifu.GetAliases() {
+		key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+			KifuId: aliasId,
+			Var:    aliasVar,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(db.tableName),
+
+			ConditionExpression: aws.String("#aliasOf = :kifuId"),
+			ExpressionAttributeNames: map[string]*string{
+				"#aliasOf": aws.String(aliasOfAttr),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":kifuId": &dynamodb.AttributeValue{S: aws.String(old.KifuId)},
+			},
+			Key: key,
+		}); err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *DynamoDB) queryVars(ctx context.Context, kifuId, prefix string) ([]string, error) {
+	var ret []string
+	var rerr error
+	if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		KeyConditionExpression: aws.String("#kifuId = :kifuId AND begins_with(#var, :prefix)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#kifuId": aws.String(kifuIdAttr),
+			"#var":    aws.String(varAttr),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":kifuId": &dynamodb.AttributeValue{S: aws.String(kifuId)},
+			":prefix": &dynamodb.AttributeValue{S: aws.String(prefix)},
+		},
+		ProjectionExpression: aws.String("#var"),
+	}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+		select {
+		case <-ctx.Done():
+			rerr = ctx.Err()
+			return false
+		default:
+		}
+
+		var records []DynamoDBKifuRecord
+		if err := dynamodbattribute.UnmarshalListOfMaps(out.Items, &records); err != nil {
+			rerr = err
+			return false
+		}
+		for _, r := range records {
+			ret = append(ret, r.Var)
+		}
+
+		return true
+	}); err != nil {
+		return nil, err
+	} else if rerr != nil {
+		return nil, rerr
+	}
+
+	return ret, nil
+}
+
+func (db *DynamoDB) ListKifuRevisions(ctx context.Context, kifuId string) ([]*documentpb.KifuRevision, error) {
+	var ret []*documentpb.KifuRevision
+	var rerr error
+	if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		KeyConditionExpression: aws.String("#kifuId = :kifuId AND begins_with(#var, :prefix)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#kifuId": aws.String(kifuIdAttr),
+			"#var":    aws.String(varAttr),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":kifuId": &dynamodb.AttributeValue{S: aws.String(kifuId)},
+			":prefix": &dynamodb.AttributeValue{S: aws.String(revisionVarPrefix)},
+		},
+		ProjectionExpression: aws.String(revisionAttr),
+	}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+		select {
+		case <-ctx.Done():
+			rerr = ctx.Err()
+			return false
+		default:
+		}
+
+		var records []DynamoDBKifuRecord
+		if err := dynamodbattribute.UnmarshalListOfMaps(out.Items, &records); err != nil {
+			rerr = err
+			return false
+		}
+		for _, r := range records {
+			var rev documentpb.KifuRevision
+			if err := proto.Unmarshal(r.Revision, &rev); err != nil {
+				rerr = &ErrInvalidValue{
+					Details: err.Error(),
+				}
+				return false
+			}
+
+			ret = append(ret, &rev)
+		}
+
+		return true
+	}); err != nil {
+		return nil, err
+	} else if rerr != nil {
+		return nil, rerr
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].GetVersion() > ret[j].GetVersion() })
+
+	return ret, nil
+}
+
+func (db *DynamoDB) GetKifuRevision(ctx context.Context, kifuId string, version int64) (*documentpb.KifuRevision, error) {
+	key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+		KifuId: kifuId,
+		Var:    revisionVar(version),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out, err := db.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:            aws.String(db.tableName),
+		Key:                  key,
+		ProjectionExpression: aws.String(revisionAttr),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Item) == 0 {
+		return nil, ErrEmpty
+	}
+
+	var record DynamoDBKifuRecord
+	if err := dynamodbattribute.UnmarshalMap(out.Item, &record); err != nil {
+		return nil, err
+	}
+
+	var rev documentpb.KifuRevision
+	if err := proto.Unmarshal(record.Revision, &rev); err != nil {
+		return nil, &ErrInvalidValue{
+			Details: err.Error(),
+		}
+	}
+
+	return &rev, nil
+}
+
+func (db *DynamoDB) queryRecords(ctx context.Context, kifuId string) ([]*DynamoDBKifuRecord, error) {
+	var ret []*DynamoDBKifuRecord
+	var rerr error
+	if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		KeyConditionExpression: aws.String("#kifuId = :kifuId"),
+		ExpressionAttributeNames: map[string]*string{
+			"#kifuId": aws.String(kifuIdAttr),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":kifuId": &dynamodb.AttributeValue{S: aws.String(kifuId)},
+		},
+	}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+		select {
+		case <-ctx.Done():
+			rerr = ctx.Err()
+			return false
+		default:
+		}
+
+		var records []*DynamoDBKifuRecord
+		if err := dynamodbattribute.UnmarshalListOfMaps(out.Items, &records); err != nil {
+			rerr = err
+			return false
+		}
+		ret = append(ret, records...)
+
+		return true
+	}); err != nil {
+		return nil, err
+	} else if rerr != nil {
+		return nil, rerr
+	}
+
+	return ret, nil
+}
+
+// rewriteKifu puts the KIFU record with optimistic locking and then puts the other records of the kifu.
+// f is called with the KIFU record first.
+func (db *DynamoDB) rewriteKifu(
+	ctx context.Context,
+	kifuId string,
+	version int64,
+	f func(*DynamoDBKifuRecord) error,
+) (int64, error) {
+	recs, err := db.queryRecords(ctx, kifuId)
+	if err != nil {
+		return 0, err
+	}
+
+	var kifuRec *DynamoDBKifuRecord
+	var others []*DynamoDBKifuRecord
+	for _, rec := range recs {
+		if rec.Var == kifuVar {
+			kifuRec = rec
+			continue
+		}
+		others = append(others, rec)
+	}
+	if kifuRec == nil {
+		return 0, ErrEmpty
+	}
+
+	newVersion := time.Now().UnixNano()
+	kifuRec.Version = newVersion
+	if err := f(kifuRec); err != nil {
+		return 0, err
+	}
+
+	kifuAv, err := dynamodbattribute.MarshalMap(kifuRec)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName),
+
+		ConditionExpression: aws.String("#version = :version"),
+		ExpressionAttributeNames: map[string]*string{
+			"#version": aws.String(versionAttr),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":version": &dynamodb.AttributeValue{
+				N: aws.String(fmt.Sprintf("%d", version)),
+			},
+		},
+		Item: kifuAv,
+	}); err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case dynamodb.ErrCodeConditionalCheckFailedException:
+				return 0, ErrLockError
+			}
+		}
+		return 0, err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	reqCh := make(chan *dynamodb.WriteRequest)
+	g.Go(func() error {
+		defer close(reqCh)
+
+		for _, rec := range others {
+			if err := f(rec); err != nil {
+				return err
+			}
+
+			av, err := dynamodbattribute.MarshalMap(rec)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case reqCh <- &dynamodb.WriteRequest{
+				PutRequest: &dynamodb.PutRequest{Item: av},
+			}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		return nil
+	})
+
+	if err := db.batchWrite(ctx, g, reqCh); err != nil {
+		return 0, err
+	}
+
+	return newVersion, nil
+}
+
+// TrashKifu marks the kifu as trashed.
+// Trashed kifu are removed from the indexes and purged by DynamoDB TTL after the retention period.
+func (db *DynamoDB) TrashKifu(ctx context.Context, kifuId string, version int64) (int64, error) {
+	now := time.Now()
+	ttl := now.Add(db.trashRetention).Unix()
+
+	return db.rewriteKifu(ctx, kifuId, version, func(rec *DynamoDBKifuRecord) error {
+		return trashRecord(rec, now.Unix(), ttl)
+	})
+}
+
+func trashRecord(rec *DynamoDBKifuRecord, trashedTs, ttl int64) error {
+	switch {
+	case rec.Var == kifuVar:
+		rec.CreatedTs = 0
+		rec.StartTs = 0
+		rec.Sfen = ""
+		rec.TrashedTs = trashedTs
+	case isStepVar(rec.Var):
+		rec.Pos = ""
+		rec.CPos = ""
+	case isTagVar(rec.Var):
+		rec.UserTag = ""
+	}
+	rec.Ttl = ttl
+
+	return nil
+}
+
+// RestoreKifu restores the trashed kifu. It returns ErrEmpty if the retention period is over,
+// because DynamoDB TTL deletes the expired records lazily and some of them may be gone.
+func (db *DynamoDB) RestoreKifu(ctx context.Context, kifuId string, version int64) (int64, error) {
+	now := time.Now().Unix()
+
+	var userId string
+	return db.rewriteKifu(ctx, kifuId, version, func(rec *DynamoDBKifuRecord) error {
+		if rec.Var == kifuVar {
+			if rec.Ttl != 0 && rec.Ttl <= now {
+				return ErrEmpty
+			}
+			userId = rec.UserId
+		}
+		return restoreRecord(rec, userId)
+	})
+}
+
+// restoreRecord recomputes the index keys of the record of the kifu of the user.
+func restoreRecord(rec *DynamoDBKifuRecord, userId string) error {
+	switch {
+	case rec.Var == kifuVar:
+		var kifu documentpb.Kifu
+		if err := proto.Unmarshal(rec.Kifu, &kifu); err != nil {
+			return &ErrInvalidValue{
+				Details: err.Error(),
+			}
+		}
+
+		rec.CreatedTs = kifu.GetCreatedTs()
+		rec.StartTs = kifu.GetStartTs()
+		rec.Sfen = kifu.GetSfen()
+		rec.TrashedTs = 0
+	case isStepVar(rec.Var):
+		var step documentpb.Step
+		if err := proto.Unmarshal(rec.Step, &step); err != nil {
+			return &ErrInvalidValue{
+				Details: err.Error(),
+			}
+		}
+
+		rec.Pos = step.GetPosition()
+		rec.CPos = canonicalPos(step.GetPosition())
+	case isTagVar(rec.Var):
+		rec.UserTag = userTag(userId, strings.TrimPrefix(rec.Var, tagVarPrefix))
+	}
+	rec.Ttl = 0
+
+	return nil
+}
+
+// ReindexKifu recomputes the index keys of the records of the kifu from their contents,
+// e.g. the records put before the index was added.
+func (db *DynamoDB) ReindexKifu(ctx context.Context, kifuId string, version int64) (int64, error) {
+	var userId string
+	return db.rewriteKifu(ctx, kifuId, version, func(rec *DynamoDBKifuRecord) error {
+		if rec.Var == kifuVar {
+			if rec.TrashedTs != 0 {
+				return ErrTrashed
+			}
+			userId = rec.UserId
+		}
+		return restoreRecord(rec, userId)
+	})
+}
+
+func (db *DynamoDB) ListTrash(ctx context.Context, userId string) ([]*TrashedKifu, error) {
+	var ret []*TrashedKifu
+	var rerr error
+	if err := db.client.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(db.tableName),
+		IndexName:              aws.String("Trash"),
+		KeyConditionExpression: aws.String("#userId = :userId"),
+		// DynamoDB TTL deletes the expired items lazily.
+		FilterExpression: aws.String("#ttl > :now"),
+		ExpressionAttributeNames: map[string]*string{
+			"#userId": aws.String(userIdAttr),
+			"#ttl":    aws.String(ttlAttr),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":userId": &dynamodb.AttributeValue{S: aws.String(userId)},
+			":now":    &dynamodb.AttributeValue{N: aws.String(fmt.Sprintf("%d", time.Now().Unix()))},
+		},
+		ProjectionExpression: aws.String(strings.Join([]string{kifuAttr, versionAttr, trashedTsAttr, "#ttl"}, ",")),
+		ScanIndexForward:     aws.Bool(false),
+	}, func(out *dynamodb.QueryOutput, lastPage bool) bool {
+		select {
+		case <-ctx.Done():
+			rerr = ctx.Err()
+			return false
+		default:
+		}
+
+		var records []DynamoDBKifuRecord
+		if err := dynamodbattribute.UnmarshalListOfMaps(out.Items, &records); err != nil {
+			rerr = err
+			return false
+		}
+		for _, rec := range records {
+			var kifu documentpb.Kifu
+			if err := proto.Unmarshal(rec.Kifu, &kifu); err != nil {
+				rerr = err
+				return false
+			}
+
+			ret = append(ret, &TrashedKifu{
+				Kifu:      &kifu,
+				Version:   rec.Version,
+				TrashedTs: rec.TrashedTs,
+				PurgeTs:   rec.Ttl,
+			})
+		}
+
+		return true
+	}); err != nil {
+		return nil, err
+	} else if rerr != nil {
+		return nil, rerr
+	}
+
+	return ret, nil
+}
+
+// PutTeam puts the team with optimistic locking. Team records share the table with kifu,
+// the team id is stored as kifuId.
+func (db *DynamoDB) PutTeam(ctx context.Context, team *documentpb.Team, version int64) (int64, error) {
+	bs, err := proto.Marshal(team)
+	if err != nil {
+		return 0, err
+	}
+
+	newVersion := time.Now().UnixNano()
+	av, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+		KifuId:  team.GetTeamId(),
+		Var:     teamVar,
+		Team:    bs,
+		Version: newVersion,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName),
+
+		ConditionExpression: aws.String("attribute_not_exists(#version) OR #version = :version"),
+		ExpressionAttributeNames: map[string]*string{
+			"#version": aws.String(versionAttr),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":version": &dynamodb.AttributeValue{
+				N: aws.String(fmt.Sprintf("%d", version)),
+			},
+		},
+		Item: av,
+	}); err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case dynamodb.ErrCodeConditionalCheckFailedException:
+				return 0, ErrLockError
+			}
+		}
+		return 0, err
+	}
+
+	return newVersion, nil
+}
+
+func (db *DynamoDB) GetTeam(ctx context.Context, teamId string) (*documentpb.Team, int64, error) {
+	key, err := dynamodbattribute.MarshalMap(DynamoDBKifuRecord{
+		KifuId: teamId,
+		Var:    teamVar,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	out, err := db.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:            aws.String(db.tableName),
+		Key:                  key,
+		ProjectionExpression: aws.String(strings.Join([]string{teamAttr, versionAttr}, ",")),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(out.Item) == 0 {
+		return nil, 0, ErrEmpty
+	}
+
+	var rec DynamoDBKifuRecord
+	if err := dynamodbattribute.UnmarshalMap(out.Item, &rec); err != nil {
+		return nil, 0, err
+	}
+
+	var team documentpb.Team
+	if err := proto.Unmarshal(rec.Team, &team); err != nil {
+		return nil, 0, &ErrInvalidValue{
+			Details: err.Error(),
+		}
+	}
+
+	return &team, rec.Version, nil
+}
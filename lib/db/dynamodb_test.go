@@ -4,11 +4,18 @@ import (
 	"testing"
 
 	"context"
+	"reflect"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
 )
 
 func TestAttributeValue(t *testing.T) {
@@ -24,6 +31,87 @@ func TestAttributeValue(t *testing.T) {
 	}
 }
 
+func TestTrashAndRestoreRecord(t *testing.T) {
+	kifuBs, err := proto.Marshal(&documentpb.Kifu{
+		KifuId:    "test-kifu-id",
+		CreatedTs: 100,
+		StartTs:   200,
+		Sfen:      "position startpos moves 7g7f",
+	})
+	if err != nil {
+		t.Fatalf("proto.Marshal: %v", err)
+	}
+	stepBs, err := proto.Marshal(&documentpb.Step{
+		Seq:      1,
+		Position: "test-position",
+	})
+	if err != nil {
+		t.Fatalf("proto.Marshal: %v", err)
+	}
+
+	kifuRec := &DynamoDBKifuRecord{
+		KifuId:    "test-kifu-id",
+		Var:       kifuVar,
+		CreatedTs: 100,
+		StartTs:   200,
+		Sfen:      "position startpos moves 7g7f",
+		Kifu:      kifuBs,
+	}
+	stepRec := &DynamoDBKifuRecord{
+		KifuId: "test-kifu-id",
+		Var:    stepVar(1),
+		Seq:    1,
+		Pos:    "test-position",
+		Step:   stepBs,
+	}
+	tagRec := &DynamoDBKifuRecord{
+		KifuId:  "test-kifu-id",
+		Var:     tagVar("test-tag"),
+		UserTag: userTag("test-user-id", "test-tag"),
+	}
+
+	for _, rec := range []*DynamoDBKifuRecord{kifuRec, stepRec, tagRec} {
+		if err := trashRecord(rec, 300, 400); err != nil {
+			t.Fatalf("trashRecord: %v", err)
+		}
+	}
+
+	av, err := dynamodbattribute.MarshalMap(kifuRec)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	for _, attr := range []string{createdTsAttr, "startTs", sfenAttr} {
+		if _, ok := av[attr]; ok {
+			t.Errorf("trashed kifu has %s", attr)
+		}
+	}
+	if kifuRec.TrashedTs != 300 || kifuRec.Ttl != 400 {
+		t.Errorf("trashed kifu: %#v", kifuRec)
+	}
+	if stepRec.Pos != "" || stepRec.Ttl != 400 {
+		t.Errorf("trashed step: %#v", stepRec)
+	}
+	if tagRec.UserTag != "" || tagRec.Ttl != 400 {
+		t.Errorf("trashed tag: %#v", tagRec)
+	}
+
+	for _, rec := range []*DynamoDBKifuRecord{kifuRec, stepRec, tagRec} {
+		if err := restoreRecord(rec, "test-user-id"); err != nil {
+			t.Fatalf("restoreRecord: %v", err)
+		}
+	}
+
+	if kifuRec.CreatedTs != 100 || kifuRec.StartTs != 200 || kifuRec.Sfen == "" || kifuRec.TrashedTs != 0 || kifuRec.Ttl != 0 {
+		t.Errorf("restored kifu: %#v", kifuRec)
+	}
+	if stepRec.Pos != "test-position" || stepRec.Ttl != 0 {
+		t.Errorf("restored step: %#v", stepRec)
+	}
+	if tagRec.UserTag != userTag("test-user-id", "test-tag") || tagRec.Ttl != 0 {
+		t.Errorf("restored tag: %#v", tagRec)
+	}
+}
+
 const (
 	num  = 1000
 	unit = 25
@@ -154,3 +242,32 @@ func BenchmarkChanChan(b *testing.B) {
 		benchmarkChanChan(ctx, as[:])
 	}
 }
+
+func TestPageToken(t *testing.T) {
+	key := map[string]*dynamodb.AttributeValue{
+		"kifuId":  &dynamodb.AttributeValue{S: aws.String("kifu-1")},
+		"var":     &dynamodb.AttributeValue{S: aws.String("TAG:to review")},
+		"userTag": &dynamodb.AttributeValue{S: aws.String("user:to review")},
+	}
+
+	token, err := encodePageToken(key)
+	if err != nil {
+		t.Fatalf("encodePageToken: %v", err)
+	}
+
+	decoded, err := decodePageToken(token)
+	if err != nil {
+		t.Fatalf("decodePageToken: %v", err)
+	}
+	if !reflect.DeepEqual(key, decoded) {
+		t.Errorf("expected=%v actual=%v", key, decoded)
+	}
+
+	if token, err := encodePageToken(nil); err != nil || token != "" {
+		t.Errorf("empty key: token=%q err=%v", token, err)
+	}
+
+	if _, err := decodePageToken("!invalid"); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,175 @@
+// Package impasse evaluates the entering king (入玉) positions.
+package impasse
+
+import (
+	"github.com/yunomu/kansousen/lib/shogi"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+const (
+	// the points which the 24-point rule requires to avoid losing.
+	rule24Points = 24
+	// the number of the pieces in the zone which the 27-point declaration requires.
+	declarePieces = 10
+)
+
+type Score struct {
+	// KingEntered reports whether the king is in the promotion zone.
+	KingEntered bool
+	// Pieces is the number of the pieces in the promotion zone excluding the king.
+	Pieces int
+	// Points is the points of the pieces in the promotion zone and in hand,
+	// which is used by the 27-point declaration.
+	Points int
+	// Total is the points of all the pieces, which is used by the 24-point rule.
+	Total int
+}
+
+func piecePoint(t shogi.PieceType) int {
+	switch t.Unpromote() {
+	case shogi.King:
+		return 0
+	case shogi.Rook, shogi.Bishop:
+		return 5
+	}
+	return 1
+}
+
+// Evaluate counts the points of c.
+func Evaluate(p *shogi.Position, c shogi.Color) Score {
+	var s Score
+
+	for i, pc := range p.Board {
+		if pc.Empty() || pc.Color != c {
+			continue
+		}
+		sq := shogi.Square(i)
+		zone := shogi.InPromotionZone(sq, c)
+
+		if pc.Type == shogi.King {
+			s.KingEntered = zone
+			continue
+		}
+
+		pt := piecePoint(pc.Type)
+		s.Total += pt
+		if zone {
+			s.Pieces++
+			s.Points += pt
+		}
+	}
+
+	for _, t := range shogi.HandTypes {
+		pt := piecePoint(t) * p.Hands[c][t]
+		s.Total += pt
+		s.Points += pt
+	}
+
+	return s
+}
+
+// CanDeclare reports whether the side to move can declare the win by the 27-point rule.
+func CanDeclare(p *shogi.Position) bool {
+	c := p.Side
+	s := Evaluate(p, c)
+
+	required := 27
+	if c == shogi.Black {
+		required = 28
+	}
+
+	return s.KingEntered && s.Pieces >= declarePieces && s.Points >= required && !p.InCheck(c)
+}
+
+type Result int
+
+const (
+	ResultNone Result = iota
+	ResultDraw
+	ResultBlackWin
+	ResultWhiteWin
+)
+
+func (r Result) String() string {
+	switch r {
+	case ResultDraw:
+		return "DRAW"
+	case ResultBlackWin:
+		return "BLACK_WIN"
+	case ResultWhiteWin:
+		return "WHITE_WIN"
+	}
+	return "NONE"
+}
+
+// Judge24 judges the position by the 24-point rule.
+// It returns ResultNone unless both kings have entered.
+func Judge24(p *shogi.Position) Result {
+	b, w := Evaluate(p, shogi.Black), Evaluate(p, shogi.White)
+	switch {
+	case !b.KingEntered || !w.KingEntered:
+		return ResultNone
+	case b.Total < rule24Points:
+		return ResultWhiteWin
+	case w.Total < rule24Points:
+		return ResultBlackWin
+	}
+	return ResultDraw
+}
+
+type Point struct {
+	Seq   int32
+	Black Score
+	White Score
+	// Declarable reports whether the side to move can declare by the 27-point rule.
+	Declarable bool
+}
+
+// Timeline returns the points of each step after either king entered the promotion zone.
+func Timeline(steps []*documentpb.Step) ([]*Point, error) {
+	var ret []*Point
+	for i, step := range steps {
+		if i > 0 && step.GetSfen() == "" {
+			break
+		}
+
+		p, err := shogi.FromSFEN(step.GetPosition())
+		if err != nil {
+			return nil, err
+		}
+
+		pt := &Point{
+			Seq:   step.GetSeq(),
+			Black: Evaluate(p, shogi.Black),
+			White: Evaluate(p, shogi.White),
+		}
+		if len(ret) == 0 && !pt.Black.KingEntered && !pt.White.KingEntered {
+			continue
+		}
+		pt.Declarable = CanDeclare(p)
+
+		ret = append(ret, pt)
+	}
+
+	return ret, nil
+}
+
+// EndsInImpasse reports whether the game ended in the entering king situation.
+func EndsInImpasse(steps []*documentpb.Step) (bool, error) {
+	if len(steps) == 0 {
+		return false, nil
+	}
+
+	last := steps[len(steps)-1]
+	switch last.GetFinishedStatus() {
+	case documentpb.FinishedStatus_NYUGYOKU_WIN, documentpb.FinishedStatus_DRAW:
+		return true, nil
+	}
+
+	p, err := shogi.FromSFEN(last.GetPosition())
+	if err != nil {
+		return false, err
+	}
+	return Evaluate(p, shogi.Black).KingEntered || Evaluate(p, shogi.White).KingEntered, nil
+}
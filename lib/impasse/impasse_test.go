@@ -0,0 +1,89 @@
+package impasse
+
+import (
+	"testing"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func TestEvaluate(t *testing.T) {
+	p := shogi.NewStartpos()
+	for c := shogi.Black; c <= shogi.White; c++ {
+		s := Evaluate(p, c)
+		if s.KingEntered || s.Pieces != 0 || s.Points != 0 || s.Total != 27 {
+			t.Errorf("%v: %+v", c, s)
+		}
+	}
+	if r := Judge24(p); r != ResultNone {
+		t.Errorf("Judge24: %v", r)
+	}
+}
+
+func TestCanDeclare(t *testing.T) {
+	// black king and 11 pieces in the zone, 19 points with 14 in hand.
+	p, err := shogi.FromSFEN("+R+BGGSSK1n/PPPPP4/9/9/9/9/9/9/k8 b RBGN2P 1")
+	if err != nil {
+		t.Fatalf("FromSFEN: %v", err)
+	}
+
+	s := Evaluate(p, shogi.Black)
+	if !s.KingEntered || s.Pieces != 11 || s.Points != 33 {
+		t.Errorf("%+v", s)
+	}
+	if !CanDeclare(p) {
+		t.Errorf("expected declarable")
+	}
+
+	p.Hands[shogi.Black][shogi.Rook] = 0
+	p.Hands[shogi.Black][shogi.Pawn] = 0
+	p.Hands[shogi.White][shogi.Rook] = 1
+	p.Hands[shogi.White][shogi.Pawn] = 2
+	if CanDeclare(p) {
+		t.Errorf("expected not declarable: %+v", Evaluate(p, shogi.Black))
+	}
+}
+
+func TestJudge24(t *testing.T) {
+	tests := []struct {
+		sfen     string
+		expected Result
+	}{
+		{"K8/9/9/9/9/9/9/9/8k b 2r2b4g4s4n4l18p 1", ResultWhiteWin},
+		{"K8/9/9/9/9/9/9/9/8k b 2R2B4G4S4N4L18P 1", ResultBlackWin},
+		{"K8/9/9/9/9/9/9/9/8k b RB2G2S2N2L9Prb2g2s2n2l9p 1", ResultDraw},
+		{"9/9/9/K8/9/9/9/9/8k b RB2G2S2N2L9Prb2g2s2n2l9p 1", ResultNone},
+	}
+	for _, test := range tests {
+		p, err := shogi.FromSFEN(test.sfen)
+		if err != nil {
+			t.Fatalf("FromSFEN: %v", err)
+		}
+		if r := Judge24(p); r != test.expected {
+			t.Errorf("%s: expected=%v actual=%v", test.sfen, test.expected, r)
+		}
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	steps := []*documentpb.Step{
+		{Seq: 0, Position: "9/9/9/K8/9/8k/9/9/9 b - 1"},
+		{Seq: 1, Position: "9/9/K8/9/9/8k/9/9/9 w - 1", Sfen: "9d9c"},
+		{Seq: 2, Position: "9/9/K8/9/9/9/8k/9/9 b - 1", Sfen: "1f1g"},
+		{Seq: 3, Position: "9/9/K8/9/9/9/8k/9/9 b - 1", FinishedStatus: documentpb.FinishedStatus_DRAW},
+	}
+
+	ok, err := EndsInImpasse(steps)
+	if err != nil || !ok {
+		t.Errorf("EndsInImpasse: %v %v", ok, err)
+	}
+
+	points, err := Timeline(steps)
+	if err != nil {
+		t.Fatalf("Timeline: %v", err)
+	}
+	if len(points) != 2 || points[0].Seq != 1 || !points[0].Black.KingEntered || points[0].White.KingEntered {
+		t.Errorf("unexpected timeline: %+v", points)
+	}
+}
@@ -0,0 +1,72 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrExpired              = errors.New("token is expired")
+)
+
+func hs256(input string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
+
+// SignHS256 returns the token of the payload signed with HMAC-SHA256.
+func SignHS256(payload map[string]interface{}, secret []byte) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	return input + "." + base64.RawURLEncoding.EncodeToString(hs256(input, secret)), nil
+}
+
+// VerifyHS256 verifies the HMAC-SHA256 signature and the exp claim of the token, and returns the payload.
+func VerifyHS256(s string, secret []byte, now time.Time) (map[string]interface{}, error) {
+	ss := strings.Split(s, ".")
+	if len(ss) != 3 {
+		return nil, ErrInvalidNumberOfSections
+	}
+
+	header, err := decodeSection(ss[0])
+	if err != nil {
+		return nil, err
+	}
+	if alg, _ := header["alg"].(string); alg != "HS256" {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(ss[2])
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sig, hs256(ss[0]+"."+ss[1], secret)) {
+		return nil, ErrInvalidSignature
+	}
+
+	payload, err := decodeSection(ss[1])
+	if err != nil {
+		return nil, err
+	}
+	if exp, ok := payload["exp"].(float64); ok && now.Unix() >= int64(exp) {
+		return nil, ErrExpired
+	}
+
+	return payload, nil
+}
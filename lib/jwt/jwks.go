@@ -0,0 +1,317 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownKey         = errors.New("unknown key")
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+)
+
+// KeySet returns the public key by the key ID (kid).
+type KeySet interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(bs), nil
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, fmt.Errorf("invalid exponent: %s", k.E)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrUnsupportedKeyType
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on the curve: %s", k.Kid)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
+
+// StaticKeySet is the keys of a JWKS document.
+type StaticKeySet map[string]crypto.PublicKey
+
+// ParseJWKS parses the JWKS document. The keys of unsupported types are ignored.
+func ParseJWKS(bs []byte) (StaticKeySet, error) {
+	doc := struct {
+		Keys []*jwk `json:"keys"`
+	}{}
+	if err := json.Unmarshal(bs, &doc); err != nil {
+		return nil, err
+	}
+
+	ret := StaticKeySet{}
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err == ErrUnsupportedKeyType {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		ret[k.Kid] = pub
+	}
+
+	return ret, nil
+}
+
+// LoadJWKSFile reads the JWKS document from the file.
+func LoadJWKSFile(path string) (StaticKeySet, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(bs)
+}
+
+func (s StaticKeySet) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	k, ok := s[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return k, nil
+}
+
+const (
+	DefaultJWKSCacheTTL = time.Hour
+	// the minimum interval of fetching the JWKS for an unknown key.
+	DefaultJWKSRefreshInterval = time.Minute
+	// how long the expired keys are used while the JWKS is unavailable.
+	DefaultJWKSMaxStale = 6 * time.Hour
+)
+
+// the maximum size of the JWKS document.
+const maxJWKSSize = 1 << 20
+
+// RemoteKeySet fetches the JWKS from the URL and caches it.
+// The JWKS is fetched again when the cache expires, or when an unknown key ID is requested
+// so that the rotated keys are found. A failed fetch is not retried within the refresh interval.
+// The expired keys are used while the JWKS is unavailable, up to the max stale after the expiration.
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	ttl             time.Duration
+	refreshInterval time.Duration
+	maxStale        time.Duration
+	now             func() time.Time
+
+	mu        sync.Mutex
+	keys      StaticKeySet
+	fetchedAt time.Time
+	// the time and the error of the last failed fetch.
+	failedAt time.Time
+	err      error
+	// closed when the ongoing fetch finishes. nil if not fetching.
+	fetching chan struct{}
+}
+
+type RemoteKeySetOption func(*RemoteKeySet)
+
+func RemoteKeySetSetHTTPClient(c *http.Client) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.client = c
+	}
+}
+
+func RemoteKeySetSetCacheTTL(d time.Duration) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.ttl = d
+	}
+}
+
+func RemoteKeySetSetRefreshInterval(d time.Duration) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.refreshInterval = d
+	}
+}
+
+func RemoteKeySetSetMaxStale(d time.Duration) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.maxStale = d
+	}
+}
+
+func RemoteKeySetSetClock(now func() time.Time) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.now = now
+	}
+}
+
+func NewRemoteKeySet(url string, opts ...RemoteKeySetOption) *RemoteKeySet {
+	s := &RemoteKeySet{
+		url:             url,
+		client:          http.DefaultClient,
+		ttl:             DefaultJWKSCacheTTL,
+		refreshInterval: DefaultJWKSRefreshInterval,
+		maxStale:        DefaultJWKSMaxStale,
+		now:             time.Now,
+	}
+
+	for _, f := range opts {
+		f(s)
+	}
+
+	return s
+}
+
+func (s *RemoteKeySet) fetch(ctx context.Context) (StaticKeySet, error) {
+	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: %s", res.Status)
+	}
+
+	bs, err := ioutil.ReadAll(io.LimitReader(res.Body, maxJWKSSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(bs) > maxJWKSSize {
+		return nil, errors.New("fetch JWKS: too large")
+	}
+	return ParseJWKS(bs)
+}
+
+func (s *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	for {
+		s.mu.Lock()
+		now := s.now()
+		k, found := s.keys[kid]
+		expired := s.keys == nil || now.Sub(s.fetchedAt) >= s.ttl
+		// the cached key is used in place of the unavailable JWKS until it gets too stale.
+		found = found && now.Sub(s.fetchedAt) < s.ttl+s.maxStale
+		if !expired {
+			if found {
+				s.mu.Unlock()
+				return k, nil
+			}
+			if now.Sub(s.fetchedAt) < s.refreshInterval {
+				s.mu.Unlock()
+				return nil, ErrUnknownKey
+			}
+		}
+
+		// keep using the cached keys if the JWKS is temporarily unavailable.
+		if s.err != nil && now.Sub(s.failedAt) < s.refreshInterval {
+			err := s.err
+			s.mu.Unlock()
+			if found {
+				return k, nil
+			}
+			return nil, err
+		}
+
+		// wait for the other caller fetching the JWKS, and look up again.
+		if ch := s.fetching; ch != nil {
+			s.mu.Unlock()
+			select {
+			case <-ch:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		ch := make(chan struct{})
+		s.fetching = ch
+		s.mu.Unlock()
+
+		keys, err := s.fetch(ctx)
+
+		s.mu.Lock()
+		s.fetching = nil
+		close(ch)
+		if err != nil {
+			// the canceled request does not mean the JWKS is unavailable.
+			if ctx.Err() == nil {
+				s.failedAt, s.err = s.now(), err
+			}
+			s.mu.Unlock()
+			if found {
+				return k, nil
+			}
+			return nil, err
+		}
+		s.keys, s.fetchedAt, s.err = keys, now, nil
+		s.mu.Unlock()
+
+		return keys.Key(ctx, kid)
+	}
+}
@@ -0,0 +1,37 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerifyHS256(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1600000000, 0)
+
+	token, err := SignHS256(map[string]interface{}{
+		"sub": "user-1",
+		"exp": now.Add(time.Hour).Unix(),
+	}, secret)
+	if err != nil {
+		t.Fatalf("SignHS256: %v", err)
+	}
+
+	payload, err := VerifyHS256(token, secret, now)
+	if err != nil {
+		t.Fatalf("VerifyHS256: %v", err)
+	}
+	if sub := payload["sub"]; sub != "user-1" {
+		t.Errorf("sub: %v", sub)
+	}
+
+	if _, err := VerifyHS256(token, []byte("other"), now); err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature: %v", err)
+	}
+	if _, err := VerifyHS256(token, secret, now.Add(2*time.Hour)); err != ErrExpired {
+		t.Errorf("expected ErrExpired: %v", err)
+	}
+	if _, err := VerifyHS256("a.b", secret, now); err != ErrInvalidNumberOfSections {
+		t.Errorf("expected ErrInvalidNumberOfSections: %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoExpiration    = errors.New("token has no exp")
+	ErrNotYetValid     = errors.New("token is not valid yet")
+	ErrInvalidIssuer   = errors.New("invalid issuer")
+	ErrInvalidAudience = errors.New("invalid audience")
+	ErrInvalidTokenUse = errors.New("invalid token_use")
+)
+
+// Verifier verifies the RS256 and ES256 signatures of the tokens with the keys of the JWKS, and the claims.
+type Verifier struct {
+	keys      KeySet
+	issuer    string
+	audiences []string
+	tokenUse  string
+	leeway    time.Duration
+	now       func() time.Time
+}
+
+type VerifierOption func(*Verifier)
+
+// VerifierSetIssuer requires the iss claim to be the issuer.
+func VerifierSetIssuer(issuer string) VerifierOption {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// VerifierAddAudience allows the audience. If any audience is added, the aud claim must contain one of them.
+// The client_id claim is checked instead for the access tokens of Cognito, which have no aud claim.
+func VerifierAddAudience(aud string) VerifierOption {
+	return func(v *Verifier) {
+		v.audiences = append(v.audiences, aud)
+	}
+}
+
+// VerifierSetTokenUse requires the token_use claim of Cognito, "id" or "access".
+func VerifierSetTokenUse(use string) VerifierOption {
+	return func(v *Verifier) {
+		v.tokenUse = use
+	}
+}
+
+// VerifierSetLeeway sets the allowed clock skew of exp and nbf.
+func VerifierSetLeeway(d time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.leeway = d
+	}
+}
+
+func VerifierSetClock(now func() time.Time) VerifierOption {
+	return func(v *Verifier) {
+		v.now = now
+	}
+}
+
+func NewVerifier(keys KeySet, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		keys: keys,
+		now:  time.Now,
+	}
+
+	for _, f := range opts {
+		f(v)
+	}
+
+	return v
+}
+
+func verifySignature(alg string, key crypto.PublicKey, input string, sig []byte) error {
+	hash := sha256.Sum256([]byte(input))
+
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], sig); err != nil {
+			return ErrInvalidSignature
+		}
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve.Params().BitSize != 256 {
+			return ErrUnsupportedAlgorithm
+		}
+		if len(sig) != 64 {
+			return ErrInvalidSignature
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, hash[:], r, s) {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+
+	return nil
+}
+
+func claimStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var ret []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	default:
+		return nil
+	}
+}
+
+func (v *Verifier) verifyClaims(payload map[string]interface{}) error {
+	now := v.now()
+
+	exp, ok := payload["exp"].(float64)
+	if !ok {
+		return ErrNoExpiration
+	}
+	if !now.Before(time.Unix(int64(exp), 0).Add(v.leeway)) {
+		return ErrExpired
+	}
+	if nbf, ok := payload["nbf"].(float64); ok && now.Add(v.leeway).Before(time.Unix(int64(nbf), 0)) {
+		return ErrNotYetValid
+	}
+
+	if v.issuer != "" {
+		if iss, _ := payload["iss"].(string); iss != v.issuer {
+			return ErrInvalidIssuer
+		}
+	}
+
+	if v.tokenUse != "" {
+		if use, _ := payload["token_use"].(string); use != v.tokenUse {
+			return ErrInvalidTokenUse
+		}
+	}
+
+	if len(v.audiences) != 0 {
+		auds := claimStrings(payload["aud"])
+		if _, ok := payload["aud"]; !ok {
+			auds = claimStrings(payload["client_id"])
+		}
+
+		found := false
+		for _, aud := range auds {
+			for _, a := range v.audiences {
+				if aud == a {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return ErrInvalidAudience
+		}
+	}
+
+	return nil
+}
+
+// Verify verifies the token and returns the payload.
+func (v *Verifier) Verify(ctx context.Context, token string) (map[string]interface{}, error) {
+	ss := strings.Split(token, ".")
+	if len(ss) != 3 {
+		return nil, ErrInvalidNumberOfSections
+	}
+
+	header, err := decodeSection(ss[0])
+	if err != nil {
+		return nil, err
+	}
+	alg, _ := header["alg"].(string)
+	kid, _ := header["kid"].(string)
+	if alg != "RS256" && alg != "ES256" {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(ss[2])
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := v.keys.Key(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(alg, key, ss[0]+"."+ss[1], sig); err != nil {
+		return nil, err
+	}
+
+	payload, err := decodeSection(ss[1])
+	if err != nil {
+		return nil, err
+	}
+	if err := v.verifyClaims(payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,319 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testNow = time.Unix(1600000000, 0)
+
+func b64(bs []byte) string {
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+func b64Int(n *big.Int, size int) string {
+	return b64(padBytes(n, size))
+}
+
+func signTest(t *testing.T, alg, kid string, key crypto.Signer, payload map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	body, _ := json.Marshal(payload)
+	input := b64(header) + "." + b64(body)
+	hash := sha256.Sum256([]byte(input))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hash[:])
+		if err != nil {
+			t.Fatalf("SignPKCS1v15: %v", err)
+		}
+		sig = s
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, hash[:])
+		if err != nil {
+			t.Fatalf("ecdsa.Sign: %v", err)
+		}
+		sig = append(padBytes(r, 32), padBytes(s, 32)...)
+	}
+	return input + "." + b64(sig)
+}
+
+func padBytes(n *big.Int, size int) []byte {
+	bs := n.Bytes()
+	return append(make([]byte, size-len(bs)), bs...)
+}
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	rk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	ek, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	return &testKeys{rsa: rk, ec: ek}
+}
+
+func (k *testKeys) jwks(rsaKid, ecKid string) []byte {
+	bs, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA", "kid": rsaKid, "alg": "RS256", "use": "sig",
+				"n": b64(k.rsa.N.Bytes()), "e": b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+			},
+			{
+				"kty": "EC", "kid": ecKid, "crv": "P-256",
+				"x": b64Int(k.ec.X, 32), "y": b64Int(k.ec.Y, 32),
+			},
+			{"kty": "oct", "kid": "symmetric", "k": "c2VjcmV0"},
+		},
+	})
+	return bs
+}
+
+func TestVerifier(t *testing.T) {
+	keys := newTestKeys(t)
+	ks, err := ParseJWKS(keys.jwks("rsa-1", "ec-1"))
+	if err != nil {
+		t.Fatalf("ParseJWKS: %v", err)
+	}
+	if len(ks) != 2 {
+		t.Fatalf("number of keys: %d", len(ks))
+	}
+
+	v := NewVerifier(ks,
+		VerifierSetIssuer("https://issuer.example.com"),
+		VerifierAddAudience("client-1"),
+		VerifierSetTokenUse("access"),
+		VerifierSetLeeway(time.Minute),
+		VerifierSetClock(func() time.Time { return testNow }),
+	)
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":       "user-1",
+			"iss":       "https://issuer.example.com",
+			"client_id": "client-1",
+			"token_use": "access",
+			"exp":       testNow.Add(time.Hour).Unix(),
+			"nbf":       testNow.Add(-time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	for _, c := range []struct {
+		name     string
+		token    string
+		expected error
+	}{
+		{"RS256", signTest(t, "RS256", "rsa-1", keys.rsa, claims(nil)), nil},
+		{"ES256", signTest(t, "ES256", "ec-1", keys.ec, claims(nil)), nil},
+		{"aud", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"client_id": nil, "aud": []string{"other", "client-1"},
+		})), nil},
+		{"leeway", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"exp": testNow.Add(-30 * time.Second).Unix(),
+		})), nil},
+		{"unknown kid", signTest(t, "RS256", "rsa-2", keys.rsa, claims(nil)), ErrUnknownKey},
+		{"key type mismatch", signTest(t, "RS256", "ec-1", keys.rsa, claims(nil)), ErrUnsupportedAlgorithm},
+		{"wrong key", signTest(t, "ES256", "ec-1", newTestKeys(t).ec, claims(nil)), ErrInvalidSignature},
+		{"expired", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"exp": testNow.Add(-time.Hour).Unix(),
+		})), ErrExpired},
+		{"no exp", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"exp": nil,
+		})), ErrNoExpiration},
+		{"nbf", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"nbf": testNow.Add(time.Hour).Unix(),
+		})), ErrNotYetValid},
+		{"iss", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"iss": "https://other.example.com",
+		})), ErrInvalidIssuer},
+		{"client_id", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"client_id": "client-2",
+		})), ErrInvalidAudience},
+		{"token_use", signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{
+			"token_use": "id",
+		})), ErrInvalidTokenUse},
+		{"HS256", func() string {
+			s, _ := SignHS256(claims(nil), []byte("secret"))
+			return s
+		}(), ErrUnsupportedAlgorithm},
+	} {
+		payload, err := v.Verify(context.Background(), c.token)
+		if err != c.expected {
+			t.Errorf("%s: expected=%v actual=%v", c.name, c.expected, err)
+			continue
+		}
+		if err == nil && payload["sub"] != "user-1" {
+			t.Errorf("%s: sub=%v", c.name, payload["sub"])
+		}
+	}
+
+	// tampered payload
+	token := signTest(t, "RS256", "rsa-1", keys.rsa, claims(nil))
+	other := signTest(t, "RS256", "rsa-1", keys.rsa, claims(map[string]interface{}{"sub": "user-2"}))
+	tampered := token[:strings.Index(token, ".")] +
+		other[strings.Index(other, "."):strings.LastIndex(other, ".")] +
+		token[strings.LastIndex(token, "."):]
+	if _, err := v.Verify(context.Background(), tampered); err != ErrInvalidSignature {
+		t.Errorf("tampered: %v", err)
+	}
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	keys := newTestKeys(t)
+
+	var fetches int32
+	var jwks atomic.Value
+	jwks.Store(keys.jwks("rsa-1", "ec-1"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Write(jwks.Load().([]byte))
+	}))
+	defer srv.Close()
+
+	now := testNow
+	ks := NewRemoteKeySet(srv.URL,
+		RemoteKeySetSetHTTPClient(srv.Client()),
+		RemoteKeySetSetClock(func() time.Time { return now }),
+	)
+	ctx := context.Background()
+
+	for _, kid := range []string{"rsa-1", "ec-1", "rsa-1"} {
+		if _, err := ks.Key(ctx, kid); err != nil {
+			t.Fatalf("Key(%s): %v", kid, err)
+		}
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("fetches: expected=1 actual=%d", n)
+	}
+
+	// rotation: an unknown key is not fetched again within the refresh interval.
+	jwks.Store(keys.jwks("rsa-2", "ec-1"))
+	if _, err := ks.Key(ctx, "rsa-2"); err != ErrUnknownKey {
+		t.Errorf("expected ErrUnknownKey: %v", err)
+	}
+	now = now.Add(DefaultJWKSRefreshInterval)
+	if _, err := ks.Key(ctx, "rsa-2"); err != nil {
+		t.Errorf("rotated key: %v", err)
+	}
+	if n := atomic.LoadInt32(&fetches); n != 2 {
+		t.Errorf("fetches: expected=2 actual=%d", n)
+	}
+
+	// cache expiration
+	now = now.Add(DefaultJWKSCacheTTL)
+	if _, err := ks.Key(ctx, "ec-1"); err != nil {
+		t.Errorf("Key: %v", err)
+	}
+	if n := atomic.LoadInt32(&fetches); n != 3 {
+		t.Errorf("fetches: expected=3 actual=%d", n)
+	}
+}
+
+func TestRemoteKeySet_Failure(t *testing.T) {
+	keys := newTestKeys(t)
+
+	var fetches, failing int32 = 0, 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		if atomic.LoadInt32(&failing) != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write(keys.jwks("rsa-1", "ec-1"))
+	}))
+	defer srv.Close()
+
+	now := testNow
+	ks := NewRemoteKeySet(srv.URL,
+		RemoteKeySetSetHTTPClient(srv.Client()),
+		RemoteKeySetSetClock(func() time.Time { return now }),
+	)
+	ctx := context.Background()
+
+	// a failed fetch is not retried within the refresh interval.
+	for i := 0; i < 3; i++ {
+		if _, err := ks.Key(ctx, "rsa-1"); err == nil {
+			t.Fatalf("expected error")
+		}
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("fetches: expected=1 actual=%d", n)
+	}
+
+	// the concurrent callers share a fetch.
+	atomic.StoreInt32(&failing, 0)
+	now = now.Add(DefaultJWKSRefreshInterval)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ks.Key(ctx, "rsa-1"); err != nil {
+				t.Errorf("Key: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&fetches); n != 2 {
+		t.Errorf("fetches: expected=2 actual=%d", n)
+	}
+
+	// the expired keys are used while the JWKS is unavailable, until they get too stale.
+	atomic.StoreInt32(&failing, 1)
+	now = now.Add(DefaultJWKSCacheTTL)
+	if _, err := ks.Key(ctx, "rsa-1"); err != nil {
+		t.Errorf("stale key: %v", err)
+	}
+	now = now.Add(DefaultJWKSMaxStale)
+	if _, err := ks.Key(ctx, "rsa-1"); err == nil {
+		t.Errorf("expected error for too stale key")
+	}
+}
+
+func TestRemoteKeySet_TooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"keys":[],"padding":"`))
+		w.Write(bytes.Repeat([]byte("a"), maxJWKSSize))
+		w.Write([]byte(`"}`))
+	}))
+	defer srv.Close()
+
+	ks := NewRemoteKeySet(srv.URL, RemoteKeySetSetHTTPClient(srv.Client()))
+	if _, err := ks.Key(context.Background(), "rsa-1"); err == nil || err == ErrUnknownKey {
+		t.Errorf("expected error: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package kifu
 
 import (
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
@@ -12,6 +14,11 @@ import (
 	"github.com/yunomu/kif/ptypes"
 	"github.com/yunomu/usi/sfen"
 
+	"github.com/yunomu/kansousen/lib/castle"
+	"github.com/yunomu/kansousen/lib/pattern"
+	"github.com/yunomu/kansousen/lib/position"
+	"github.com/yunomu/kansousen/lib/shogi"
+	"github.com/yunomu/kansousen/lib/team"
 	documentpb "github.com/yunomu/kansousen/proto/document"
 )
 
@@ -108,6 +115,57 @@ func parseHandicap(s string) documentpb.Handicap_Id {
 	return documentpb.Handicap_OTHER
 }
 
+const handicapRanks = "/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL w - 1"
+
+// handicapPositions are the initial SFEN positions in the order of handicapString.
+// The white (上手) gives the handicap and moves first.
+var handicapPositions = []string{
+	"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1",
+	"lnsgkgsn1/1r5b1" + handicapRanks,
+	"1nsgkgsnl/1r5b1" + handicapRanks,
+	"lnsgkgsnl/1r7" + handicapRanks,
+	"lnsgkgsnl/7b1" + handicapRanks,
+	"lnsgkgsn1/7b1" + handicapRanks,
+	"lnsgkgsnl/9" + handicapRanks,
+	"lnsgkgsn1/9" + handicapRanks,
+	"1nsgkgsn1/9" + handicapRanks,
+	"2sgkgsn1/9" + handicapRanks,
+	"1nsgkgs2/9" + handicapRanks,
+	"2sgkgs2/9" + handicapRanks,
+	"3gkg3/9" + handicapRanks,
+	"4k4/9" + handicapRanks,
+}
+
+var ErrUnknownHandicap = errors.New("unknown handicap")
+
+// handicapHeaders are the header names holding the handicap.
+// "手割合" is a common misspelling of "手合割" and accepted as well.
+var handicapHeaders = []string{"手合割", "手割合"}
+
+func isHandicapHeader(name string) bool {
+	for _, h := range handicapHeaders {
+		if name == h {
+			return true
+		}
+	}
+	return false
+}
+
+// startPosition returns the SFEN position where the game of k starts.
+func startPosition(k *ptypes.Kif) (string, error) {
+	h := documentpb.Handicap_NONE
+	for _, header := range k.GetHeaders() {
+		if isHandicapHeader(header.GetName()) {
+			h = parseHandicap(header.GetValue())
+		}
+	}
+
+	if i := int(h); i < len(handicapPositions) {
+		return handicapPositions[i], nil
+	}
+	return "", ErrUnknownHandicap
+}
+
 func readHeader(hs []*ptypes.Header, loc *time.Location, out *documentpb.Kifu) error {
 	header := map[string]string{}
 	used := map[string]struct{}{}
@@ -170,8 +228,12 @@ func readHeader(hs []*ptypes.Header, loc *time.Location, out *documentpb.Kifu) e
 		{
 			field: "",
 			f: func(field, v string) error {
-				out.Handicap = parseHandicap(header["手割合"])
-				used["手割合"] = struct{}{}
+				for _, name := range handicapHeaders {
+					if v, ok := header[name]; ok {
+						out.Handicap = parseHandicap(v)
+						used[name] = struct{}{}
+					}
+				}
 				return nil
 			},
 		},
@@ -275,24 +337,79 @@ func kifPosToPos(p *ptypes.Pos) *documentpb.Pos {
 	}
 }
 
-func posXFromInt(x int32) sfen.PosX {
-	x = 9 - x
-	if x < 0 {
-		panic("invalid x")
-	}
-	return sfen.PosXs[x]
+var shogiPieces = map[shogi.PieceType]documentpb.Piece_Id{
+	shogi.King:      documentpb.Piece_GYOKU,
+	shogi.Rook:      documentpb.Piece_HISHA,
+	shogi.Dragon:    documentpb.Piece_RYU,
+	shogi.Bishop:    documentpb.Piece_KAKU,
+	shogi.Horse:     documentpb.Piece_UMA,
+	shogi.Gold:      documentpb.Piece_KIN,
+	shogi.Silver:    documentpb.Piece_GIN,
+	shogi.ProSilver: documentpb.Piece_NARI_GIN,
+	shogi.Knight:    documentpb.Piece_KEI,
+	shogi.ProKnight: documentpb.Piece_NARI_KEI,
+	shogi.Lance:     documentpb.Piece_KYOU,
+	shogi.ProLance:  documentpb.Piece_NARI_KYOU,
+	shogi.Pawn:      documentpb.Piece_FU,
+	shogi.ProPawn:   documentpb.Piece_TO,
+}
+
+var ErrPieceMismatch = errors.New("the piece differs from the piece on the source square")
+
+// IllegalMoveError is returned when the kif has a move which violates the rules.
+type IllegalMoveError struct {
+	Seq int32
+	// Move is the move in KIF notation.
+	Move   string
+	Reason error
 }
 
-func posYFromInt(y int32) sfen.PosY {
-	if y <= 0 {
-		panic("invalid y")
+func (e *IllegalMoveError) Error() string {
+	return fmt.Sprintf("illegal move at %d %s: %v", e.Seq, e.Move, e.Reason)
+}
+
+func (e *IllegalMoveError) Unwrap() error {
+	return e.Reason
+}
+
+// validateStep validates the move of step on board.
+func validateStep(board *shogi.Position, step *ptypes.Step, move string) (shogi.Move, error) {
+	illegal := func(reason error) error {
+		return &IllegalMoveError{
+			Seq:    step.GetSeq(),
+			Move:   kif.PrintMove(step),
+			Reason: reason,
+		}
 	}
-	y -= 1
-	return sfen.PosYs[y]
+
+	m, err := shogi.ParseUSI(move)
+	if err != nil {
+		return m, illegal(err)
+	}
+	if err := board.Validate(m); err != nil {
+		return m, illegal(err)
+	}
+	if !m.IsDrop() && shogiPieces[board.Board[m.From].Type] != kifPieceToPiece(step.GetPiece()) {
+		return m, illegal(ErrPieceMismatch)
+	}
+
+	return m, nil
 }
 
 func kifToSteps(userId, kifuId string, k *ptypes.Kif) ([]*documentpb.Step, error) {
-	p := sfen.NewSurfaceStartpos()
+	start, err := startPosition(k)
+	if err != nil {
+		return nil, err
+	}
+	p, err := sfen.NewSurface(start)
+	if err != nil {
+		return nil, err
+	}
+	board, err := shogi.FromSFEN(start)
+	if err != nil {
+		return nil, err
+	}
+	castles := castle.NewDetector()
 	var steps []*documentpb.Step
 
 	var buf strings.Builder
@@ -320,30 +437,17 @@ func kifToSteps(userId, kifuId string, k *ptypes.Kif) ([]*documentpb.Step, error
 			Notes:        step.GetNotes(),
 		}
 
+		move := kif.StepToMove(step)
 		var captured documentpb.Piece_Id
-		if step.FinishedStatus == ptypes.FinishedStatus_NOT_FINISHED {
-			if piece := p.GetPiece(posXFromInt(step.Dst.X), posYFromInt(step.Dst.Y)); piece != nil {
-				switch piece.Type {
-				case sfen.Piece_NULL:
-					captured = documentpb.Piece_NULL
-				case sfen.Piece_HISHA:
-					captured = documentpb.Piece_HISHA
-				case sfen.Piece_KAKU:
-					captured = documentpb.Piece_KAKU
-				case sfen.Piece_KIN:
-					captured = documentpb.Piece_KIN
-				case sfen.Piece_GIN:
-					captured = documentpb.Piece_GIN
-				case sfen.Piece_KEI:
-					captured = documentpb.Piece_KEI
-				case sfen.Piece_KYOU:
-					captured = documentpb.Piece_KYOU
-				case sfen.Piece_FU:
-					captured = documentpb.Piece_FU
-				default:
-					panic("Unknown Piece type")
-				}
+		if move != "" {
+			m, err := validateStep(board, step, move)
+			if err != nil {
+				return nil, err
+			}
+			if piece := board.Board[m.To]; !piece.Empty() {
+				captured = shogiPieces[piece.Type.Unpromote()]
 			}
+			board = board.Play(m)
 		}
 
 		s.Src = kifPosToPos(step.GetSrc())
@@ -354,7 +458,6 @@ func kifToSteps(userId, kifuId string, k *ptypes.Kif) ([]*documentpb.Step, error
 		s.Captured = captured
 		s.FinishedStatus = kifFinishedStatusToStatus(step.GetFinishedStatus())
 
-		move := kif.StepToMove(step)
 		s.Sfen = move
 		if move != "" {
 			if err := p.Move(move); err != nil {
@@ -369,6 +472,14 @@ func kifToSteps(userId, kifuId string, k *ptypes.Kif) ([]*documentpb.Step, error
 		}
 		s.Position = buf.String()
 
+		if move != "" {
+			pos, err := position.Parse(s.Position)
+			if err != nil {
+				return nil, err
+			}
+			s.Castles = castles.Next(pos)
+		}
+
 		steps = append(steps, s)
 
 		if step.FinishedStatus != ptypes.FinishedStatus_NOT_FINISHED {
@@ -399,11 +510,199 @@ func (p *Parser) Parse(r io.Reader, userId, kifuId string) (*documentpb.Kifu, []
 		return nil, nil, err
 	}
 	kifu.Sfen = buf.String()
+	if kifu.Handicap != documentpb.Handicap_NONE {
+		// the writer always starts from startpos
+		start, err := startPosition(k)
+		if err != nil {
+			return nil, nil, err
+		}
+		kifu.Sfen = strings.Replace(kifu.Sfen, "position startpos", "position sfen "+start, 1)
+	}
 
 	steps, err := kifToSteps(kifu.UserId, kifu.KifuId, k)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if err := setFeatures(kifu, steps); err != nil {
+		return nil, nil, err
+	}
+
+	if err := setRepetition(kifu, steps); err != nil {
+		return nil, nil, err
+	}
+
 	return kifu, steps, nil
 }
+
+func setFeatures(kifu *documentpb.Kifu, steps []*documentpb.Step) error {
+	kifu.Features = 0
+	for _, step := range steps {
+		pos, err := position.Parse(step.GetPosition())
+		if err != nil {
+			return err
+		}
+
+		step.Features = pattern.Features(pos)
+		kifu.Features |= step.Features
+	}
+
+	return nil
+}
+
+// AddAliases adds kifu ids to aliases of k. Ids which already exist are ignored.
+func AddAliases(k *documentpb.Kifu, kifuIds ...string) {
+	exists := map[string]struct{}{
+		k.GetKifuId(): struct{}{},
+	}
+	for _, alias := range k.GetAliases() {
+		exists[alias] = struct{}{}
+	}
+
+	for _, id := range kifuIds {
+		if _, ok := exists[id]; ok {
+			continue
+		}
+		exists[id] = struct{}{}
+
+		k.Aliases = append(k.Aliases, id)
+	}
+}
+
+// NormalizeTag trims spaces of tag. Empty string is returned for invalid tags.
+func NormalizeTag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if strings.ContainsAny(tag, "\n\r\t") {
+		return ""
+	}
+	return tag
+}
+
+// AddTags adds tags to k. Tags which already exist are ignored.
+func AddTags(k *documentpb.Kifu, tags ...string) {
+	exists := make(map[string]struct{})
+	for _, tag := range k.GetTags() {
+		exists[tag] = struct{}{}
+	}
+
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := exists[tag]; ok {
+			continue
+		}
+		exists[tag] = struct{}{}
+
+		k.Tags = append(k.Tags, tag)
+	}
+}
+
+// RemoveTags removes tags from k.
+func RemoveTags(k *documentpb.Kifu, tags ...string) {
+	remove := make(map[string]struct{})
+	for _, tag := range tags {
+		remove[NormalizeTag(tag)] = struct{}{}
+	}
+
+	var ret []string
+	for _, tag := range k.GetTags() {
+		if _, ok := remove[tag]; ok {
+			continue
+		}
+		ret = append(ret, tag)
+	}
+	k.Tags = ret
+}
+
+type Access int
+
+const (
+	AccessNone Access = iota
+	AccessRead
+	AccessOwner
+)
+
+func (a Access) String() string {
+	switch a {
+	case AccessRead:
+		return "READ"
+	case AccessOwner:
+		return "OWNER"
+	default:
+		return "NONE"
+	}
+}
+
+type accessOptions struct {
+	team *documentpb.Team
+}
+
+type AccessOption func(*accessOptions)
+
+// AccessViaTeam gives the members of t read access to the kifus of the other members.
+// Invited users who have not accepted are not members.
+func AccessViaTeam(t *documentpb.Team) AccessOption {
+	return func(o *accessOptions) {
+		o.team = t
+	}
+}
+
+// GetAccess returns the access of userId to k.
+// linkToken is the token given by the user, it may be empty.
+func GetAccess(k *documentpb.Kifu, userId, linkToken string, options ...AccessOption) Access {
+	o := &accessOptions{}
+	for _, f := range options {
+		f(o)
+	}
+
+	if userId != "" && k.GetUserId() == userId {
+		return AccessOwner
+	}
+
+	if t := o.team; t != nil && team.IsMember(t, userId) && team.IsMember(t, k.GetUserId()) {
+		return AccessRead
+	}
+
+	if userId != "" {
+		for _, share := range k.GetShares() {
+			if share.GetUserId() == userId {
+				return AccessRead
+			}
+		}
+	}
+
+	if linkToken != "" && subtle.ConstantTimeCompare([]byte(k.GetLinkToken()), []byte(linkToken)) == 1 {
+		return AccessRead
+	}
+
+	return AccessNone
+}
+
+// SetShare shares k with userId. The role is overwritten if already shared.
+func SetShare(k *documentpb.Kifu, userId string, role documentpb.Share_Role) {
+	for _, share := range k.GetShares() {
+		if share.GetUserId() == userId {
+			share.Role = role
+			return
+		}
+	}
+
+	k.Shares = append(k.Shares, &documentpb.Share{
+		UserId: userId,
+		Role:   role,
+	})
+}
+
+// RemoveShare stops sharing k with userId.
+func RemoveShare(k *documentpb.Kifu, userId string) {
+	var ret []*documentpb.Share
+	for _, share := range k.GetShares() {
+		if share.GetUserId() == userId {
+			continue
+		}
+		ret = append(ret, share)
+	}
+	k.Shares = ret
+}
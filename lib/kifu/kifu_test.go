@@ -0,0 +1,203 @@
+package kifu
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yunomu/kif/ptypes"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func TestAddAliases(t *testing.T) {
+	k := &documentpb.Kifu{
+		KifuId:  "kifu-1",
+		Aliases: []string{"kifu-2"},
+	}
+
+	AddAliases(k, "kifu-1", "kifu-2", "kifu-3", "kifu-3", "kifu-4")
+
+	expected := []string{"kifu-2", "kifu-3", "kifu-4"}
+	if !reflect.DeepEqual(k.Aliases, expected) {
+		t.Errorf("expected=%v actual=%v", expected, k.Aliases)
+	}
+}
+
+func TestAddRemoveTags(t *testing.T) {
+	k := &documentpb.Kifu{
+		Tags: []string{"club league 2026"},
+	}
+
+	AddTags(k, " to review ", "club league 2026", "", "opening: 角換わり", "to review")
+
+	expected := []string{"club league 2026", "to review", "opening: 角換わり"}
+	if !reflect.DeepEqual(k.Tags, expected) {
+		t.Errorf("AddTags: expected=%v actual=%v", expected, k.Tags)
+	}
+
+	RemoveTags(k, "to review ", "unknown")
+
+	expected = []string{"club league 2026", "opening: 角換わり"}
+	if !reflect.DeepEqual(k.Tags, expected) {
+		t.Errorf("RemoveTags: expected=%v actual=%v", expected, k.Tags)
+	}
+}
+
+func TestGetAccess(t *testing.T) {
+	k := &documentpb.Kifu{
+		UserId: "owner",
+	}
+	SetShare(k, "reader", documentpb.Share_READ)
+	SetShare(k, "reader2", documentpb.Share_READ)
+	SetShare(k, "reader2", documentpb.Share_READ)
+	SetShare(k, "removed", documentpb.Share_READ)
+	RemoveShare(k, "removed")
+
+	if len(k.Shares) != 2 {
+		t.Fatalf("shares: %v", k.Shares)
+	}
+
+	cases := []struct {
+		userId    string
+		linkToken string
+		expected  Access
+	}{
+		{"owner", "", AccessOwner},
+		{"reader", "", AccessRead},
+		{"reader2", "", AccessRead},
+		{"removed", "", AccessNone},
+		{"other", "", AccessNone},
+		{"", "", AccessNone},
+		{"other", "token", AccessNone},
+	}
+	for _, c := range cases {
+		if a := GetAccess(k, c.userId, c.linkToken); a != c.expected {
+			t.Errorf("GetAccess(%q, %q): expected=%v actual=%v", c.userId, c.linkToken, c.expected, a)
+		}
+	}
+
+	k.LinkToken = "token"
+	if a := GetAccess(k, "other", "token"); a != AccessRead {
+		t.Errorf("link token: expected=%v actual=%v", AccessRead, a)
+	}
+	if a := GetAccess(k, "", "token"); a != AccessRead {
+		t.Errorf("link token without user: expected=%v actual=%v", AccessRead, a)
+	}
+	if a := GetAccess(k, "other", "wrong"); a != AccessNone {
+		t.Errorf("wrong link token: expected=%v actual=%v", AccessNone, a)
+	}
+
+	tm := &documentpb.Team{
+		OwnerUserId:    "other",
+		MemberUserIds:  []string{"other", "owner"},
+		InvitedUserIds: []string{"invited"},
+	}
+	if a := GetAccess(k, "other", "", AccessViaTeam(tm)); a != AccessRead {
+		t.Errorf("team member: expected=%v actual=%v", AccessRead, a)
+	}
+	if a := GetAccess(k, "invited", "", AccessViaTeam(tm)); a != AccessNone {
+		t.Errorf("invited user: expected=%v actual=%v", AccessNone, a)
+	}
+	tm.MemberUserIds = []string{"other"}
+	tm.InvitedUserIds = []string{"owner"}
+	if a := GetAccess(k, "other", "", AccessViaTeam(tm)); a != AccessNone {
+		t.Errorf("kifu of invited user: expected=%v actual=%v", AccessNone, a)
+	}
+}
+
+func TestKifToSteps_IllegalMove(t *testing.T) {
+	k := &ptypes.Kif{
+		Steps: []*ptypes.Step{
+			{Seq: 1, Src: &ptypes.Pos{X: 7, Y: 7}, Dst: &ptypes.Pos{X: 7, Y: 6}, Piece: ptypes.Piece_FU},
+			{Seq: 2, Src: &ptypes.Pos{X: 3, Y: 3}, Dst: &ptypes.Pos{X: 3, Y: 4}, Piece: ptypes.Piece_FU},
+			{Seq: 3, Dst: &ptypes.Pos{X: 5, Y: 5}, Piece: ptypes.Piece_FU, Modifier: ptypes.Modifier_PUTTED},
+		},
+	}
+
+	_, err := kifToSteps("user", "kifu", k)
+	var illegal *IllegalMoveError
+	if !errors.As(err, &illegal) {
+		t.Fatalf("expected IllegalMoveError: %v", err)
+	}
+	if illegal.Seq != 3 || illegal.Reason != shogi.ErrNoPieceInHand {
+		t.Errorf("unexpected error: %v", illegal)
+	}
+
+	k.Steps[2] = &ptypes.Step{Seq: 3, Src: &ptypes.Pos{X: 8, Y: 8}, Dst: &ptypes.Pos{X: 2, Y: 2}, Piece: ptypes.Piece_HISHA, Modifier: ptypes.Modifier_PROMOTE}
+	if _, err := kifToSteps("user", "kifu", k); !errors.Is(err, ErrPieceMismatch) {
+		t.Errorf("expected ErrPieceMismatch: %v", err)
+	}
+
+	k.Steps[2] = &ptypes.Step{Seq: 3, Src: &ptypes.Pos{X: 10, Y: 8}, Dst: &ptypes.Pos{X: 2, Y: 2}, Piece: ptypes.Piece_KAKU}
+	if _, err := kifToSteps("user", "kifu", k); !errors.Is(err, shogi.ErrInvalidUSI) {
+		t.Errorf("expected ErrInvalidUSI: %v", err)
+	}
+
+	k.Steps[2] = &ptypes.Step{Seq: 3, Src: &ptypes.Pos{X: 8, Y: 8}, Dst: &ptypes.Pos{X: 2, Y: 2}, Piece: ptypes.Piece_KAKU, Modifier: ptypes.Modifier_PROMOTE}
+	steps, err := kifToSteps("user", "kifu", k)
+	if err != nil {
+		t.Fatalf("kifToSteps: %v", err)
+	}
+	if c := steps[3].GetCaptured(); c != documentpb.Piece_KAKU {
+		t.Errorf("captured: %v", c)
+	}
+}
+
+func TestKifToSteps_Same(t *testing.T) {
+	k := &ptypes.Kif{
+		Steps: []*ptypes.Step{
+			{Seq: 1, Src: &ptypes.Pos{X: 7, Y: 7}, Dst: &ptypes.Pos{X: 7, Y: 6}, Piece: ptypes.Piece_FU},
+			{Seq: 2, Src: &ptypes.Pos{X: 3, Y: 3}, Dst: &ptypes.Pos{X: 3, Y: 4}, Piece: ptypes.Piece_FU},
+			{Seq: 3, Src: &ptypes.Pos{X: 8, Y: 8}, Dst: &ptypes.Pos{X: 2, Y: 2}, Piece: ptypes.Piece_KAKU, Modifier: ptypes.Modifier_PROMOTE},
+			// 同銀(31); the parser fills in the destination of the previous move.
+			{Seq: 4, Src: &ptypes.Pos{X: 3, Y: 1}, Dst: &ptypes.Pos{X: 2, Y: 2}, Piece: ptypes.Piece_GIN},
+			{Seq: 5, FinishedStatus: ptypes.FinishedStatus_SURRENDER},
+		},
+	}
+
+	steps, err := kifToSteps("user", "kifu", k)
+	if err != nil {
+		t.Fatalf("kifToSteps: %v", err)
+	}
+	s := steps[4]
+	if s.GetSfen() != "3a2b" || s.GetDst().GetX() != 2 || s.GetDst().GetY() != 2 {
+		t.Errorf("unexpected step: %v", s)
+	}
+	if c := s.GetCaptured(); c != documentpb.Piece_KAKU {
+		t.Errorf("captured: %v", c)
+	}
+	if d := steps[5].GetDst(); d != nil {
+		t.Errorf("finished step has dst: %v", d)
+	}
+}
+
+func TestKifToSteps_Handicap(t *testing.T) {
+	k := &ptypes.Kif{
+		Headers: []*ptypes.Header{
+			{Name: "手合割", Value: "角落ち"},
+		},
+		Steps: []*ptypes.Step{
+			// the white (上手) moves first
+			{Seq: 1, Src: &ptypes.Pos{X: 3, Y: 3}, Dst: &ptypes.Pos{X: 3, Y: 4}, Piece: ptypes.Piece_FU},
+			{Seq: 2, Src: &ptypes.Pos{X: 7, Y: 7}, Dst: &ptypes.Pos{X: 7, Y: 6}, Piece: ptypes.Piece_FU},
+		},
+	}
+
+	steps, err := kifToSteps("user", "kifu", k)
+	if err != nil {
+		t.Fatalf("kifToSteps: %v", err)
+	}
+	if p := steps[0].GetPosition(); p != "lnsgkgsnl/1r7/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL w - 1" {
+		t.Errorf("start position: %v", p)
+	}
+	if p := steps[2].GetPosition(); p != "lnsgkgsnl/1r7/pppppp1pp/6p2/9/2P6/PP1PPPPPP/1B5R1/LNSGKGSNL w - 1" {
+		t.Errorf("position: %v", p)
+	}
+
+	k.Headers[0].Value = "その他"
+	if _, err := kifToSteps("user", "kifu", k); !errors.Is(err, ErrUnknownHandicap) {
+		t.Errorf("expected ErrUnknownHandicap: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package kifu
+
+import (
+	"fmt"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+// moveSteps returns the steps which have the positions, excluding the step of the finished status.
+func moveSteps(steps []*documentpb.Step) []*documentpb.Step {
+	for i, step := range steps {
+		if i > 0 && step.GetSfen() == "" {
+			return steps[:i]
+		}
+	}
+	return steps
+}
+
+// continuousCheck reports whether c checked the opponent in every move of c in steps.
+func continuousCheck(steps []*documentpb.Step, c shogi.Color) (bool, error) {
+	checked := false
+	for _, step := range steps {
+		p, err := shogi.FromSFEN(step.GetPosition())
+		if err != nil {
+			return false, err
+		}
+		if p.Side == c {
+			continue
+		}
+		if !p.InCheck(p.Side) {
+			return false, nil
+		}
+		checked = true
+	}
+	return checked, nil
+}
+
+// DetectRepetition finds the first fourfold repetition (千日手) in steps.
+// It returns nil if there is no repetition.
+func DetectRepetition(steps []*documentpb.Step) (*documentpb.Repetition, error) {
+	steps = moveSteps(steps)
+
+	seen := make(map[string][]int)
+	for i, step := range steps {
+		pos := step.GetPosition()
+		idx := append(seen[pos], i)
+		seen[pos] = idx
+		if len(idx) < 4 {
+			continue
+		}
+
+		rep := &documentpb.Repetition{
+			Result: documentpb.Repetition_DRAW,
+			Seq:    step.GetSeq(),
+		}
+		for _, c := range []shogi.Color{shogi.Black, shogi.White} {
+			ok, err := continuousCheck(steps[idx[0]+1:i+1], c)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rep.Result = documentpb.Repetition_PERPETUAL_CHECK
+				rep.CheckingPlayer = documentpb.Player_Order(c)
+			}
+		}
+		return rep, nil
+	}
+
+	return nil, nil
+}
+
+// repetitionWarnings returns the inconsistencies between rep and the recorded result.
+func repetitionWarnings(rep *documentpb.Repetition, steps []*documentpb.Step) []string {
+	if len(steps) == 0 {
+		return nil
+	}
+	moves := moveSteps(steps)
+	last := moves[len(moves)-1]
+
+	var status documentpb.FinishedStatus_Id
+	if len(moves) < len(steps) {
+		status = steps[len(moves)].GetFinishedStatus()
+	}
+
+	if rep == nil {
+		if status == documentpb.FinishedStatus_REPETITION_DRAW {
+			return []string{"the result is repetition draw but no fourfold repetition was found"}
+		}
+		return nil
+	}
+
+	var ret []string
+	if last.GetSeq() > rep.GetSeq() {
+		ret = append(ret, fmt.Sprintf("moves continue after the fourfold repetition at %d", rep.GetSeq()))
+	}
+	switch rep.GetResult() {
+	case documentpb.Repetition_DRAW:
+		if status != documentpb.FinishedStatus_REPETITION_DRAW {
+			ret = append(ret, fmt.Sprintf("fourfold repetition at %d but the result is %v", rep.GetSeq(), status))
+		}
+	case documentpb.Repetition_PERPETUAL_CHECK:
+		if status != documentpb.FinishedStatus_FOUL_LOSS && status != documentpb.FinishedStatus_FOUL_WIN {
+			ret = append(ret, fmt.Sprintf("perpetual check by %v at %d but the result is %v", rep.GetCheckingPlayer(), rep.GetSeq(), status))
+		}
+	}
+	return ret
+}
+
+func setRepetition(kifu *documentpb.Kifu, steps []*documentpb.Step) error {
+	rep, err := DetectRepetition(steps)
+	if err != nil {
+		return err
+	}
+
+	kifu.Repetition = rep
+	kifu.Warnings = repetitionWarnings(rep, steps)
+	return nil
+}
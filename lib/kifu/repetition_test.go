@@ -0,0 +1,77 @@
+package kifu
+
+import (
+	"testing"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func cycleSteps(cycle []string, n int) []*documentpb.Step {
+	var steps []*documentpb.Step
+	for i := 0; i < n; i++ {
+		step := &documentpb.Step{
+			Seq:      int32(i),
+			Position: cycle[i%len(cycle)],
+		}
+		if i > 0 {
+			step.Sfen = "move"
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func TestDetectRepetition(t *testing.T) {
+	steps := cycleSteps([]string{
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1",
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B4R2/LNSGKGSNL w - 1",
+		"lnsgkgsnl/2r4b1/ppppppppp/9/9/9/PPPPPPPPP/1B4R2/LNSGKGSNL b - 1",
+		"lnsgkgsnl/2r4b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL w - 1",
+	}, 13)
+
+	rep, err := DetectRepetition(steps)
+	if err != nil {
+		t.Fatalf("DetectRepetition: %v", err)
+	}
+	if rep.GetResult() != documentpb.Repetition_DRAW || rep.GetSeq() != 12 {
+		t.Errorf("unexpected repetition: %v", rep)
+	}
+
+	if rep, err := DetectRepetition(steps[:12]); err != nil || rep != nil {
+		t.Errorf("unexpected repetition: %v %v", rep, err)
+	}
+
+	steps = append(steps, &documentpb.Step{Seq: 13, FinishedStatus: documentpb.FinishedStatus_REPETITION_DRAW})
+	if ws := repetitionWarnings(rep, steps); len(ws) != 0 {
+		t.Errorf("unexpected warnings: %v", ws)
+	}
+	steps[13].FinishedStatus = documentpb.FinishedStatus_SURRENDER
+	if ws := repetitionWarnings(rep, steps); len(ws) != 1 {
+		t.Errorf("expected a warning: %v", ws)
+	}
+}
+
+func TestDetectRepetition_PerpetualCheck(t *testing.T) {
+	steps := cycleSteps([]string{
+		"8k/9/9/9/9/9/9/9/K6R1 b - 1",
+		"8k/9/9/9/9/9/9/9/K7R w - 1",
+		"7k1/9/9/9/9/9/9/9/K7R b - 1",
+		"7k1/9/9/9/9/9/9/9/K6R1 w - 1",
+	}, 13)
+	steps = append(steps, &documentpb.Step{Seq: 13, FinishedStatus: documentpb.FinishedStatus_REPETITION_DRAW})
+
+	rep, err := DetectRepetition(steps)
+	if err != nil {
+		t.Fatalf("DetectRepetition: %v", err)
+	}
+	if rep.GetResult() != documentpb.Repetition_PERPETUAL_CHECK || rep.GetCheckingPlayer() != documentpb.Player_BLACK {
+		t.Errorf("unexpected repetition: %v", rep)
+	}
+
+	if ws := repetitionWarnings(rep, steps); len(ws) != 1 {
+		t.Errorf("expected a warning: %v", ws)
+	}
+	if ws := repetitionWarnings(nil, steps); len(ws) != 1 {
+		t.Errorf("expected a warning: %v", ws)
+	}
+}
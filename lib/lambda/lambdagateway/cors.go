@@ -0,0 +1,127 @@
+package lambdagateway
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCORSCredentialsAnyOrigin is returned by CORS.Validate if "*" is allowed with credentials,
+// which would let any site make the credentialed requests.
+var ErrCORSCredentialsAnyOrigin = errors.New("cors: \"*\" is not allowed with credentials")
+
+// CORS is the policy of the cross-origin requests.
+type CORS struct {
+	// "*" allows any origin, which cannot be combined with AllowCredentials.
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	// the time the preflight response can be cached. 0 omits Access-Control-Max-Age.
+	MaxAge time.Duration
+}
+
+// DefaultCORS allows any origin without credentials.
+func DefaultCORS() *CORS {
+	return &CORS{
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization"},
+	}
+}
+
+// SetCORS sets the CORS policy applied to all responses including errors, and answers the preflight requests.
+// nil disables CORS. The default is DefaultCORS().
+// It panics if the policy is invalid, see CORS.Validate.
+func SetCORS(c *CORS) GatewayOption {
+	if c != nil {
+		if err := c.Validate(); err != nil {
+			panic("lambdagateway: " + err.Error())
+		}
+	}
+	return func(s *Gateway) {
+		s.cors = c
+	}
+}
+
+// Validate returns ErrCORSCredentialsAnyOrigin if "*" is allowed with credentials.
+func (c *CORS) Validate() error {
+	if c.AllowCredentials && c.allowAny() {
+		return ErrCORSCredentialsAnyOrigin
+	}
+	return nil
+}
+
+func (c *CORS) allowAny() bool {
+	for _, o := range c.AllowOrigins {
+		if o == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// allowOrigin returns the value of Access-Control-Allow-Origin for the origin, or "" if it is not allowed.
+func (c *CORS) allowOrigin(origin string) string {
+	if c.allowAny() {
+		return "*"
+	}
+	for _, o := range c.AllowOrigins {
+		if o == origin {
+			return origin
+		}
+	}
+	return ""
+}
+
+func isPreflight(req *Request) bool {
+	return req.RequestContext.HTTP.Method == "OPTIONS" && req.Headers["access-control-request-method"] != ""
+}
+
+// apply sets the CORS headers of the response to the request.
+func (c *CORS) apply(req *Request, res *Response) {
+	if res.Headers == nil {
+		res.Headers = map[string]string{}
+	}
+
+	origin := req.Headers["origin"]
+	allowed := c.allowOrigin(origin)
+	if allowed != "*" {
+		res.Headers["Vary"] = "Origin"
+	}
+	if allowed == "" {
+		return
+	}
+
+	res.Headers["Access-Control-Allow-Origin"] = allowed
+	if c.AllowCredentials {
+		res.Headers["Access-Control-Allow-Credentials"] = "true"
+	}
+	if len(c.ExposeHeaders) != 0 {
+		res.Headers["Access-Control-Expose-Headers"] = strings.Join(c.ExposeHeaders, ", ")
+	}
+}
+
+// preflight returns the response to the preflight request.
+func (c *CORS) preflight(req *Request) *Response {
+	res := &Response{
+		StatusCode: 204,
+		Headers:    map[string]string{},
+	}
+	c.apply(req, res)
+	if _, ok := res.Headers["Access-Control-Allow-Origin"]; !ok {
+		res.StatusCode = 403
+		return res
+	}
+
+	res.Headers["Access-Control-Allow-Methods"] = strings.Join(c.AllowMethods, ", ")
+	if len(c.AllowHeaders) != 0 {
+		res.Headers["Access-Control-Allow-Headers"] = strings.Join(c.AllowHeaders, ", ")
+	}
+	if c.MaxAge > 0 {
+		res.Headers["Access-Control-Max-Age"] = strconv.Itoa(int(c.MaxAge / time.Second))
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package lambdagateway
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCORS(t *testing.T) {
+	newGateway := func(cors *CORS) *Gateway {
+		return NewGateway(HandlerInvoker(&echoHandler{}),
+			AddFunction("/echo", "POST", "echo", "Echo"),
+			SetCORS(cors),
+		)
+	}
+	restricted := &CORS{
+		AllowOrigins:     []string{"https://a.example.com", "https://b.example.com"},
+		AllowMethods:     []string{"GET", "POST"},
+		AllowHeaders:     []string{"Authorization"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	for _, c := range []struct {
+		name      string
+		cors      *CORS
+		method    string
+		path      string
+		origin    string
+		preflight bool
+		code      int
+		headers   map[string]string
+	}{
+		{"default", DefaultCORS(), "POST", "/echo", "https://x.example.com", false, 200, map[string]string{
+			"Access-Control-Allow-Origin": "*",
+		}},
+		{"default preflight", DefaultCORS(), "OPTIONS", "/echo", "https://x.example.com", true, 204, map[string]string{
+			"Access-Control-Allow-Origin":  "*",
+			"Access-Control-Allow-Methods": "GET, POST, PATCH, DELETE, OPTIONS",
+			"Access-Control-Max-Age":       "",
+		}},
+		{"allowed", restricted, "POST", "/echo", "https://b.example.com", false, 200, map[string]string{
+			"Access-Control-Allow-Origin":      "https://b.example.com",
+			"Access-Control-Allow-Credentials": "true",
+			"Vary":                             "Origin",
+		}},
+		{"allowed error", restricted, "POST", "/unknown", "https://a.example.com", false, 404, map[string]string{
+			"Access-Control-Allow-Origin": "https://a.example.com",
+		}},
+		{"allowed preflight", restricted, "OPTIONS", "/echo", "https://a.example.com", true, 204, map[string]string{
+			"Access-Control-Allow-Origin":  "https://a.example.com",
+			"Access-Control-Allow-Methods": "GET, POST",
+			"Access-Control-Allow-Headers": "Authorization",
+			"Access-Control-Max-Age":       "600",
+		}},
+		{"denied", restricted, "POST", "/echo", "https://evil.example.com", false, 200, map[string]string{
+			"Access-Control-Allow-Origin": "",
+			"Vary":                        "Origin",
+		}},
+		{"denied preflight", restricted, "OPTIONS", "/echo", "https://evil.example.com", true, 403, map[string]string{
+			"Access-Control-Allow-Origin":  "",
+			"Access-Control-Allow-Methods": "",
+		}},
+		{"disabled", nil, "POST", "/echo", "https://x.example.com", false, 200, map[string]string{
+			"Access-Control-Allow-Origin": "",
+		}},
+		{"disabled preflight", nil, "OPTIONS", "/echo", "https://x.example.com", true, 405, nil},
+	} {
+		req := newTestRequest(c.path, "{}")
+		req.RequestContext.HTTP.Method = c.method
+		req.Headers = map[string]string{"origin": c.origin}
+		if c.preflight {
+			req.Headers["access-control-request-method"] = "POST"
+		}
+
+		res, err := newGateway(c.cors).Serve(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s: Serve: %v", c.name, err)
+		}
+		if res.StatusCode != c.code {
+			t.Errorf("%s: code=%d", c.name, res.StatusCode)
+		}
+		for k, v := range c.headers {
+			if res.Headers[k] != v {
+				t.Errorf("%s: %s: expected=%q actual=%q", c.name, k, v, res.Headers[k])
+			}
+		}
+	}
+}
+
+func TestCORS_Validate(t *testing.T) {
+	cors := DefaultCORS()
+	cors.AllowCredentials = true
+	if err := cors.Validate(); err != ErrCORSCredentialsAnyOrigin {
+		t.Errorf("any origin with credentials: %v", err)
+	}
+
+	cors.AllowOrigins = []string{"https://a.example.com"}
+	if err := cors.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	cors.AllowOrigins = append(cors.AllowOrigins, "*")
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("SetCORS: expected panic")
+			}
+		}()
+		SetCORS(cors)
+	}()
+}
@@ -0,0 +1,174 @@
+package lambdagateway
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type httpHandler struct {
+	gw         *Gateway
+	authorizer func(*http.Request) (map[string]string, error)
+	logger     Logger
+}
+
+type HTTPOption func(*httpHandler)
+
+// HTTPSetAuthorizer sets the function which authenticates the request and returns the JWT claims,
+// in place of the authorizer of API Gateway. The request is rejected with 401 if it returns an error.
+func HTTPSetAuthorizer(f func(*http.Request) (map[string]string, error)) HTTPOption {
+	return func(h *httpHandler) {
+		h.authorizer = f
+	}
+}
+
+func HTTPSetLogger(logger Logger) HTTPOption {
+	return func(h *httpHandler) {
+		if logger == nil {
+			logger = &defaultLogger{}
+		}
+		h.logger = logger
+	}
+}
+
+// HTTPHandler returns the net/http handler serving the gateway.
+func HTTPHandler(gw *Gateway, opts ...HTTPOption) http.Handler {
+	h := &httpHandler{
+		gw:     gw,
+		logger: &defaultLogger{},
+	}
+
+	for _, f := range opts {
+		f(h)
+	}
+
+	return h
+}
+
+// NewHTTPRequest translates the request to the API Gateway payload format 2.0.
+func NewHTTPRequest(r *http.Request, body []byte) *Request {
+	headers := map[string]string{}
+	for k, vs := range r.Header {
+		headers[strings.ToLower(k)] = strings.Join(vs, ",")
+	}
+
+	query := map[string]string{}
+	for k, vs := range r.URL.Query() {
+		query[k] = strings.Join(vs, ",")
+	}
+
+	var cookies []string
+	for _, c := range r.Cookies() {
+		cookies = append(cookies, c.String())
+	}
+
+	requestId := r.Header.Get("X-Request-Id")
+	if requestId == "" {
+		requestId = newRequestId()
+	}
+
+	sourceIP := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		sourceIP = host
+	}
+
+	return &Request{
+		Version:               "2.0",
+		RouteKey:              "$default",
+		RawPath:               r.URL.EscapedPath(),
+		RawQueryString:        r.URL.RawQuery,
+		Cookies:               cookies,
+		Headers:               headers,
+		QueryStringParameters: query,
+		Body:                  string(body),
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RouteKey:   "$default",
+			RequestID:  requestId,
+			DomainName: r.Host,
+			TimeEpoch:  time.Now().UnixNano() / int64(time.Millisecond),
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Protocol:  r.Proto,
+				SourceIP:  sourceIP,
+				UserAgent: r.UserAgent(),
+			},
+			Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+				JWT: &events.APIGatewayV2HTTPRequestContextAuthorizerJWTDescription{
+					Claims: map[string]string{},
+				},
+			},
+		},
+	}
+}
+
+// WriteHTTPResponse writes the response of the gateway to w.
+func WriteHTTPResponse(w http.ResponseWriter, res *Response) error {
+	h := w.Header()
+	for k, v := range res.Headers {
+		h.Set(k, v)
+	}
+	for k, vs := range res.MultiValueHeaders {
+		for _, v := range vs {
+			h.Add(k, v)
+		}
+	}
+	for _, c := range res.Cookies {
+		h.Add("Set-Cookie", c)
+	}
+
+	body := []byte(res.Body)
+	if res.IsBase64Encoded {
+		bs, err := base64.StdEncoding.DecodeString(res.Body)
+		if err != nil {
+			return err
+		}
+		body = bs
+	}
+
+	code := res.StatusCode
+	if code == 0 {
+		code = http.StatusOK
+	}
+	w.WriteHeader(code)
+	_, err := w.Write(body)
+	return err
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Error("read body", err)
+		WriteHTTPResponse(w, h.gw.errorResponse(ClientError(400, "BadRequest")))
+		return
+	}
+
+	req := NewHTTPRequest(r, body)
+
+	// the preflight requests are not authenticated.
+	if h.authorizer != nil && !isPreflight(req) {
+		claims, err := h.authorizer(r)
+		if err != nil {
+			h.logger.Error("authorizer", err)
+			WriteHTTPResponse(w, h.gw.withCORS(req, h.gw.errorResponse(ClientError(401, "Unauthorized"))))
+			return
+		}
+		req.RequestContext.Authorizer.JWT.Claims = claims
+	}
+
+	res, err := h.gw.Serve(r.Context(), req)
+	if err != nil {
+		h.logger.Error("Serve", err)
+		WriteHTTPResponse(w, h.gw.errorResponse(ServerError()))
+		return
+	}
+
+	if err := WriteHTTPResponse(w, res); err != nil {
+		h.logger.Error("write response", err)
+	}
+}
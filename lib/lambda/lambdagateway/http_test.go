@@ -0,0 +1,99 @@
+package lambdagateway
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	gw := NewGateway(HandlerInvoker(&echoHandler{}),
+		AddFunction("/echo", "POST", "echo", "Echo"),
+		WithClaimSubID("user-id"),
+	)
+	srv := httptest.NewServer(HTTPHandler(gw,
+		HTTPSetAuthorizer(func(r *http.Request) (map[string]string, error) {
+			if r.Header.Get("Authorization") == "" {
+				return nil, errors.New("no token")
+			}
+			return map[string]string{"sub": "user-1"}, nil
+		}),
+	))
+	defer srv.Close()
+
+	for _, c := range []struct {
+		path  string
+		token string
+		code  int
+		body  string
+	}{
+		{"/echo", "token", 200, `["Echo","{}"]`},
+		{"/echo", "", 401, `{"error_type":"ClientError","error_message":"Unauthorized"}` + "\n"},
+		{"/unknown", "token", 404, `{"error_type":"ClientError","error_message":"NotFound"}` + "\n"},
+	} {
+		req, err := http.NewRequest("POST", srv.URL+c.path, strings.NewReader(`{}`))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", c.token)
+		}
+
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatalf("ReadAll: %v", err)
+		}
+
+		if res.StatusCode != c.code || string(body) != c.body {
+			t.Errorf("%s: code=%d body=%q", c.path, res.StatusCode, body)
+		}
+		if origin := res.Header.Get("Access-Control-Allow-Origin"); origin != "*" {
+			t.Errorf("%s: Access-Control-Allow-Origin=%q", c.path, origin)
+		}
+	}
+
+	// the preflight request has no token.
+	req, err := http.NewRequest("OPTIONS", srv.URL+"/echo", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != 204 || res.Header.Get("Access-Control-Allow-Methods") == "" {
+		t.Errorf("preflight: code=%d headers=%v", res.StatusCode, res.Header)
+	}
+}
+
+func TestNewHTTPRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/kifu/a%2Fb?a=1&a=2&b=3", nil)
+	r.Header.Set("X-Request-Id", "req-1")
+	r.RemoteAddr = "192.0.2.1:1234"
+
+	req := NewHTTPRequest(r, nil)
+	// the path is kept escaped, same as rawPath of API Gateway.
+	if req.RawPath != "/kifu/a%2Fb" || req.RawQueryString != "a=1&a=2&b=3" {
+		t.Errorf("path: %q %q", req.RawPath, req.RawQueryString)
+	}
+	if q := req.QueryStringParameters; q["a"] != "1,2" || q["b"] != "3" {
+		t.Errorf("query: %v", q)
+	}
+	if req.Headers["x-request-id"] != "req-1" || req.RequestContext.RequestID != "req-1" {
+		t.Errorf("request id: %v %v", req.Headers, req.RequestContext.RequestID)
+	}
+	if h := req.RequestContext.HTTP; h.Method != "GET" || h.SourceIP != "192.0.2.1" {
+		t.Errorf("http: %+v", h)
+	}
+}
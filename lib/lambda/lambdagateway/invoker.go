@@ -0,0 +1,207 @@
+package lambdagateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awslambda "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// ClientContextHeaderPrefix is the prefix of the request headers which carry the custom fields
+// of the client context to the HTTP backends, e.g. "X-Client-Context-Function-Id".
+const ClientContextHeaderPrefix = "X-Client-Context-"
+
+// SecretHeader is the request header which carries the secret of HTTPInvokerSetSecret,
+// same as lambdarpc.SecretHeader.
+const SecretHeader = "X-Function-Secret"
+
+// Invoker invokes the function of a route.
+// The error of the function is returned as *LambdaError, other errors are failures of the invocation.
+type Invoker interface {
+	Invoke(ctx context.Context, function string, cc *lambdacontext.ClientContext, payload []byte) ([]byte, error)
+}
+
+type lambdaInvoker struct {
+	client lambdaiface.LambdaAPI
+}
+
+// LambdaInvoker invokes the functions by ARN through the Lambda API.
+func LambdaInvoker(client lambdaiface.LambdaAPI) Invoker {
+	return &lambdaInvoker{client: client}
+}
+
+func encodeClientContext(cc *lambdacontext.ClientContext) (string, error) {
+	var buf strings.Builder
+
+	w := base64.NewEncoder(base64.URLEncoding, &buf)
+
+	enc := json.NewEncoder(w)
+
+	if err := enc.Encode(cc); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func decodeLambdaError(payload []byte) error {
+	errObj := &LambdaError{}
+	if err := json.NewDecoder(bytes.NewReader(payload)).Decode(errObj); err != nil {
+		return &ErrorDecodeError{
+			OriginalErrorMessage: &ErrorMessage{ErrorMessage: string(payload)},
+			Err:                  err,
+		}
+	}
+	return errObj
+}
+
+func (i *lambdaInvoker) Invoke(ctx context.Context, function string, cc *lambdacontext.ClientContext, payload []byte) ([]byte, error) {
+	encoded, err := encodeClientContext(cc)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := i.client.InvokeWithContext(ctx, &awslambda.InvokeInput{
+		ClientContext:  aws.String(encoded),
+		FunctionName:   aws.String(function),
+		InvocationType: aws.String(awslambda.InvocationTypeRequestResponse),
+		Payload:        payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if out.FunctionError != nil {
+		return nil, decodeLambdaError(out.Payload)
+	}
+
+	return out.Payload, nil
+}
+
+type handlerInvoker struct {
+	handler lambda.Handler
+}
+
+// HandlerInvoker invokes the handler in-process, e.g. lambdarpc.Handler.
+// The function name is passed as InvokedFunctionArn of the lambda context.
+func HandlerInvoker(handler lambda.Handler) Invoker {
+	return &handlerInvoker{handler: handler}
+}
+
+func newRequestId() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// errorType returns the type name of the error as the Lambda runtime reports.
+func errorType(err error) string {
+	t := reflect.TypeOf(err)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func (i *handlerInvoker) Invoke(ctx context.Context, function string, cc *lambdacontext.ClientContext, payload []byte) ([]byte, error) {
+	ctx = lambdacontext.NewContext(ctx, &lambdacontext.LambdaContext{
+		AwsRequestID:       newRequestId(),
+		InvokedFunctionArn: function,
+		ClientContext:      *cc,
+	})
+
+	out, err := i.handler.Invoke(ctx, payload)
+	if err != nil {
+		return nil, &LambdaError{
+			ErrorType:    errorType(err),
+			ErrorMessage: err.Error(),
+		}
+	}
+
+	return out, nil
+}
+
+type httpInvoker struct {
+	client *http.Client
+	secret string
+}
+
+type HTTPInvokerOption func(*httpInvoker)
+
+// HTTPInvokerSetSecret sends the secret shared with the functions in SecretHeader.
+func HTTPInvokerSetSecret(secret string) HTTPInvokerOption {
+	return func(i *httpInvoker) {
+		i.secret = secret
+	}
+}
+
+// HTTPInvoker invokes the functions by POSTing the payload to the function as URL.
+// The custom fields of the client context are sent as the headers with ClientContextHeaderPrefix.
+// A response other than 2xx is decoded as LambdaError.
+func HTTPInvoker(client *http.Client, opts ...HTTPInvokerOption) Invoker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	i := &httpInvoker{client: client}
+
+	for _, f := range opts {
+		f(i)
+	}
+
+	return i
+}
+
+func (i *httpInvoker) Invoke(ctx context.Context, function string, cc *lambdacontext.ClientContext, payload []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, function, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range cc.Custom {
+		req.Header.Set(ClientContextHeaderPrefix+k, v)
+	}
+	if i.secret != "" {
+		req.Header.Set(SecretHeader, i.secret)
+	}
+
+	res, err := i.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode/100 != 2 {
+		if len(body) == 0 {
+			return nil, fmt.Errorf("%s: %s", function, res.Status)
+		}
+		return nil, decodeLambdaError(body)
+	}
+
+	return body, nil
+}
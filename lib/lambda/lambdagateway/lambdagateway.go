@@ -1,17 +1,15 @@
 package lambdagateway
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"net/url"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 )
 
 type Request events.APIGatewayV2HTTPRequest
@@ -98,36 +96,79 @@ var _ Logger = (*defaultLogger)(nil)
 func (*defaultLogger) Error(msg string, err error) {}
 
 type function struct {
+	// the name passed to the invoker, such as the ARN of the Lambda function.
 	lambdaArn string
 	id        string
 }
 
+// templateRoute is the functions of a path template.
+type templateRoute struct {
+	path      string
+	template  *pathTemplate
+	functions map[string]function
+}
+
 type Gateway struct {
-	lambdaClient         *lambda.Lambda
+	invoker              Invoker
 	basePath             string
 	functions            map[string]map[string]function
+	templates            []*templateRoute
 	functionErrorHandler func(*LambdaError) error
 	contextModifiers     []func(*lambdacontext.ClientContext, *Request) error
 	logger               Logger
+	cors                 *CORS
+	verifier             TokenVerifier
 }
 
 type GatewayOption func(*Gateway)
 
+// AddFunction routes the requests of the method to the path to the function.
+// The path may be a template such as "/kifu/{kifu_id}/steps/{seq:int}?format={format}":
+// the path parameters and the declared query parameters are bound to the fields of the JSON payload by the names.
+// {name:int} and {name:bool} bind a number and a boolean and match only the values of the type.
+// It panics if the template is invalid.
+// The exact paths take precedence over the templates, and the templates are matched in the order of addition.
 func AddFunction(path, method, lambdaArn string, id string) GatewayOption {
 	return func(s *Gateway) {
+		f := function{
+			lambdaArn: lambdaArn,
+			id:        id,
+		}
+
+		if isTemplate(path) {
+			s.addTemplate(path, method, f)
+			return
+		}
+
 		p, ok := s.functions[path]
 		if !ok {
 			p = map[string]function{}
 		}
 
-		p[method] = function{
-			lambdaArn: lambdaArn,
-			id:        id,
-		}
+		p[method] = f
 		s.functions[path] = p
 	}
 }
 
+func (s *Gateway) addTemplate(path, method string, f function) {
+	for _, r := range s.templates {
+		if r.path == path {
+			r.functions[method] = f
+			return
+		}
+	}
+
+	t, err := parseTemplate(path)
+	if err != nil {
+		panic("lambdagateway: invalid path template " + path + ": " + err.Error())
+	}
+	s.templates = append(s.templates, &templateRoute{
+		path:      path,
+		template:  t,
+		functions: map[string]function{method: f},
+	})
+}
+
 func SetFunctionErrorHandler(h func(*LambdaError) error) GatewayOption {
 	return func(s *Gateway) {
 		if h == nil {
@@ -199,6 +240,63 @@ func WithClaimSubID(field string) GatewayOption {
 	}
 }
 
+// GroupsClaim is the claim of the groups of the user in the Cognito tokens.
+const GroupsClaim = "cognito:groups"
+
+// parseClaimList parses the claim of an array formatted as "[a b]" by the JWT authorizer.
+func parseClaimList(s string) []string {
+	return strings.Fields(strings.TrimSuffix(strings.TrimPrefix(s, "["), "]"))
+}
+
+// WithClaimGroups sets the groups of the user (GroupsClaim) to the field of the client context, separated by spaces.
+func WithClaimGroups(field string) GatewayOption {
+	return func(s *Gateway) {
+		s.contextModifiers = append(s.contextModifiers, func(cc *lambdacontext.ClientContext, req *Request) error {
+			if cc == nil {
+				return nil
+			}
+
+			if cc.Custom == nil {
+				cc.Custom = make(map[string]string)
+			}
+
+			var groups []string
+			if a := req.RequestContext.Authorizer; a != nil && a.JWT != nil {
+				groups = parseClaimList(a.JWT.Claims[GroupsClaim])
+			}
+			cc.Custom[field] = strings.Join(groups, " ")
+
+			return nil
+		})
+	}
+}
+
+// WithScopes sets the scopes of the token to the field of the client context, separated by spaces.
+func WithScopes(field string) GatewayOption {
+	return func(s *Gateway) {
+		s.contextModifiers = append(s.contextModifiers, func(cc *lambdacontext.ClientContext, req *Request) error {
+			if cc == nil {
+				return nil
+			}
+
+			if cc.Custom == nil {
+				cc.Custom = make(map[string]string)
+			}
+
+			var scopes []string
+			if a := req.RequestContext.Authorizer; a != nil && a.JWT != nil {
+				scopes = a.JWT.Scopes
+				if len(scopes) == 0 {
+					scopes = strings.Fields(a.JWT.Claims["scope"])
+				}
+			}
+			cc.Custom[field] = strings.Join(scopes, " ")
+
+			return nil
+		})
+	}
+}
+
 func SetLogger(logger Logger) GatewayOption {
 	return func(s *Gateway) {
 		if logger == nil {
@@ -208,15 +306,25 @@ func SetLogger(logger Logger) GatewayOption {
 	}
 }
 
+// NewLambdaGateway returns the gateway which invokes the functions through the Lambda API.
 func NewLambdaGateway(
-	lambdaClient *lambda.Lambda,
+	lambdaClient lambdaiface.LambdaAPI,
+	opts ...GatewayOption,
+) *Gateway {
+	return NewGateway(LambdaInvoker(lambdaClient), opts...)
+}
+
+// NewGateway returns the gateway which invokes the functions with the invoker.
+func NewGateway(
+	invoker Invoker,
 	opts ...GatewayOption,
 ) *Gateway {
 	h := &Gateway{
-		lambdaClient:         lambdaClient,
+		invoker:              invoker,
 		functions:            map[string]map[string]function{},
 		functionErrorHandler: func(err *LambdaError) error { return err },
 		logger:               &defaultLogger{},
+		cors:                 DefaultCORS(),
 	}
 
 	for _, f := range opts {
@@ -227,9 +335,7 @@ func NewLambdaGateway(
 }
 
 func (s *Gateway) buildResponse(statusCode int, contentType, body string) *Response {
-	headers := map[string]string{
-		"Access-Control-Allow-Origin": "*",
-	}
+	headers := map[string]string{}
 
 	if contentType != "" {
 		headers["Content-Type"] = contentType
@@ -278,35 +384,81 @@ func (s *Gateway) errorResponse(e Error) *Response {
 	return s.buildResponse(e.statusCode(), "application/josn", buf.String())
 }
 
-func encodeClientContext(cc *lambdacontext.ClientContext) (string, error) {
-	var buf strings.Builder
+// route returns the function of the request and the parameters bound to the payload.
+func (s *Gateway) route(reqPath string, req *Request) (function, map[string]interface{}, Error) {
+	method := req.RequestContext.HTTP.Method
 
-	w := base64.NewEncoder(base64.URLEncoding, &buf)
+	path, pathMatched := s.functions[reqPath]
+	if f, ok := path[method]; ok {
+		return f, nil, nil
+	}
 
-	enc := json.NewEncoder(w)
+	for _, r := range s.templates {
+		params, ok := r.template.match(reqPath)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+
+		f, ok := r.functions[method]
+		if !ok {
+			continue
+		}
 
-	if err := enc.Encode(cc); err != nil {
-		return "", err
+		query, err := url.ParseQuery(req.RawQueryString)
+		if err != nil {
+			return function{}, nil, ClientError(400, "invalid query string")
+		}
+		if err := r.template.bindQuery(query, params); err != nil {
+			return function{}, nil, ClientError(400, err.Error())
+		}
+		return f, params, nil
 	}
 
-	if err := w.Close(); err != nil {
-		return "", err
+	if pathMatched {
+		return function{}, nil, ClientError(405, "MethodNotAllowed")
 	}
+	return function{}, nil, ClientError(404, "NotFound")
+}
 
-	return buf.String(), nil
+// withCORS applies the CORS policy to the response.
+func (s *Gateway) withCORS(req *Request, res *Response) *Response {
+	if s.cors != nil {
+		s.cors.apply(req, res)
+	}
+	return res
 }
 
 func (s *Gateway) Serve(ctx context.Context, req *Request) (*Response, error) {
+	if s.cors != nil && isPreflight(req) {
+		return s.cors.preflight(req), nil
+	}
+
+	if s.verifier != nil {
+		if err := s.authorize(ctx, req); err != nil {
+			s.logger.Error("authorize", err)
+			return s.withCORS(req, s.errorResponse(ClientError(401, "Unauthorized"))), nil
+		}
+	}
+
+	res, err := s.serve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.withCORS(req, res), nil
+}
+
+func (s *Gateway) serve(ctx context.Context, req *Request) (*Response, error) {
 	reqPath := strings.TrimPrefix(req.RawPath, s.basePath)
 
-	path, ok := s.functions[reqPath]
-	if !ok {
-		return s.errorResponse(ClientError(404, "NotFound")), nil
+	function, params, e := s.route(reqPath, req)
+	if e != nil {
+		return s.errorResponse(e), nil
 	}
 
-	function, ok := path[req.RequestContext.HTTP.Method]
-	if !ok {
-		return s.errorResponse(ClientError(405, "MethodNotAllowed")), nil
+	payload, err := bindPayload(req.Body, params)
+	if err != nil {
+		return s.errorResponse(ClientError(400, err.Error())), nil
 	}
 
 	clientContext := lambdacontext.ClientContext{
@@ -321,31 +473,11 @@ func (s *Gateway) Serve(ctx context.Context, req *Request) (*Response, error) {
 		}
 	}
 
-	cc, err := encodeClientContext(&clientContext)
+	out, err := s.invoker.Invoke(ctx, function.lambdaArn, &clientContext, []byte(payload))
 	if err != nil {
-		s.logger.Error("encode client_context", err)
-		return s.errorResponse(ServerError()), nil
-	}
-
-	in := &lambda.InvokeInput{
-		ClientContext:  aws.String(cc),
-		FunctionName:   aws.String(function.lambdaArn),
-		InvocationType: aws.String(lambda.InvocationTypeRequestResponse),
-		Payload:        []byte(req.Body),
-	}
-
-	out, err := s.lambdaClient.InvokeWithContext(ctx, in)
-	if err != nil {
-		s.logger.Error("lambda invocation", err)
-		return s.errorResponse(ServerError()), nil
-	}
-
-	if out.FunctionError != nil {
-		buf := bytes.NewReader(out.Payload)
-		d := json.NewDecoder(buf)
-		errObj := &LambdaError{}
-		if err := d.Decode(errObj); err != nil {
-			s.logger.Error("DecodeError: "+string(out.Payload), err)
+		errObj, ok := err.(*LambdaError)
+		if !ok {
+			s.logger.Error("lambda invocation", err)
 			return s.errorResponse(ServerError()), nil
 		}
 
@@ -361,8 +493,8 @@ func (s *Gateway) Serve(ctx context.Context, req *Request) (*Response, error) {
 	}
 
 	var body, contentType string
-	if out.Payload != nil {
-		body = string(out.Payload)
+	if out != nil {
+		body = string(out)
 		contentType = "application/json"
 	}
 
@@ -0,0 +1,137 @@
+package lambdagateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	awslambda "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+type TestError struct{}
+
+func (*TestError) Error() string { return "test error" }
+
+// echoHandler returns the function id and the payload, or TestError if the payload is "error".
+type echoHandler struct{}
+
+func (*echoHandler) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	lc, ok := lambdacontext.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("no lambda context")
+	}
+	if string(payload) == `"error"` {
+		return nil, &TestError{}
+	}
+	return json.Marshal([]string{lc.ClientContext.Custom["function-id"], string(payload)})
+}
+
+func newTestRequest(path, body string) *Request {
+	return &Request{
+		RawPath: path,
+		Body:    body,
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RequestID: "api-request-id",
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: "POST",
+			},
+		},
+	}
+}
+
+func testGateway(t *testing.T, invoker Invoker, function string) {
+	gw := NewGateway(invoker,
+		AddFunction("/echo", "POST", function, "Echo"),
+		SetFunctionErrorHandler(func(e *LambdaError) error {
+			if e.ErrorType == "TestError" {
+				return ClientError(400, e.ErrorMessage)
+			}
+			return ServerError()
+		}),
+	)
+
+	for _, c := range []struct {
+		path, body string
+		code       int
+		res        string
+	}{
+		{"/echo", `{}`, 200, `["Echo","{}"]`},
+		{"/echo", `"error"`, 400, `{"error_type":"ClientError","error_message":"test error"}` + "\n"},
+		{"/unknown", `{}`, 404, `{"error_type":"ClientError","error_message":"NotFound"}` + "\n"},
+	} {
+		res, err := gw.Serve(context.Background(), newTestRequest(c.path, c.body))
+		if err != nil {
+			t.Fatalf("Serve: %v", err)
+		}
+		if res.StatusCode != c.code || res.Body != c.res {
+			t.Errorf("%s %s: code=%d body=%q", c.path, c.body, res.StatusCode, res.Body)
+		}
+	}
+}
+
+func TestGateway_HandlerInvoker(t *testing.T) {
+	testGateway(t, HandlerInvoker(&echoHandler{}), "echo")
+}
+
+func TestGateway_HTTPInvoker(t *testing.T) {
+	h := &echoHandler{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(SecretHeader) != "secret-test" {
+			w.WriteHeader(401)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		cc := lambdacontext.ClientContext{
+			Custom: map[string]string{
+				"function-id": r.Header.Get(ClientContextHeaderPrefix + "function-id"),
+			},
+		}
+		ctx := lambdacontext.NewContext(r.Context(), &lambdacontext.LambdaContext{ClientContext: cc})
+		out, err := h.Invoke(ctx, body)
+		if err != nil {
+			w.WriteHeader(500)
+			json.NewEncoder(w).Encode(&LambdaError{ErrorType: errorType(err), ErrorMessage: err.Error()})
+			return
+		}
+		w.Write(out)
+	}))
+	defer srv.Close()
+
+	testGateway(t, HTTPInvoker(srv.Client(), HTTPInvokerSetSecret("secret-test")), srv.URL)
+}
+
+type fakeLambda struct {
+	lambdaiface.LambdaAPI
+
+	invoker Invoker
+}
+
+func (l *fakeLambda) InvokeWithContext(ctx aws.Context, in *awslambda.InvokeInput, _ ...request.Option) (*awslambda.InvokeOutput, error) {
+	if aws.StringValue(in.ClientContext) == "" {
+		return nil, errors.New("no client context")
+	}
+	cc := &lambdacontext.ClientContext{Custom: map[string]string{"function-id": "Echo"}}
+
+	out, err := l.invoker.Invoke(ctx, aws.StringValue(in.FunctionName), cc, in.Payload)
+	if e, ok := err.(*LambdaError); ok {
+		bs, _ := json.Marshal(e)
+		return &awslambda.InvokeOutput{FunctionError: aws.String("Unhandled"), Payload: bs}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &awslambda.InvokeOutput{Payload: out}, nil
+}
+
+func TestGateway_LambdaInvoker(t *testing.T) {
+	testGateway(t, LambdaInvoker(&fakeLambda{invoker: HandlerInvoker(&echoHandler{})}), "arn:echo")
+}
@@ -0,0 +1,186 @@
+package lambdagateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidPayload = errors.New("payload is not a JSON object")
+
+// param is a parameter of the path template, "{name}", "{name:int}" or "{name:bool}".
+type param struct {
+	name string
+	typ  string
+}
+
+func parseParam(s string) (*param, bool, error) {
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, false, nil
+	}
+
+	name := s[1 : len(s)-1]
+	p := &param{name: name}
+	if i := strings.Index(name, ":"); i >= 0 {
+		p.name, p.typ = name[:i], name[i+1:]
+		if p.typ != "int" && p.typ != "bool" {
+			return nil, false, fmt.Errorf("unknown type of parameter: %s", s)
+		}
+	}
+	if p.name == "" {
+		return nil, false, fmt.Errorf("empty parameter name: %s", s)
+	}
+
+	return p, true, nil
+}
+
+func (p *param) value(s string) (interface{}, error) {
+	switch p.typ {
+	case "int":
+		return strconv.ParseInt(s, 10, 64)
+	case "bool":
+		return strconv.ParseBool(s)
+	default:
+		return s, nil
+	}
+}
+
+type segment struct {
+	literal string
+	param   *param
+}
+
+type queryParam struct {
+	key   string
+	param *param
+}
+
+// pathTemplate is the path such as "/kifu/{kifu_id}/steps/{seq:int}?format={format}".
+// The parameters are bound to the fields of the request payload by the names.
+type pathTemplate struct {
+	segments []segment
+	query    []queryParam
+}
+
+func isTemplate(s string) bool {
+	return strings.Contains(s, "{")
+}
+
+func parseTemplate(s string) (*pathTemplate, error) {
+	t := &pathTemplate{}
+
+	path, query := s, ""
+	if i := strings.Index(s, "?"); i >= 0 {
+		path, query = s[:i], s[i+1:]
+	}
+
+	for _, seg := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		p, ok, err := parseParam(seg)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			t.segments = append(t.segments, segment{param: p})
+		} else {
+			t.segments = append(t.segments, segment{literal: seg})
+		}
+	}
+
+	if query != "" {
+		for _, kv := range strings.Split(query, "&") {
+			i := strings.Index(kv, "=")
+			if i < 0 {
+				return nil, fmt.Errorf("query parameter without value: %s", kv)
+			}
+			p, ok, err := parseParam(kv[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("query parameter is not a template: %s", kv)
+			}
+			t.query = append(t.query, queryParam{key: kv[:i], param: p})
+		}
+	}
+
+	return t, nil
+}
+
+// match returns the path parameters if the path matches the template.
+// A typed parameter does not match a segment of another type.
+func (t *pathTemplate) match(path string) (map[string]interface{}, bool) {
+	segs := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segs) != len(t.segments) {
+		return nil, false
+	}
+
+	params := map[string]interface{}{}
+	for i, seg := range t.segments {
+		if seg.param == nil {
+			if seg.literal != segs[i] {
+				return nil, false
+			}
+			continue
+		}
+
+		s, err := url.PathUnescape(segs[i])
+		if err != nil || s == "" {
+			return nil, false
+		}
+		v, err := seg.param.value(s)
+		if err != nil {
+			return nil, false
+		}
+		params[seg.param.name] = v
+	}
+
+	return params, true
+}
+
+// bindQuery adds the query parameters declared in the template to params.
+func (t *pathTemplate) bindQuery(values url.Values, params map[string]interface{}) error {
+	for _, q := range t.query {
+		s := values.Get(q.key)
+		if s == "" {
+			continue
+		}
+		v, err := q.param.value(s)
+		if err != nil {
+			return fmt.Errorf("invalid query parameter %s: %v", q.key, err)
+		}
+		params[q.param.name] = v
+	}
+	return nil
+}
+
+// bindPayload sets the parameters to the fields of the JSON object of body.
+// The parameters take precedence over the fields of body.
+func bindPayload(body string, params map[string]interface{}) (string, error) {
+	if len(params) == 0 {
+		return body, nil
+	}
+
+	obj := map[string]json.RawMessage{}
+	if strings.TrimSpace(body) != "" {
+		if err := json.Unmarshal([]byte(body), &obj); err != nil || obj == nil {
+			return "", ErrInvalidPayload
+		}
+	}
+
+	for k, v := range params {
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		obj[k] = bs
+	}
+
+	bs, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
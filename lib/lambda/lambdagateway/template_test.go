@@ -0,0 +1,122 @@
+package lambdagateway
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tmpl, err := parseTemplate("/kifu/{kifu_id}/steps/{seq:int}?f={format}&n={cell_size:int}&x={flip:bool}")
+	if err != nil {
+		t.Fatalf("parseTemplate: %v", err)
+	}
+
+	params, ok := tmpl.match("/kifu/a%2Fb/steps/12")
+	if !ok {
+		t.Fatalf("not matched")
+	}
+	query, _ := url.ParseQuery("f=PNG&n=30&x=true&other=1")
+	if err := tmpl.bindQuery(query, params); err != nil {
+		t.Fatalf("bindQuery: %v", err)
+	}
+	expected := map[string]interface{}{
+		"kifu_id":   "a/b",
+		"seq":       int64(12),
+		"format":    "PNG",
+		"cell_size": int64(30),
+		"flip":      true,
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("params: expected=%v actual=%v", expected, params)
+	}
+
+	for _, path := range []string{
+		"/kifu/a/steps/x",
+		"/kifu/a/steps",
+		"/kifu//steps/1",
+		"/kifu/a/moves/1",
+	} {
+		if _, ok := tmpl.match(path); ok {
+			t.Errorf("%s: matched", path)
+		}
+	}
+
+	query, _ = url.ParseQuery("n=abc")
+	if err := tmpl.bindQuery(query, map[string]interface{}{}); err == nil {
+		t.Errorf("expected error for invalid int")
+	}
+
+	for _, s := range []string{"/kifu/{id:float}", "/kifu/{}", "/kifu?id", "/kifu?id=1"} {
+		if _, err := parseTemplate(s); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
+
+func TestBindPayload(t *testing.T) {
+	params := map[string]interface{}{"kifu_id": "k1", "seq": int64(3)}
+
+	for body, expected := range map[string]string{
+		"":                                 `{"kifu_id":"k1","seq":3}`,
+		`{"kifu_id":"k0","format":"PNG"}`:  `{"format":"PNG","kifu_id":"k1","seq":3}`,
+		`{"arrows":[{"from":{"x":1}}]}   `: `{"arrows":[{"from":{"x":1}}],"kifu_id":"k1","seq":3}`,
+	} {
+		actual, err := bindPayload(body, params)
+		if err != nil {
+			t.Fatalf("bindPayload(%q): %v", body, err)
+		}
+		if actual != expected {
+			t.Errorf("bindPayload(%q): expected=%s actual=%s", body, expected, actual)
+		}
+	}
+
+	for _, body := range []string{`[]`, `null`, `"s"`} {
+		if _, err := bindPayload(body, params); err != ErrInvalidPayload {
+			t.Errorf("bindPayload(%q): expected ErrInvalidPayload: %v", body, err)
+		}
+	}
+
+	if actual, err := bindPayload("raw", nil); err != nil || actual != "raw" {
+		t.Errorf("body without params is modified: %q %v", actual, err)
+	}
+}
+
+func TestGateway_Template(t *testing.T) {
+	gw := NewGateway(HandlerInvoker(&echoHandler{}),
+		AddFunction("/kifu", "POST", "echo", "PostKifu"),
+		AddFunction("/kifu?limit={limit:int}", "GET", "echo", "RecentKifu"),
+		AddFunction("/kifu/{kifu_id}", "GET", "echo", "GetKifu"),
+		AddFunction("/kifu/{kifu_id}", "DELETE", "echo", "DeleteKifu"),
+		AddFunction("/kifu/{kifu_id}/steps/{seq:int}", "GET", "echo", "GetStep"),
+	)
+
+	for _, c := range []struct {
+		method, path, query, body string
+		code                      int
+		res                       string
+	}{
+		{"POST", "/kifu", "", `{}`, 200, `["PostKifu","{}"]`},
+		{"GET", "/kifu", "limit=5", "", 200, `["RecentKifu","{\"limit\":5}"]`},
+		{"GET", "/kifu/k1", "", "", 200, `["GetKifu","{\"kifu_id\":\"k1\"}"]`},
+		{"DELETE", "/kifu/k1", "", `{"version":2}`, 200, `["DeleteKifu","{\"kifu_id\":\"k1\",\"version\":2}"]`},
+		{"GET", "/kifu/k1/steps/3", "", "", 200, `["GetStep","{\"kifu_id\":\"k1\",\"seq\":3}"]`},
+		{"PATCH", "/kifu/k1", "", "", 405, `{"error_type":"ClientError","error_message":"MethodNotAllowed"}` + "\n"},
+		{"GET", "/kifu/k1/steps/x", "", "", 404, `{"error_type":"ClientError","error_message":"NotFound"}` + "\n"},
+		{"GET", "/kifu", "limit=x", "", 400, ""},
+		{"GET", "/kifu/k1", "", "[]", 400, ""},
+	} {
+		req := newTestRequest(c.path, c.body)
+		req.RawQueryString = c.query
+		req.RequestContext.HTTP.Method = c.method
+
+		res, err := gw.Serve(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Serve: %v", err)
+		}
+		if res.StatusCode != c.code || (c.res != "" && res.Body != c.res) {
+			t.Errorf("%s %s?%s: code=%d body=%q", c.method, c.path, c.query, res.StatusCode, res.Body)
+		}
+	}
+}
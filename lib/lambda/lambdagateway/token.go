@@ -0,0 +1,73 @@
+package lambdagateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+var ErrNoToken = errors.New("no bearer token")
+
+// TokenVerifier verifies the token and returns the claims, e.g. *jwt.Verifier.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (map[string]interface{}, error)
+}
+
+// VerifyToken verifies the bearer tokens of the requests and sets the claims and the scopes to the JWT authorizer context,
+// in place of the authorizer of API Gateway. The requests without a valid token are rejected with 401.
+func VerifyToken(v TokenVerifier) GatewayOption {
+	return func(s *Gateway) {
+		s.verifier = v
+	}
+}
+
+// claimString formats the claim as the JWT authorizer of API Gateway does: the arrays are "[a b]".
+func claimString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		var ss []string
+		for _, e := range v {
+			ss = append(ss, claimString(e))
+		}
+		return "[" + strings.Join(ss, " ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (s *Gateway) authorize(ctx context.Context, req *Request) error {
+	token := req.Headers["authorization"]
+	if !strings.HasPrefix(token, "Bearer ") {
+		return ErrNoToken
+	}
+
+	payload, err := s.verifier.Verify(ctx, strings.TrimPrefix(token, "Bearer "))
+	if err != nil {
+		return err
+	}
+
+	claims := map[string]string{}
+	for k, v := range payload {
+		claims[k] = claimString(v)
+	}
+	var scopes []string
+	if scope, ok := payload["scope"].(string); ok {
+		scopes = strings.Fields(scope)
+	}
+
+	req.RequestContext.Authorizer = &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+		JWT: &events.APIGatewayV2HTTPRequestContextAuthorizerJWTDescription{
+			Claims: claims,
+			Scopes: scopes,
+		},
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package lambdagateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+type fakeVerifier struct{}
+
+func (*fakeVerifier) Verify(ctx context.Context, token string) (map[string]interface{}, error) {
+	if token != "valid" {
+		return nil, errors.New("invalid token")
+	}
+	return map[string]interface{}{
+		"sub":            "user-1",
+		"exp":            float64(1600000000),
+		"cognito:groups": []interface{}{"admin", "user"},
+		"scope":          "kifu/read kifu/write",
+	}, nil
+}
+
+// captureInvoker records the client context.
+type captureInvoker struct {
+	cc *lambdacontext.ClientContext
+}
+
+func (i *captureInvoker) Invoke(ctx context.Context, function string, cc *lambdacontext.ClientContext, payload []byte) ([]byte, error) {
+	i.cc = cc
+	return []byte("{}"), nil
+}
+
+func TestVerifyToken(t *testing.T) {
+	invoker := &captureInvoker{}
+	gw := NewGateway(invoker,
+		AddFunction("/echo", "POST", "echo", "Echo"),
+		WithClaimSubID("user-id"),
+		WithClaimGroups("groups"),
+		WithScopes("scopes"),
+		VerifyToken(&fakeVerifier{}),
+	)
+
+	for token, code := range map[string]int{
+		"":               401,
+		"Bearer invalid": 401,
+		"valid":          401,
+		"Bearer valid":   200,
+	} {
+		invoker.cc = nil
+		req := newTestRequest("/echo", "{}")
+		req.Headers = map[string]string{"authorization": token}
+
+		res, err := gw.Serve(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Serve: %v", err)
+		}
+		if res.StatusCode != code {
+			t.Errorf("%q: code=%d", token, res.StatusCode)
+		}
+		if code != 200 {
+			if invoker.cc != nil {
+				t.Errorf("%q: invoked", token)
+			}
+			continue
+		}
+
+		if u := invoker.cc.Custom["user-id"]; u != "user-1" {
+			t.Errorf("user-id: %q", u)
+		}
+		if g := invoker.cc.Custom["groups"]; g != "admin user" {
+			t.Errorf("groups: %q", g)
+		}
+		if s := invoker.cc.Custom["scopes"]; s != "kifu/read kifu/write" {
+			t.Errorf("scopes: %q", s)
+		}
+		jwt := req.RequestContext.Authorizer.JWT
+		if g := jwt.Claims["cognito:groups"]; g != "[admin user]" {
+			t.Errorf("groups: %q", g)
+		}
+		if exp := jwt.Claims["exp"]; exp != "1600000000" {
+			t.Errorf("exp: %q", exp)
+		}
+		if len(jwt.Scopes) != 2 || jwt.Scopes[1] != "kifu/write" {
+			t.Errorf("scopes: %v", jwt.Scopes)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package lambdarpc
 
 import (
 	"context"
+	"strings"
 )
 
 const (
@@ -9,6 +10,9 @@ const (
 	RequestIdField    = "request-id"
 	UserIdField       = "user-id"
 	FunctionIdField   = "function-id"
+	// space separated groups and scopes of the caller.
+	GroupsField = "groups"
+	ScopesField = "scopes"
 )
 
 func GetApiRequestId(ctx context.Context) string {
@@ -21,7 +25,23 @@ func GetRequestId(ctx context.Context) string {
 	return v.(string)
 }
 
+// GetUserId returns the user ID of the caller, or "" if it is not set.
 func GetUserId(ctx context.Context) string {
-	v := ctx.Value(UserIdField)
-	return v.(string)
+	v, _ := ctx.Value(UserIdField).(string)
+	return v
+}
+
+func getList(ctx context.Context, field string) []string {
+	v, _ := ctx.Value(field).(string)
+	return strings.Fields(v)
+}
+
+// GetGroups returns the groups of the caller, e.g. the Cognito groups.
+func GetGroups(ctx context.Context) []string {
+	return getList(ctx, GroupsField)
+}
+
+// GetScopes returns the OAuth scopes of the caller.
+func GetScopes(ctx context.Context) []string {
+	return getList(ctx, ScopesField)
 }
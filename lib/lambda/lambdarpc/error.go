@@ -23,6 +23,17 @@ func (e *ClientError) Error() string {
 
 func (*ClientError) lambdarpcError() {}
 
+// ForbiddenError is returned when the caller is not allowed to call the method.
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+func (*ForbiddenError) lambdarpcError() {}
+
 type InternalError struct {
 	Message string
 	Err     error
@@ -23,9 +23,26 @@ type Handler struct {
 
 	serviceType reflect.Type
 	methods     map[string]reflect.Method
+	permissions map[string]*Permission
+	interceptor UnaryInterceptor
 	initialized bool
 }
 
+type HandlerOption func(*Handler)
+
+// WithUnaryInterceptors adds the interceptors of the method calls.
+// The interceptors are called in the order of addition, after the permission check.
+// The calls rejected before the method, e.g. by the permission check, also go through the interceptors
+// with the payload as json.RawMessage, so that the errors are logged.
+func WithUnaryInterceptors(interceptors ...UnaryInterceptor) HandlerOption {
+	return func(h *Handler) {
+		if h.interceptor != nil {
+			interceptors = append([]UnaryInterceptor{h.interceptor}, interceptors...)
+		}
+		h.interceptor = ChainUnaryInterceptors(interceptors...)
+	}
+}
+
 var _ lambda.Handler = (*Handler)(nil)
 
 var (
@@ -33,12 +50,17 @@ var (
 	errorType   = reflect.TypeOf((*error)(nil)).Elem()
 )
 
-func NewHandler(service interface{}) *Handler {
-	return &Handler{
+func NewHandler(service interface{}, opts ...HandlerOption) *Handler {
+	h := &Handler{
 		service: service,
 
 		serviceType: reflect.TypeOf(service),
 	}
+	for _, f := range opts {
+		f(h)
+	}
+
+	return h
 }
 
 func validMethod(svcType reflect.Type, m reflect.Method) error {
@@ -103,11 +125,80 @@ func (h *Handler) Init() error {
 	}
 	h.methods = methods
 
+	if p, ok := h.service.(PermissionProvider); ok {
+		h.permissions = p.Permissions()
+		for name := range h.permissions {
+			if _, ok := methods[name]; !ok {
+				return &InvalidMethodError{
+					Details:      "permission of unknown method",
+					InvalidParam: name,
+				}
+			}
+		}
+	}
+
 	h.initialized = true
 
 	return nil
 }
 
+// prepare checks the method and the permission, and returns the decoded request and the method call.
+func (h *Handler) prepare(ctx context.Context, functionId string, payload []byte) (interface{}, UnaryHandler, error) {
+	if functionId == "" {
+		return nil, nil, &ClientError{
+			Message: "No method specified",
+		}
+	}
+
+	m, ok := h.methods[functionId]
+	if !ok {
+		return nil, nil, &ClientError{
+			Message: "method not found",
+		}
+	}
+
+	if p, ok := h.permissions[functionId]; ok && !p.Allowed(ctx) {
+		return nil, nil, &ForbiddenError{
+			Message: "permission denied: " + functionId,
+		}
+	}
+
+	reqType := m.Type.In(2)
+	if reqType.Kind() == reflect.Ptr {
+		reqType = reqType.Elem()
+	}
+
+	reqVal := reflect.New(reqType)
+	if err := json.Unmarshal(payload, reqVal.Interface()); err != nil {
+		return nil, nil, &ClientError{
+			Message: "invalid request",
+			Err:     err,
+		}
+	}
+
+	call := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rets := m.Func.Call([]reflect.Value{
+			reflect.ValueOf(h.service),
+			reflect.ValueOf(ctx),
+			reflect.ValueOf(req),
+		})
+
+		if len(rets) != 2 {
+			return nil, &InternalError{
+				Message: "invalid number of return values",
+			}
+		}
+
+		if e := rets[1].Interface(); e != nil {
+			return nil, e.(error)
+		}
+
+		return rets[0].Interface(), nil
+	}
+
+	return reqVal.Interface(), call, nil
+}
+
 func (h *Handler) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
 	if err := h.Init(); err != nil {
 		return nil, err
@@ -139,46 +230,38 @@ func (h *Handler) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
 		ctx = context.WithValue(ctx, UserIdField, userId)
 	}
 
-	functionId, ok := custom[FunctionIdField]
-	if !ok {
-		return nil, &ClientError{
-			Message: "No method specified",
+	for _, field := range []string{GroupsField, ScopesField} {
+		if v, ok := custom[field]; ok {
+			ctx = context.WithValue(ctx, field, v)
 		}
 	}
 
-	m, ok := h.methods[functionId]
-	if !ok {
-		return nil, &ClientError{
-			Message: "method not found",
+	functionId := custom[FunctionIdField]
+	req, call, err := h.prepare(ctx, functionId, payload)
+	if err != nil {
+		// the rejected call goes through the interceptors to be logged.
+		rejected := err
+		req = json.RawMessage(payload)
+		call = func(context.Context, interface{}) (interface{}, error) {
+			return nil, rejected
 		}
 	}
 
-	reqType := m.Type.In(2)
-	if reqType.Kind() == reflect.Ptr {
-		reqType = reqType.Elem()
+	var resMsg interface{}
+	if h.interceptor != nil {
+		resMsg, err = h.interceptor(ctx, req, &UnaryInfo{Method: functionId}, call)
+	} else {
+		resMsg, err = call(ctx, req)
 	}
-
-	reqVal := reflect.New(reqType)
-	if err := json.Unmarshal(payload, reqVal.Interface()); err != nil {
-		return nil, &InternalError{
-			Message: "json.Unmarshal(req)",
-			Err:     err,
+	if err != nil {
+		if e, ok := err.(lambdarpcError); ok {
+			return nil, e
 		}
-	}
-
-	rets := m.Func.Call([]reflect.Value{
-		reflect.ValueOf(h.service),
-		reflect.ValueOf(ctx),
-		reqVal,
-	})
-
-	if len(rets) != 2 {
 		return nil, &InternalError{
-			Message: "invalid number of return values",
+			Err: err,
 		}
 	}
 
-	resMsg := rets[0].Interface()
 	bs, err := json.Marshal(resMsg)
 	if err != nil {
 		return nil, &InternalError{
@@ -187,14 +270,5 @@ func (h *Handler) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
 		}
 	}
 
-	if e := rets[1].Interface(); e != nil {
-		if err, ok := e.(lambdarpcError); ok {
-			return nil, err
-		}
-		return nil, &InternalError{
-			Err: e.(error),
-		}
-	}
-
 	return bs, nil
 }
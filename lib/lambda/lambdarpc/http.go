@@ -0,0 +1,137 @@
+package lambdarpc
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// ClientContextHeaderPrefix is the prefix of the request headers which carry the custom fields of the client context,
+// same as lambdagateway.ClientContextHeaderPrefix. The field names are lower-cased, e.g. "X-Client-Context-User-Id" is "user-id".
+const ClientContextHeaderPrefix = "X-Client-Context-"
+
+// SecretHeader is the request header which carries the shared secret of HTTPRequireSecret.
+const SecretHeader = "X-Function-Secret"
+
+type httpHandler struct {
+	handler *Handler
+	secret  string
+}
+
+type HTTPOption func(*httpHandler)
+
+// HTTPRequireSecret rejects the requests whose SecretHeader is not the secret with 401,
+// and the requests without the user ID with 403.
+func HTTPRequireSecret(secret string) HTTPOption {
+	return func(h *httpHandler) {
+		h.secret = secret
+	}
+}
+
+// HTTPHandler returns the net/http handler invoking h with the request body as the payload.
+// The errors are written as the JSON of the Lambda function errors,
+// with 400 for ClientError, 403 for ForbiddenError and 500 for others.
+//
+// The client context headers, e.g. the user ID and the groups, are trusted as they are,
+// so the handler must never be exposed to the clients. Only the gateway should reach it,
+// and it should require the secret shared with the gateway by HTTPRequireSecret.
+func HTTPHandler(h *Handler, opts ...HTTPOption) http.Handler {
+	ret := &httpHandler{handler: h}
+
+	for _, f := range opts {
+		f(ret)
+	}
+
+	return ret
+}
+
+type functionError struct {
+	ErrorType    string `json:"errorType"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+func newRequestId() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	t := reflect.TypeOf(err)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&functionError{
+		ErrorType:    t.Name(),
+		ErrorMessage: err.Error(),
+	})
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, &ClientError{Message: "method not allowed"})
+		return
+	}
+
+	if h.secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(SecretHeader)), []byte(h.secret)) != 1 {
+		writeError(w, http.StatusUnauthorized, &ForbiddenError{Message: "invalid secret"})
+		return
+	}
+	if h.secret != "" && r.Header.Get(ClientContextHeaderPrefix+UserIdField) == "" {
+		writeError(w, http.StatusForbidden, &ForbiddenError{Message: "user-id is not found"})
+		return
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, &ClientError{Message: "read body", Err: err})
+		return
+	}
+
+	custom := map[string]string{}
+	for k := range r.Header {
+		if strings.HasPrefix(k, ClientContextHeaderPrefix) {
+			custom[strings.ToLower(strings.TrimPrefix(k, ClientContextHeaderPrefix))] = r.Header.Get(k)
+		}
+	}
+
+	requestId := r.Header.Get("X-Request-Id")
+	if requestId == "" {
+		requestId = newRequestId()
+	}
+
+	ctx := lambdacontext.NewContext(r.Context(), &lambdacontext.LambdaContext{
+		AwsRequestID: requestId,
+		ClientContext: lambdacontext.ClientContext{
+			Custom: custom,
+		},
+	})
+
+	res, err := h.handler.Invoke(ctx, payload)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch err.(type) {
+		case *ClientError:
+			code = http.StatusBadRequest
+		case *ForbiddenError:
+			code = http.StatusForbidden
+		}
+		writeError(w, code, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(res)
+}
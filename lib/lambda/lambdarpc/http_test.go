@@ -0,0 +1,104 @@
+package lambdarpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	srv := httptest.NewServer(HTTPHandler(NewHandler(&testSvc{})))
+	defer srv.Close()
+
+	post := func(method string) (int, []byte) {
+		req, err := http.NewRequest("POST", srv.URL, strings.NewReader(`{"kifu_id":"kifu-id-test"}`))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		req.Header.Set(ClientContextHeaderPrefix+"function-id", method)
+		req.Header.Set(ClientContextHeaderPrefix+"user-id", "user-id-test")
+
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		defer res.Body.Close()
+
+		var raw json.RawMessage
+		if err := json.NewDecoder(res.Body).Decode(&raw); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		return res.StatusCode, raw
+	}
+
+	code, body := post("ValidMethod")
+	if code != 200 {
+		t.Fatalf("ValidMethod: code=%d body=%s", code, body)
+	}
+	res := &kifupb.GetKifuResponse{}
+	if err := json.Unmarshal(body, res); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if res.KifuId != "kifu-id-test" || res.UserId != "user-id-test" {
+		t.Errorf("unexpected response: %v", res)
+	}
+
+	for method, expected := range map[string]struct {
+		code      int
+		errorType string
+	}{
+		"ErrorMethod":   {500, "InternalError"},
+		"UnknownMethod": {400, "ClientError"},
+	} {
+		code, body := post(method)
+		e := &functionError{}
+		if err := json.Unmarshal(body, e); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		if code != expected.code || e.ErrorType != expected.errorType {
+			t.Errorf("%s: code=%d body=%s", method, code, body)
+		}
+	}
+}
+
+func TestHTTPHandler_Secret(t *testing.T) {
+	srv := httptest.NewServer(HTTPHandler(NewHandler(&testSvc{}), HTTPRequireSecret("secret-test")))
+	defer srv.Close()
+
+	for _, c := range []struct {
+		secret   string
+		userId   string
+		expected int
+	}{
+		{"", "user-id-test", 401},
+		{"wrong", "user-id-test", 401},
+		{"secret-test", "user-id-test", 200},
+		{"secret-test", "", 403},
+	} {
+		req, err := http.NewRequest("POST", srv.URL, strings.NewReader(`{"kifu_id":"kifu-id-test"}`))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		req.Header.Set(ClientContextHeaderPrefix+"function-id", "ValidMethod")
+		if c.userId != "" {
+			req.Header.Set(ClientContextHeaderPrefix+"user-id", c.userId)
+		}
+		if c.secret != "" {
+			req.Header.Set(SecretHeader, c.secret)
+		}
+
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != c.expected {
+			t.Errorf("secret=%q user-id=%q: code=%d expected=%d", c.secret, c.userId, res.StatusCode, c.expected)
+		}
+	}
+}
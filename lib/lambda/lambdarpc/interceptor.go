@@ -0,0 +1,132 @@
+package lambdarpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UnaryInfo is the information of the method call passed to the interceptors.
+type UnaryInfo struct {
+	// Method is the name of the service method, i.e. the function-id.
+	Method string
+}
+
+// UnaryHandler calls the next interceptor or the service method.
+type UnaryHandler func(ctx context.Context, req interface{}) (interface{}, error)
+
+// UnaryInterceptor intercepts the method call.
+// It can inspect or replace the context, the request, the response and the error
+// (a replaced request must have the same type),
+// and must call handler to continue the call.
+type UnaryInterceptor func(ctx context.Context, req interface{}, info *UnaryInfo, handler UnaryHandler) (interface{}, error)
+
+// ChainUnaryInterceptors creates a single interceptor from the interceptors.
+// The first interceptor is the outermost.
+func ChainUnaryInterceptors(interceptors ...UnaryInterceptor) UnaryInterceptor {
+	return func(ctx context.Context, req interface{}, info *UnaryInfo, handler UnaryHandler) (interface{}, error) {
+		h := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			h = bindInterceptor(interceptors[i], info, h)
+		}
+		return h(ctx, req)
+	}
+}
+
+func bindInterceptor(i UnaryInterceptor, info *UnaryInfo, next UnaryHandler) UnaryHandler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return i(ctx, req, info, next)
+	}
+}
+
+// RecoveryInterceptor converts a panic in the method to InternalError.
+func RecoveryInterceptor() UnaryInterceptor {
+	return func(ctx context.Context, req interface{}, info *UnaryInfo, handler UnaryHandler) (res interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				res = nil
+				err = &InternalError{
+					Message: fmt.Sprintf("panic in %s: %v", info.Method, r),
+				}
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// TimeoutInterceptor bounds the context passed to the method by d.
+// The method must observe the context; the call is not abandoned.
+func TimeoutInterceptor(d time.Duration) UnaryInterceptor {
+	return func(ctx context.Context, req interface{}, info *UnaryInfo, handler UnaryHandler) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+
+		res, err := handler(ctx, req)
+		if err != nil && ctx.Err() == context.DeadlineExceeded {
+			return nil, &InternalError{
+				Message: "timeout: " + info.Method,
+				Err:     err,
+			}
+		}
+		return res, err
+	}
+}
+
+// Validator is implemented by the requests which can validate themselves.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationInterceptor calls Validate of the request before the method
+// and returns ClientError if the request is invalid.
+func ValidationInterceptor() UnaryInterceptor {
+	return func(ctx context.Context, req interface{}, info *UnaryInfo, handler UnaryHandler) (interface{}, error) {
+		if v, ok := req.(Validator); ok {
+			if err := v.Validate(); err != nil {
+				if e, ok := err.(lambdarpcError); ok {
+					return nil, e
+				}
+				return nil, &ClientError{
+					Message: "invalid request",
+					Err:     err,
+				}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// CallLog is the record of a method call.
+type CallLog struct {
+	Method       string
+	RequestId    string
+	ApiRequestId string
+	UserId       string
+	Duration     time.Duration
+	Err          error
+}
+
+// LoggingInterceptor calls f after each method call.
+// It can be used for the access log and the metrics.
+func LoggingInterceptor(f func(context.Context, *CallLog)) UnaryInterceptor {
+	return func(ctx context.Context, req interface{}, info *UnaryInfo, handler UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+
+		requestId, _ := ctx.Value(RequestIdField).(string)
+		apiRequestId, _ := ctx.Value(ApiRequestIdField).(string)
+		userId, _ := ctx.Value(UserIdField).(string)
+		f(ctx, &CallLog{
+			Method:       info.Method,
+			RequestId:    requestId,
+			ApiRequestId: apiRequestId,
+			UserId:       userId,
+			Duration:     time.Since(start),
+			Err:          err,
+		})
+
+		return res, err
+	}
+}
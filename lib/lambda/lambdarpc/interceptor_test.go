@@ -0,0 +1,166 @@
+package lambdarpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+type interceptorSvc struct{}
+
+func (*interceptorSvc) Panic(ctx context.Context, req *kifupb.GetKifuRequest) (*kifupb.GetKifuResponse, error) {
+	panic("test panic")
+}
+
+func (*interceptorSvc) Wait(ctx context.Context, req *kifupb.GetKifuRequest) (*kifupb.GetKifuResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (*interceptorSvc) Echo(ctx context.Context, req *kifupb.GetKifuRequest) (*kifupb.GetKifuResponse, error) {
+	return &kifupb.GetKifuResponse{KifuId: req.KifuId}, nil
+}
+
+func invokeMethod(t *testing.T, h *Handler, method, kifuId string) ([]byte, error) {
+	t.Helper()
+
+	payload, err := json.Marshal(&kifupb.GetKifuRequest{KifuId: kifuId})
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		AwsRequestID: "aws-request-id",
+		ClientContext: lambdacontext.ClientContext{
+			Custom: map[string]string{
+				UserIdField:     "user-id-test",
+				FunctionIdField: method,
+			},
+		},
+	})
+
+	return h.Invoke(ctx, payload)
+}
+
+func TestChainUnaryInterceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) UnaryInterceptor {
+		return func(ctx context.Context, req interface{}, info *UnaryInfo, handler UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+":before:"+info.Method)
+			res, err := handler(ctx, req)
+			calls = append(calls, name+":after")
+			return res, err
+		}
+	}
+
+	h := NewHandler(&interceptorSvc{},
+		WithUnaryInterceptors(record("a"), record("b")),
+		WithUnaryInterceptors(record("c")),
+	)
+	bs, err := invokeMethod(t, h, "Echo", "kifu-1")
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+
+	res := &kifupb.GetKifuResponse{}
+	if err := json.Unmarshal(bs, res); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if res.KifuId != "kifu-1" {
+		t.Errorf("KifuId: %q", res.KifuId)
+	}
+
+	expected := []string{
+		"a:before:Echo", "b:before:Echo", "c:before:Echo",
+		"c:after", "b:after", "a:after",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("calls: expected=%v actual=%v", expected, calls)
+	}
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	h := NewHandler(&interceptorSvc{}, WithUnaryInterceptors(RecoveryInterceptor()))
+
+	_, err := invokeMethod(t, h, "Panic", "kifu-1")
+	if _, ok := err.(*InternalError); !ok {
+		t.Errorf("expected InternalError: %v", err)
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	h := NewHandler(&interceptorSvc{}, WithUnaryInterceptors(TimeoutInterceptor(10*time.Millisecond)))
+
+	_, err := invokeMethod(t, h, "Wait", "kifu-1")
+	e, ok := err.(*InternalError)
+	if !ok {
+		t.Fatalf("expected InternalError: %v", err)
+	}
+	if !errors.Is(e.Err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded: %v", e.Err)
+	}
+}
+
+type validateRequest struct {
+	kifupb.GetKifuRequest
+}
+
+func (r *validateRequest) Validate() error {
+	if r.KifuId == "" {
+		return errors.New("kifu_id is empty")
+	}
+	return nil
+}
+
+func TestValidationInterceptor(t *testing.T) {
+	i := ValidationInterceptor()
+	info := &UnaryInfo{Method: "Echo"}
+	next := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	if _, err := i(context.Background(), &validateRequest{}, info, next); err == nil {
+		t.Errorf("expected error")
+	} else if _, ok := err.(*ClientError); !ok {
+		t.Errorf("expected ClientError: %v", err)
+	}
+
+	req := &validateRequest{}
+	req.KifuId = "kifu-1"
+	if _, err := i(context.Background(), req, info, next); err != nil {
+		t.Errorf("valid request: %v", err)
+	}
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	var logs []*CallLog
+	h := NewHandler(&interceptorSvc{}, WithUnaryInterceptors(
+		LoggingInterceptor(func(ctx context.Context, l *CallLog) {
+			logs = append(logs, l)
+		}),
+		RecoveryInterceptor(),
+	))
+
+	if _, err := invokeMethod(t, h, "Echo", "kifu-1"); err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if _, err := invokeMethod(t, h, "Panic", "kifu-1"); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if len(logs) != 2 {
+		t.Fatalf("logs: %d", len(logs))
+	}
+	if l := logs[0]; l.Method != "Echo" || l.RequestId != "aws-request-id" || l.UserId != "user-id-test" || l.Err != nil {
+		t.Errorf("log[0]: %+v", l)
+	}
+	if l := logs[1]; l.Method != "Panic" || l.Err == nil {
+		t.Errorf("log[1]: %+v", l)
+	}
+}
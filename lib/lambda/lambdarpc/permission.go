@@ -0,0 +1,34 @@
+package lambdarpc
+
+import (
+	"context"
+)
+
+// Permission is the requirement to call a method.
+// The caller needs to be in any of the groups or to have any of the scopes.
+type Permission struct {
+	Groups []string
+	Scopes []string
+}
+
+// PermissionProvider is implemented by the services which restrict the methods.
+// Permissions returns the permissions by the method names. The methods not in the map are allowed to everyone.
+type PermissionProvider interface {
+	Permissions() map[string]*Permission
+}
+
+func containsAny(xs, ys []string) bool {
+	for _, x := range xs {
+		for _, y := range ys {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Allowed reports whether the caller of ctx has the permission.
+func (p *Permission) Allowed(ctx context.Context) bool {
+	return containsAny(p.Groups, GetGroups(ctx)) || containsAny(p.Scopes, GetScopes(ctx))
+}
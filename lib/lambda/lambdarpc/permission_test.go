@@ -0,0 +1,116 @@
+package lambdarpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	kifupb "github.com/yunomu/kansousen/proto/kifu"
+)
+
+type adminSvc struct {
+	testSvc
+	permissions map[string]*Permission
+}
+
+func (s *adminSvc) Permissions() map[string]*Permission {
+	return s.permissions
+}
+
+func TestHandler_Permission(t *testing.T) {
+	h := NewHandler(&adminSvc{
+		permissions: map[string]*Permission{
+			"ValidMethod": {Groups: []string{"admin"}, Scopes: []string{"kifu/admin"}},
+		},
+	})
+
+	payload, err := json.Marshal(&kifupb.GetKifuRequest{KifuId: "kifu-id-test"})
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	for _, c := range []struct {
+		method string
+		groups string
+		scopes string
+		err    bool
+	}{
+		{"ValidMethod", "admin", "", false},
+		{"ValidMethod", "user admin", "", false},
+		{"ValidMethod", "", "openid kifu/admin", false},
+		{"ValidMethod", "user", "openid", true},
+		{"ValidMethod", "", "", true},
+		{"ErrorMethod", "", "", false},
+	} {
+		custom := map[string]string{
+			UserIdField:     "user-id-test",
+			FunctionIdField: c.method,
+		}
+		if c.groups != "" {
+			custom[GroupsField] = c.groups
+		}
+		if c.scopes != "" {
+			custom[ScopesField] = c.scopes
+		}
+		ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+			ClientContext: lambdacontext.ClientContext{Custom: custom},
+		})
+
+		_, err := h.Invoke(ctx, payload)
+		_, forbidden := err.(*ForbiddenError)
+		if forbidden != c.err {
+			t.Errorf("%s groups=%q scopes=%q: %v", c.method, c.groups, c.scopes, err)
+		}
+	}
+}
+
+func TestHandler_PermissionOfUnknownMethod(t *testing.T) {
+	h := NewHandler(&adminSvc{
+		permissions: map[string]*Permission{
+			"UnknownMethod": {Groups: []string{"admin"}},
+		},
+	})
+	if err := h.Init(); err == nil {
+		t.Errorf("expected InvalidMethodError")
+	}
+}
+
+func TestHandler_RejectedCallsAreLogged(t *testing.T) {
+	logs := map[string]error{}
+	h := NewHandler(&adminSvc{
+		permissions: map[string]*Permission{
+			"ValidMethod": {Groups: []string{"admin"}},
+		},
+	}, WithUnaryInterceptors(LoggingInterceptor(func(ctx context.Context, l *CallLog) {
+		logs[l.Method] = l.Err
+	})))
+
+	for method, payload := range map[string]string{
+		"ValidMethod":   `{"kifu_id":"kifu-id-test"}`,
+		"UnknownMethod": `{}`,
+		"ErrorMethod":   `[]`,
+	} {
+		ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+			ClientContext: lambdacontext.ClientContext{Custom: map[string]string{
+				UserIdField:     "user-id-test",
+				FunctionIdField: method,
+			}},
+		})
+		if _, err := h.Invoke(ctx, []byte(payload)); err == nil {
+			t.Errorf("%s: expected error", method)
+		}
+	}
+
+	if _, ok := logs["ValidMethod"].(*ForbiddenError); !ok {
+		t.Errorf("ValidMethod: %v", logs["ValidMethod"])
+	}
+	if _, ok := logs["UnknownMethod"].(*ClientError); !ok {
+		t.Errorf("UnknownMethod: %v", logs["UnknownMethod"])
+	}
+	// the undecodable payload is rejected before ErrorMethod is called.
+	if e, ok := logs["ErrorMethod"].(*ClientError); !ok || e.Message != "invalid request" {
+		t.Errorf("ErrorMethod: %v", logs["ErrorMethod"])
+	}
+}
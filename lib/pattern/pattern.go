@@ -0,0 +1,177 @@
+// Package pattern matches partial boards against positions.
+package pattern
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yunomu/kansousen/lib/position"
+)
+
+// Square requires Piece on (X, Y). X is the file and Y is the rank, both 1-9.
+// Piece is a SFEN piece such as "K", "+b", or "" for an empty square.
+type Square struct {
+	X     int32  `json:"x"`
+	Y     int32  `json:"y"`
+	Piece string `json:"piece"`
+}
+
+type Pattern struct {
+	Squares []*Square `json:"squares,omitempty"`
+	// Hands is the minimum number of pieces in hand, keyed by SFEN piece such as "P", "b".
+	Hands map[string]int `json:"hands,omitempty"`
+}
+
+const (
+	pieceKinds  = "KRBGSNLP"
+	promotables = "RBSNLP"
+)
+
+func validPiece(s string, allowPromoted bool) bool {
+	promoted := strings.HasPrefix(s, "+")
+	if promoted {
+		if !allowPromoted {
+			return false
+		}
+		s = s[1:]
+	}
+	if len(s) != 1 {
+		return false
+	}
+
+	u := strings.ToUpper(s)
+	if promoted {
+		return strings.Contains(promotables, u)
+	}
+	return strings.Contains(pieceKinds, u)
+}
+
+func (p *Pattern) Validate() error {
+	if len(p.Squares) == 0 && len(p.Hands) == 0 {
+		return fmt.Errorf("empty pattern")
+	}
+
+	for _, sq := range p.Squares {
+		if sq.X < 1 || 9 < sq.X || sq.Y < 1 || 9 < sq.Y {
+			return fmt.Errorf("invalid square: (%d, %d)", sq.X, sq.Y)
+		}
+		if sq.Piece != "" && !validPiece(sq.Piece, true) {
+			return fmt.Errorf("invalid piece: %q", sq.Piece)
+		}
+	}
+	for piece, n := range p.Hands {
+		if !validPiece(piece, false) || strings.EqualFold(piece, "K") || n < 0 {
+			return fmt.Errorf("invalid hand: %q", piece)
+		}
+	}
+
+	return nil
+}
+
+func pieceAt(pos *position.Position, x, y int32) string {
+	return pos.Board[y-1][9-x]
+}
+
+// Match reports whether pos satisfies all requirements of p.
+func (p *Pattern) Match(pos *position.Position) bool {
+	for _, sq := range p.Squares {
+		if pieceAt(pos, sq.X, sq.Y) != sq.Piece {
+			return false
+		}
+	}
+	for piece, n := range p.Hands {
+		if pos.Hands[piece] < n {
+			return false
+		}
+	}
+	return true
+}
+
+func swapCase(s string) string {
+	if u := strings.ToUpper(s); u != s {
+		return u
+	}
+	return strings.ToLower(s)
+}
+
+// ColorFlipped returns the pattern for the other player.
+func (p *Pattern) ColorFlipped() *Pattern {
+	ret := &Pattern{
+		Hands: make(map[string]int),
+	}
+	for _, sq := range p.Squares {
+		ret.Squares = append(ret.Squares, &Square{
+			X:     10 - sq.X,
+			Y:     10 - sq.Y,
+			Piece: swapCase(sq.Piece),
+		})
+	}
+	for piece, n := range p.Hands {
+		ret.Hands[swapCase(piece)] = n
+	}
+	return ret
+}
+
+// featureBit returns the bit of a non-pawn piece in the quarter of the board.
+// Promoted pieces share the bit with the unpromoted ones.
+func featureBit(piece string, x, y int32) (uint64, bool) {
+	piece = strings.TrimPrefix(piece, "+")
+	if piece == "" {
+		return 0, false
+	}
+
+	kind := strings.Index(pieceKinds, strings.ToUpper(piece))
+	if kind < 0 || pieceKinds[kind] == 'P' {
+		return 0, false
+	}
+
+	var color int
+	if strings.ToUpper(piece) != piece {
+		color = 1
+	}
+
+	var zone int
+	if x < 5 {
+		zone |= 1
+	}
+	if y < 5 {
+		zone |= 2
+	}
+
+	return 1 << uint((color*7+kind)*4+zone), true
+}
+
+// Features returns the bitset of pieces and their areas in pos.
+// A position can match a pattern only if it has all features of the pattern.
+func Features(pos *position.Position) uint64 {
+	var ret uint64
+	for r, row := range pos.Board {
+		for f, piece := range row {
+			if bit, ok := featureBit(piece, int32(9-f), int32(r+1)); ok {
+				ret |= bit
+			}
+		}
+	}
+	return ret
+}
+
+// Features returns the bitset which matching positions must have.
+func (p *Pattern) Features() uint64 {
+	var ret uint64
+	for _, sq := range p.Squares {
+		if bit, ok := featureBit(sq.Piece, sq.X, sq.Y); ok {
+			ret |= bit
+		}
+	}
+	return ret
+}
+
+// MayMatch reports whether positions with the features can match p.
+// 0 means that the features are unknown.
+func (p *Pattern) MayMatch(features uint64) bool {
+	if features == 0 {
+		return true
+	}
+	f := p.Features()
+	return features&f == f
+}
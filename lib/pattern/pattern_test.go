@@ -0,0 +1,96 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/yunomu/kansousen/lib/position"
+)
+
+const (
+	startpos = "lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1"
+	// after 7g7f 3c3d 8h2b+ 3a2b
+	kakugawari = "lnsgkg1nl/1r5s1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/7R1/LNSGKGSNL b Bb 1"
+)
+
+func mustParse(t *testing.T, s string) *position.Position {
+	p, err := position.Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%v): %v", s, err)
+	}
+	return p
+}
+
+func TestMatch(t *testing.T) {
+	start := mustParse(t, startpos)
+	kaku := mustParse(t, kakugawari)
+
+	pat := &Pattern{
+		Squares: []*Square{
+			{X: 5, Y: 9, Piece: "K"},
+			{X: 8, Y: 8, Piece: ""},
+		},
+		Hands: map[string]int{"B": 1},
+	}
+	if err := pat.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	if pat.Match(start) {
+		t.Errorf("startpos matched")
+	}
+	if !pat.Match(kaku) {
+		t.Errorf("kakugawari not matched")
+	}
+	if !pat.MayMatch(Features(kaku)) {
+		t.Errorf("features of kakugawari: %x pattern: %x", Features(kaku), pat.Features())
+	}
+
+	flipped := pat.ColorFlipped()
+	if flipped.Squares[0].X != 5 || flipped.Squares[0].Y != 1 || flipped.Squares[0].Piece != "k" {
+		t.Errorf("ColorFlipped: %v", flipped.Squares[0])
+	}
+	// 2b is occupied by the silver
+	if flipped.Match(kaku) {
+		t.Errorf("flipped pattern matched")
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	start := mustParse(t, startpos)
+
+	pat := &Pattern{
+		Squares: []*Square{
+			{X: 2, Y: 8, Piece: "R"},
+		},
+	}
+	if !pat.MayMatch(Features(start)) {
+		t.Errorf("R on 2h is not in features")
+	}
+
+	pat = &Pattern{
+		Squares: []*Square{
+			{X: 2, Y: 2, Piece: "R"},
+		},
+	}
+	if pat.MayMatch(Features(start)) {
+		t.Errorf("R on 2b is in features")
+	}
+	if !pat.MayMatch(0) {
+		t.Errorf("unknown features must be candidates")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, pat := range []*Pattern{
+		{},
+		{Squares: []*Square{{X: 0, Y: 1, Piece: "K"}}},
+		{Squares: []*Square{{X: 1, Y: 1, Piece: "+K"}}},
+		{Squares: []*Square{{X: 1, Y: 1, Piece: "X"}}},
+		{Hands: map[string]int{"+P": 1}},
+		{Hands: map[string]int{"K": 1}},
+	} {
+		if err := pat.Validate(); err == nil {
+			t.Errorf("expected error: %v", pat)
+		}
+	}
+}
@@ -35,24 +35,22 @@ func KifPosToPos(p *ptypes.Pos) *document.Pos {
 	}
 }
 
-func posXFromInt(x int32) sfen.PosX {
-	x = 9 - x
-	if x < 0 {
-		panic("invalid x")
+func posFromPos(p *ptypes.Pos) (sfen.PosX, sfen.PosY, error) {
+	if p == nil || p.X < 1 || p.X > 9 || p.Y < 1 || p.Y > 9 {
+		return 0, 0, fmt.Errorf("invalid position: %v", p)
 	}
-	return sfen.PosXs[x]
-}
-
-func posYFromInt(y int32) sfen.PosY {
-	if y <= 0 {
-		panic("invalid y")
-	}
-	y -= 1
-	return sfen.PosYs[y]
+	return sfen.PosXs[9-p.X], sfen.PosYs[p.Y-1], nil
 }
 
 func KifToSteps(userId, kifuId string, k *ptypes.Kif) ([]*document.Step, error) {
-	p := sfen.NewSurfaceStartpos()
+	start, err := StartPosition(k)
+	if err != nil {
+		return nil, err
+	}
+	p, err := sfen.NewSurface(start)
+	if err != nil {
+		return nil, err
+	}
 	var steps []*document.Step
 
 	var buf strings.Builder
@@ -82,7 +80,11 @@ func KifToSteps(userId, kifuId string, k *ptypes.Kif) ([]*document.Step, error)
 
 		var captured document.Piece_Id
 		if step.FinishedStatus == ptypes.FinishedStatus_NOT_FINISHED {
-			if piece := p.GetPiece(posXFromInt(step.Dst.X), posYFromInt(step.Dst.Y)); piece != nil {
+			x, y, err := posFromPos(step.Dst)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: %w", step.GetSeq(), err)
+			}
+			if piece := p.GetPiece(x, y); piece != nil {
 				switch piece.Type {
 				case sfen.Piece_NULL:
 					captured = document.Piece_NULL
@@ -101,7 +103,7 @@ func KifToSteps(userId, kifuId string, k *ptypes.Kif) ([]*document.Step, error)
 				case sfen.Piece_FU:
 					captured = document.Piece_FU
 				default:
-					panic("Unknown Piece type")
+					return nil, fmt.Errorf("step %d: unknown piece type: %v", step.GetSeq(), piece.Type)
 				}
 			}
 		}
@@ -165,6 +167,55 @@ func HandicapString(h document.Handicap_Id) string {
 	panic("unknown handicap")
 }
 
+const handicapRanks = "/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL w - 1"
+
+// handicapPositions are the initial SFEN positions in the order of handicapString.
+// The white (上手) gives the handicap and moves first.
+var handicapPositions = []string{
+	"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1",
+	"lnsgkgsn1/1r5b1" + handicapRanks,
+	"1nsgkgsnl/1r5b1" + handicapRanks,
+	"lnsgkgsnl/1r7" + handicapRanks,
+	"lnsgkgsnl/7b1" + handicapRanks,
+	"lnsgkgsn1/7b1" + handicapRanks,
+	"lnsgkgsnl/9" + handicapRanks,
+	"lnsgkgsn1/9" + handicapRanks,
+	"1nsgkgsn1/9" + handicapRanks,
+	"2sgkgsn1/9" + handicapRanks,
+	"1nsgkgs2/9" + handicapRanks,
+	"2sgkgs2/9" + handicapRanks,
+	"3gkg3/9" + handicapRanks,
+	"4k4/9" + handicapRanks,
+}
+
+// handicapHeaders are the header names holding the handicap.
+// "手割合" is a common misspelling of "手合割" and accepted as well.
+var handicapHeaders = []string{"手合割", "手割合"}
+
+func isHandicapHeader(name string) bool {
+	for _, h := range handicapHeaders {
+		if name == h {
+			return true
+		}
+	}
+	return false
+}
+
+// StartPosition returns the SFEN position where the game of k starts.
+func StartPosition(k *ptypes.Kif) (string, error) {
+	h := document.Handicap_NONE
+	for _, header := range k.GetHeaders() {
+		if isHandicapHeader(header.GetName()) {
+			h = ParseHandicap(header.GetValue())
+		}
+	}
+
+	if i := int(h); i < len(handicapPositions) {
+		return handicapPositions[i], nil
+	}
+	return "", fmt.Errorf("unknown handicap: %v", h)
+}
+
 func ParseHandicap(s string) document.Handicap_Id {
 	if s == "" {
 		return document.Handicap_NONE
@@ -289,8 +340,12 @@ func ReadHeader(hs []*ptypes.Header, loc *time.Location, out *document.Kifu) err
 		{
 			field: "",
 			f: func(field, v string) error {
-				out.Handicap = ParseHandicap(header["手割合"])
-				used["手割合"] = struct{}{}
+				for _, name := range handicapHeaders {
+					if v, ok := header[name]; ok {
+						out.Handicap = ParseHandicap(v)
+						used[name] = struct{}{}
+					}
+				}
 				return nil
 			},
 		},
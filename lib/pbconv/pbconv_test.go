@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"time"
+
+	"github.com/yunomu/kif/ptypes"
+
+	"github.com/yunomu/kansousen/proto/document"
 )
 
 func TestParseDateTime(t *testing.T) {
@@ -39,3 +43,46 @@ func TestParseDateTimeWithWeek(t *testing.T) {
 		t.Errorf("expected=%d actual=%d", tm, ts)
 	}
 }
+
+func TestKifToSteps_Same(t *testing.T) {
+	k := &ptypes.Kif{
+		Steps: []*ptypes.Step{
+			{Seq: 1, Src: &ptypes.Pos{X: 7, Y: 7}, Dst: &ptypes.Pos{X: 7, Y: 6}, Piece: ptypes.Piece_FU},
+			{Seq: 2, Src: &ptypes.Pos{X: 3, Y: 3}, Dst: &ptypes.Pos{X: 3, Y: 4}, Piece: ptypes.Piece_FU},
+			{Seq: 3, Src: &ptypes.Pos{X: 8, Y: 8}, Dst: &ptypes.Pos{X: 2, Y: 2}, Piece: ptypes.Piece_KAKU, Modifier: ptypes.Modifier_PROMOTE},
+			// 同銀(31); the parser fills in the destination of the previous move.
+			{Seq: 4, Src: &ptypes.Pos{X: 3, Y: 1}, Dst: &ptypes.Pos{X: 2, Y: 2}, Piece: ptypes.Piece_GIN},
+		},
+	}
+
+	steps, err := KifToSteps("user", "kifu", k)
+	if err != nil {
+		t.Fatalf("KifToSteps: %v", err)
+	}
+	s := steps[4]
+	if s.GetSfen() != "3a2b" || s.GetDst().GetX() != 2 || s.GetDst().GetY() != 2 {
+		t.Errorf("unexpected step: %v", s)
+	}
+	if c := s.GetCaptured(); c != document.Piece_KAKU {
+		t.Errorf("captured: %v", c)
+	}
+}
+
+func TestKifToSteps_Handicap(t *testing.T) {
+	k := &ptypes.Kif{
+		Headers: []*ptypes.Header{
+			{Name: "手合割", Value: "二枚落ち"},
+		},
+		Steps: []*ptypes.Step{
+			{Seq: 1, Src: &ptypes.Pos{X: 5, Y: 3}, Dst: &ptypes.Pos{X: 5, Y: 4}, Piece: ptypes.Piece_FU},
+		},
+	}
+
+	steps, err := KifToSteps("user", "kifu", k)
+	if err != nil {
+		t.Fatalf("KifToSteps: %v", err)
+	}
+	if p := steps[1].GetPosition(); p != "lnsgkgsnl/9/pppp1pppp/4p4/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1" {
+		t.Errorf("position: %v", p)
+	}
+}
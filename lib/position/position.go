@@ -0,0 +1,305 @@
+// Package position provides normalization of SFEN positions.
+package position
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidPosition = errors.New("invalid position")
+
+type Option int
+
+const (
+	// Mirror identifies left-right mirrored positions.
+	Mirror Option = 1 << iota
+	// ColorFlip identifies positions whose colors are swapped, normalizing the side to move.
+	ColorFlip
+)
+
+type Position struct {
+	// Board[0] is rank a. Board[r][0] is file 9.
+	Board [9][9]string
+	Side  string
+	Hands map[string]int
+}
+
+// maxPieces is the number of the pieces of each kind in a game.
+var maxPieces = map[rune]int{
+	'K': 2,
+	'R': 2,
+	'B': 2,
+	'G': 4,
+	'S': 4,
+	'N': 4,
+	'L': 4,
+	'P': 18,
+}
+
+func promotable(c rune) bool {
+	return strings.ContainsRune("RBSNLP", unicode.ToUpper(c))
+}
+
+// Parse parses SFEN position "{board} {side} {hands} [{step}]". The step is ignored.
+// The pieces are validated, e.g. the count of each kind, but the legality of the position is not.
+func Parse(s string) (*Position, error) {
+	fs := strings.Fields(s)
+	if len(fs) > 0 && fs[0] == "sfen" {
+		fs = fs[1:]
+	}
+	if len(fs) < 3 || len(fs) > 4 {
+		return nil, ErrInvalidPosition
+	}
+	if len(fs) == 4 {
+		if n, err := strconv.Atoi(fs[3]); err != nil || n < 1 {
+			return nil, ErrInvalidPosition
+		}
+	}
+
+	p := &Position{
+		Side:  fs[1],
+		Hands: make(map[string]int),
+	}
+	if p.Side != "b" && p.Side != "w" {
+		return nil, ErrInvalidPosition
+	}
+
+	counts := make(map[rune]int)
+
+	rows := strings.Split(fs[0], "/")
+	if len(rows) != 9 {
+		return nil, ErrInvalidPosition
+	}
+	for r, row := range rows {
+		f := 0
+		promoted := false
+		for _, c := range row {
+			switch {
+			case c == '+':
+				if promoted {
+					return nil, ErrInvalidPosition
+				}
+				promoted = true
+				continue
+			case '1' <= c && c <= '9':
+				if promoted {
+					return nil, ErrInvalidPosition
+				}
+				f += int(c - '0')
+			case maxPieces[unicode.ToUpper(c)] > 0:
+				if f >= 9 || (promoted && !promotable(c)) {
+					return nil, ErrInvalidPosition
+				}
+				piece := string(c)
+				if promoted {
+					piece = "+" + piece
+				}
+				p.Board[r][f] = piece
+				counts[unicode.ToUpper(c)]++
+				f++
+			default:
+				return nil, ErrInvalidPosition
+			}
+			promoted = false
+		}
+		if f != 9 || promoted {
+			return nil, ErrInvalidPosition
+		}
+	}
+
+	if fs[2] != "-" {
+		n := 0
+		digits := false
+		for _, c := range fs[2] {
+			switch {
+			case '0' <= c && c <= '9':
+				n = n*10 + int(c-'0')
+				digits = true
+			case unicode.ToUpper(c) != 'K' && maxPieces[unicode.ToUpper(c)] > 0:
+				if !digits {
+					n = 1
+				} else if n == 0 {
+					return nil, ErrInvalidPosition
+				}
+				p.Hands[string(c)] += n
+				counts[unicode.ToUpper(c)] += n
+				n = 0
+				digits = false
+			default:
+				return nil, ErrInvalidPosition
+			}
+		}
+		if digits {
+			return nil, ErrInvalidPosition
+		}
+	}
+
+	for c, n := range counts {
+		if n > maxPieces[c] {
+			return nil, ErrInvalidPosition
+		}
+	}
+
+	return p, nil
+}
+
+var handOrder = []string{"R", "B", "G", "S", "N", "L", "P", "r", "b", "g", "s", "n", "l", "p"}
+
+// String returns SFEN position without the step.
+func (p *Position) String() string {
+	var b strings.Builder
+	for r, row := range p.Board {
+		if r > 0 {
+			b.WriteByte('/')
+		}
+		empty := 0
+		for _, piece := range row {
+			if piece == "" {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				b.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			b.WriteString(piece)
+		}
+		if empty > 0 {
+			b.WriteString(strconv.Itoa(empty))
+		}
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(p.Side)
+	b.WriteByte(' ')
+
+	hands := false
+	for _, piece := range handOrder {
+		n := p.Hands[piece]
+		if n == 0 {
+			continue
+		}
+		if n > 1 {
+			b.WriteString(strconv.Itoa(n))
+		}
+		b.WriteString(piece)
+		hands = true
+	}
+	if !hands {
+		b.WriteByte('-')
+	}
+
+	return b.String()
+}
+
+// Mirrored returns the left-right mirrored position.
+func (p *Position) Mirrored() *Position {
+	ret := &Position{
+		Side:  p.Side,
+		Hands: p.Hands,
+	}
+	for r, row := range p.Board {
+		for f, piece := range row {
+			ret.Board[r][8-f] = piece
+		}
+	}
+	return ret
+}
+
+func swapCase(s string) string {
+	return strings.Map(func(c rune) rune {
+		switch {
+		case unicode.IsUpper(c):
+			return unicode.ToLower(c)
+		case unicode.IsLower(c):
+			return unicode.ToUpper(c)
+		default:
+			return c
+		}
+	}, s)
+}
+
+// ColorFlipped returns the position seen from the other player.
+// The board is rotated, and the colors of pieces and the side to move are swapped.
+func (p *Position) ColorFlipped() *Position {
+	ret := &Position{
+		Side:  "b",
+		Hands: make(map[string]int),
+	}
+	if p.Side == "b" {
+		ret.Side = "w"
+	}
+	for r, row := range p.Board {
+		for f, piece := range row {
+			ret.Board[8-r][8-f] = swapCase(piece)
+		}
+	}
+	for piece, n := range p.Hands {
+		ret.Hands[swapCase(piece)] = n
+	}
+	return ret
+}
+
+// Canonical returns the normalized key of the position.
+// mirrored and flipped report the transformations applied to pos to get the key.
+func Canonical(pos string, opt Option) (key string, mirrored, flipped bool, err error) {
+	p, err := Parse(pos)
+	if err != nil {
+		return "", false, false, err
+	}
+
+	if opt&ColorFlip != 0 && p.Side == "w" {
+		p = p.ColorFlipped()
+		flipped = true
+	}
+
+	key = p.String()
+	if opt&Mirror != 0 {
+		if m := p.Mirrored().String(); m < key {
+			key = m
+			mirrored = true
+		}
+	}
+
+	return key, mirrored, flipped, nil
+}
+
+// Relation returns the transformations which convert pos to target.
+// ok is false if pos can not be converted to target with the options.
+func Relation(pos, target string, opt Option) (mirrored, flipped, ok bool) {
+	p, err := Parse(pos)
+	if err != nil {
+		return false, false, false
+	}
+	t, err := Parse(target)
+	if err != nil {
+		return false, false, false
+	}
+	ts := t.String()
+
+	for _, f := range []bool{false, true} {
+		if f && opt&ColorFlip == 0 {
+			continue
+		}
+		q := p
+		if f {
+			q = q.ColorFlipped()
+		}
+		for _, m := range []bool{false, true} {
+			if m && opt&Mirror == 0 {
+				continue
+			}
+			r := q
+			if m {
+				r = r.Mirrored()
+			}
+			if r.String() == ts {
+				return m, f, true
+			}
+		}
+	}
+
+	return false, false, false
+}
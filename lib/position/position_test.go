@@ -0,0 +1,95 @@
+package position
+
+import (
+	"testing"
+)
+
+const (
+	// after 7g7f 3c3d 8h2b+
+	pos1 = "lnsgkgsnl/1r5+B1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/7R1/LNSGKGSNL w B 1"
+	// left-right mirror of pos1
+	pos1Mirrored = "lnsgkgsnl/1+B5r1/pp1pppppp/2p6/9/6P2/PPPPPP1PP/1R7/LNSGKGSNL w B 1"
+	// colors of pos1 are swapped
+	pos1Flipped = "lnsgkgsnl/1r7/pppppp1pp/6p2/9/2P6/PP1PPPPPP/1+b5R1/LNSGKGSNL b b 1"
+)
+
+func TestParseString(t *testing.T) {
+	p, err := Parse("sfen " + pos1)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	expected := "lnsgkgsnl/1r5+B1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/7R1/LNSGKGSNL w B"
+	if s := p.String(); s != expected {
+		t.Errorf("expected=%v actual=%v", expected, s)
+	}
+
+	for _, s := range []string{
+		"lnsgkgsnl/9 b - 1",
+		// unknown piece
+		"lnsgkgsnx/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1",
+		// promoted gold
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNS+GKGSNL b - 1",
+		// dangling promotion
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSN+ b - 1",
+		// king in hand
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b K 1",
+		// too many pawns
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b P 1",
+		// count without piece
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPP1/1B5R1/LNSGKGSNL b 1 1",
+		// invalid move number
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - x",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("expected error: %v", s)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	exact, _, _, err := Canonical(pos1, 0)
+	if err != nil {
+		t.Fatalf("Canonical: %v", err)
+	}
+	if m, _, _, _ := Canonical(pos1Mirrored, 0); m == exact {
+		t.Errorf("mirrored position matched without Mirror")
+	}
+
+	key, _, _, err := Canonical(pos1, Mirror|ColorFlip)
+	if err != nil {
+		t.Fatalf("Canonical: %v", err)
+	}
+	for _, pos := range []string{pos1Mirrored, pos1Flipped} {
+		k, _, _, err := Canonical(pos, Mirror|ColorFlip)
+		if err != nil {
+			t.Fatalf("Canonical(%v): %v", pos, err)
+		}
+		if k != key {
+			t.Errorf("Canonical(%v): expected=%v actual=%v", pos, key, k)
+		}
+	}
+}
+
+func TestRelation(t *testing.T) {
+	cases := []struct {
+		pos      string
+		opt      Option
+		mirrored bool
+		flipped  bool
+		ok       bool
+	}{
+		{pos1, 0, false, false, true},
+		{pos1Mirrored, 0, false, false, false},
+		{pos1Mirrored, Mirror, true, false, true},
+		{pos1Flipped, Mirror, false, false, false},
+		{pos1Flipped, ColorFlip, false, true, true},
+	}
+	for _, c := range cases {
+		m, f, ok := Relation(c.pos, pos1, c.opt)
+		if m != c.mirrored || f != c.flipped || ok != c.ok {
+			t.Errorf("Relation(%v, %v): expected=(%v,%v,%v) actual=(%v,%v,%v)",
+				c.pos, c.opt, c.mirrored, c.flipped, c.ok, m, f, ok)
+		}
+	}
+}
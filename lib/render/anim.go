@@ -0,0 +1,127 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+var pieceLetters = map[documentpb.Piece_Id]string{
+	documentpb.Piece_GYOKU:     "K",
+	documentpb.Piece_HISHA:     "R",
+	documentpb.Piece_RYU:       "+R",
+	documentpb.Piece_KAKU:      "B",
+	documentpb.Piece_UMA:       "+B",
+	documentpb.Piece_KIN:       "G",
+	documentpb.Piece_GIN:       "S",
+	documentpb.Piece_NARI_GIN:  "+S",
+	documentpb.Piece_KEI:       "N",
+	documentpb.Piece_NARI_KEI:  "+N",
+	documentpb.Piece_KYOU:      "L",
+	documentpb.Piece_NARI_KYOU: "+L",
+	documentpb.Piece_FU:        "P",
+	documentpb.Piece_TO:        "+P",
+}
+
+// MoveText returns the move of the step such as "12. P7g7f", "13. B8h2b+", "14. P*5e".
+func MoveText(step *documentpb.Step) string {
+	seq := step.GetSeq()
+	switch {
+	case seq == 0:
+		return "0. start"
+	case step.GetFinishedStatus() != documentpb.FinishedStatus_NOT_FINISHED:
+		return fmt.Sprintf("%d. %v", seq, step.GetFinishedStatus())
+	case step.GetDrop():
+		return fmt.Sprintf("%d. %s", seq, step.GetSfen())
+	}
+	return fmt.Sprintf("%d. %s%s", seq, pieceLetters[step.GetPiece()], step.GetSfen())
+}
+
+func inRange(step *documentpb.Step, start, end int32) bool {
+	return step.GetSeq() >= start && (end <= 0 || step.GetSeq() <= end)
+}
+
+// FrameCount returns the number of the frames which Frames returns.
+func FrameCount(steps []*documentpb.Step, start, end int32) int {
+	n := 0
+	for _, step := range steps {
+		if inRange(step, start, end) {
+			n++
+		}
+	}
+	return n
+}
+
+// Frames returns the renderers of the steps whose seq is in [start, end].
+// end <= 0 means the last step. The notes of the steps are drawn in the caption if notes is true.
+func Frames(kifu *documentpb.Kifu, steps []*documentpb.Step, start, end int32, notes bool, opts ...Option) ([]*Renderer, error) {
+	lines := 1
+	if notes {
+		lines = maxCaptionLines
+	}
+
+	var ret []*Renderer
+	for _, step := range steps {
+		if !inRange(step, start, end) {
+			continue
+		}
+
+		caption := []string{MoveText(step)}
+		if notes {
+			caption = append(caption, step.GetNotes()...)
+		}
+
+		var fopts []Option
+		fopts = append(fopts, StepOptions(kifu, step)...)
+		fopts = append(fopts, opts...)
+		fopts = append(fopts, SetCaption(caption...), ReserveCaptionLines(lines))
+
+		r, err := New(step.GetPosition(), fopts...)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+
+	return ret, nil
+}
+
+func gifPalette() color.Palette {
+	return append(color.Palette{
+		colorBackground,
+		colorBoard,
+		colorLastMove,
+		colorLine,
+		colorPiece,
+		colorText,
+		colorPromoted,
+	}, palette.WebSafe...)
+}
+
+// WriteGIF writes the frames as an animated GIF. delay is the time of a frame in 1/100 seconds.
+// The last frame is shown three times longer.
+func WriteGIF(w io.Writer, frames []*Renderer, delay int) error {
+	pal := gifPalette()
+
+	anim := &gif.GIF{}
+	for i, f := range frames {
+		img := f.Image()
+		p := image.NewPaletted(img.Bounds(), pal)
+		draw.Draw(p, p.Rect, img, img.Bounds().Min, draw.Src)
+
+		d := delay
+		if i == len(frames)-1 {
+			d *= 3
+		}
+		anim.Image = append(anim.Image, p)
+		anim.Delay = append(anim.Delay, d)
+	}
+
+	return gif.EncodeAll(w, anim)
+}
@@ -0,0 +1,122 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"strings"
+)
+
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+	// glyphAdvance includes the space between letters.
+	glyphAdvance = glyphWidth + 1
+)
+
+// glyphs is the 5x7 bitmap font for the raster images.
+// Lower case letters are drawn as upper case, and unknown letters as '?'.
+var glyphs = map[rune][glyphHeight]string{
+	' ':  {".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	'0':  {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1':  {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2':  {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3':  {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4':  {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5':  {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6':  {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7':  {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8':  {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9':  {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'A':  {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B':  {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C':  {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D':  {"###..", "#..#.", "#...#", "#...#", "#...#", "#..#.", "###.."},
+	'E':  {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F':  {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G':  {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H':  {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I':  {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J':  {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K':  {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L':  {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M':  {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N':  {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O':  {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P':  {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q':  {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R':  {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S':  {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T':  {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U':  {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V':  {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W':  {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X':  {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y':  {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z':  {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'+':  {".....", "..#..", "..#..", "#####", "..#..", "..#..", "....."},
+	'-':  {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	'=':  {".....", ".....", "#####", ".....", "#####", ".....", "....."},
+	'.':  {".....", ".....", ".....", ".....", ".....", ".##..", ".##.."},
+	',':  {".....", ".....", ".....", ".....", ".##..", "..#..", ".#..."},
+	':':  {".....", ".##..", ".##..", ".....", ".##..", ".##..", "....."},
+	'(':  {"...#.", "..#..", ".#...", ".#...", ".#...", "..#..", "...#."},
+	')':  {".#...", "..#..", "...#.", "...#.", "...#.", "..#..", ".#..."},
+	'/':  {".....", "....#", "...#.", "..#..", ".#...", "#....", "....."},
+	'*':  {".....", "..#..", "#.#.#", ".###.", "#.#.#", "..#..", "....."},
+	'#':  {".#.#.", ".#.#.", "#####", ".#.#.", "#####", ".#.#.", ".#.#."},
+	'!':  {"..#..", "..#..", "..#..", "..#..", "..#..", ".....", "..#.."},
+	'?':  {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+	'\'': {"..#..", "..#..", ".#...", ".....", ".....", ".....", "....."},
+	'_':  {".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+}
+
+func lookupGlyph(r rune) ([glyphHeight]string, bool) {
+	if g, ok := glyphs[r]; ok {
+		return g, true
+	}
+	g, ok := glyphs[[]rune(strings.ToUpper(string(r)))[0]]
+	return g, ok
+}
+
+func glyph(r rune) [glyphHeight]string {
+	if g, ok := lookupGlyph(r); ok {
+		return g
+	}
+	return glyphs['?']
+}
+
+// drawable reports whether the font has all the letters of s.
+// The raster images omit the text which is not drawable, such as Japanese.
+func drawable(s string) bool {
+	for _, r := range s {
+		if _, ok := lookupGlyph(r); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// textWidth returns the width of s drawn by drawText.
+func textWidth(s string, scale int) int {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+	return (n*glyphAdvance - 1) * scale
+}
+
+// drawText draws s with the top-left corner at (x, y).
+func drawText(img *image.RGBA, x, y int, s string, scale int, c color.Color) {
+	for _, r := range s {
+		g := glyph(r)
+		for gy, row := range g {
+			for gx, b := range row {
+				if b != '#' {
+					continue
+				}
+				fillRect(img, image.Rect(x+gx*scale, y+gy*scale, x+(gx+1)*scale, y+(gy+1)*scale), c)
+			}
+		}
+		x += glyphAdvance * scale
+	}
+}
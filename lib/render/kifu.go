@@ -0,0 +1,29 @@
+package render
+
+import (
+	"strings"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+// StepOptions returns the options for the step of the kifu:
+// the player names and the destination of the last move.
+func StepOptions(kifu *documentpb.Kifu, step *documentpb.Step) []Option {
+	var black, white []string
+	for _, p := range kifu.GetPlayers() {
+		switch p.GetOrder() {
+		case documentpb.Player_BLACK:
+			black = append(black, p.GetName())
+		case documentpb.Player_WHITE:
+			white = append(white, p.GetName())
+		}
+	}
+
+	opts := []Option{
+		SetPlayers(strings.Join(black, "・"), strings.Join(white, "・")),
+	}
+	if dst := step.GetDst(); dst != nil {
+		opts = append(opts, SetLastMove(dst.GetX(), dst.GetY()))
+	}
+	return opts
+}
@@ -0,0 +1,173 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+)
+
+type point struct {
+	x, y float64
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+// fillPolygon fills the polygon with the even-odd rule, sampling at the pixel centers.
+func fillPolygon(img *image.RGBA, pts []point, c color.Color) {
+	if len(pts) < 3 {
+		return
+	}
+
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range pts {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+
+	b := image.Rect(
+		int(math.Floor(minX)), int(math.Floor(minY)),
+		int(math.Ceil(maxX))+1, int(math.Ceil(maxY))+1,
+	).Intersect(img.Bounds())
+	if b.Empty() {
+		return
+	}
+
+	mask := image.NewAlpha(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		sy := float64(y) + 0.5
+
+		var xs []float64
+		for i := range pts {
+			p, q := pts[i], pts[(i+1)%len(pts)]
+			if (p.y <= sy) == (q.y <= sy) {
+				continue
+			}
+			xs = append(xs, p.x+(sy-p.y)*(q.x-p.x)/(q.y-p.y))
+		}
+		sortFloats(xs)
+
+		for i := 0; i+1 < len(xs); i += 2 {
+			for x := int(math.Ceil(xs[i] - 0.5)); float64(x)+0.5 <= xs[i+1]; x++ {
+				if x >= b.Min.X && x < b.Max.X {
+					mask.SetAlpha(x, y, color.Alpha{A: 0xff})
+				}
+			}
+		}
+	}
+
+	draw.DrawMask(img, b, image.NewUniform(c), image.Point{}, mask, b.Min, draw.Over)
+}
+
+func sortFloats(xs []float64) {
+	for i := 1; i < len(xs); i++ {
+		for j := i; j > 0 && xs[j] < xs[j-1]; j-- {
+			xs[j], xs[j-1] = xs[j-1], xs[j]
+		}
+	}
+}
+
+// drawLine draws the line of the width w.
+func drawLine(img *image.RGBA, from, to point, w float64, c color.Color) {
+	dx, dy := to.x-from.x, to.y-from.y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return
+	}
+	nx, ny := -dy/l*w/2, dx/l*w/2
+	fillPolygon(img, []point{
+		{from.x + nx, from.y + ny},
+		{to.x + nx, to.y + ny},
+		{to.x - nx, to.y - ny},
+		{from.x - nx, from.y - ny},
+	}, c)
+}
+
+// Image draws the board.
+func (r *Renderer) Image() *image.RGBA {
+	l := r.layout()
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	fillRect(img, img.Bounds(), colorBackground)
+
+	c := r.cellSize
+	scale := c / 20
+	if scale < 1 {
+		scale = 1
+	}
+
+	// players and hands
+	for _, info := range r.infos(l) {
+		drawText(img, info.x, info.y-glyphHeight*scale/2, info.ascii, scale, colorText)
+	}
+
+	// coordinates
+	for i := 1; i <= 9; i++ {
+		s := string(rune('0' + i))
+		x, _ := l.center(i, 1)
+		drawText(img, int(x)-textWidth(s, scale)/2, l.boardY-c/4-glyphHeight*scale/2, s, scale, colorText)
+		_, y := l.center(1, i)
+		s = string(rune('a' + i - 1))
+		drawText(img, l.boardX+9*c+c/4-textWidth(s, scale)/2, int(y)-glyphHeight*scale/2, s, scale, colorText)
+	}
+
+	// board
+	fillRect(img, image.Rect(l.boardX, l.boardY, l.boardX+9*c, l.boardY+9*c), colorBoard)
+	if sq := r.lastMove; sq != nil {
+		x, y := l.cellOrigin(sq.X(), sq.Y())
+		fillRect(img, image.Rect(x, y, x+c, y+c), colorLastMove)
+	}
+	for i := 0; i <= 9; i++ {
+		fillRect(img, image.Rect(l.boardX+i*c, l.boardY, l.boardX+i*c+1, l.boardY+9*c+1), colorLine)
+		fillRect(img, image.Rect(l.boardX, l.boardY+i*c, l.boardX+9*c+1, l.boardY+i*c+1), colorLine)
+	}
+	for _, p := range l.stars() {
+		fillPolygon(img, circle(p, float64(c)/16), colorLine)
+	}
+
+	// pieces
+	for i, pc := range r.pos.Board {
+		if pc.Empty() {
+			continue
+		}
+		sq := shogi.Square(i)
+		x, y := l.cellOrigin(sq.X(), sq.Y())
+		pts := piecePolygon(float64(x), float64(y), float64(c), pc.Color)
+		fillPolygon(img, pts, colorLine)
+		fillPolygon(img, shrink(pts, 1.5), colorPiece)
+
+		s := pieceLetter(pc.Type)
+		fg := colorText
+		if pc.Type.Promoted() {
+			fg = colorPromoted
+		}
+		cx, cy := l.center(sq.X(), sq.Y())
+		drawText(img, int(cx)-textWidth(s, scale)/2, int(cy)-glyphHeight*scale/2+c/20, s, scale, fg)
+	}
+
+	// arrows
+	for _, a := range r.arrows {
+		fillPolygon(img, l.arrowPolygon(a), colorArrow)
+	}
+
+	i := 0
+	for _, line := range r.caption {
+		if !drawable(line) {
+			continue
+		}
+		drawText(img, l.boardX, l.captionLine(i), line, scale, colorText)
+		i++
+	}
+
+	return img
+}
+
+// WritePNG writes the board in PNG.
+func (r *Renderer) WritePNG(w io.Writer) error {
+	return png.Encode(w, r.Image())
+}
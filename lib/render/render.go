@@ -0,0 +1,329 @@
+// Package render draws the board images in SVG and PNG.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+)
+
+const (
+	DefaultCellSize = 40
+
+	// the caption lines are wrapped to fit the width of the image.
+	captionRunes    = 25
+	maxCaptionLines = 6
+)
+
+var ErrInvalidSquare = errors.New("invalid square")
+
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorBoard      = color.RGBA{0xf2, 0xd0, 0x8a, 0xff}
+	colorLastMove   = color.RGBA{0xf5, 0xa6, 0x5b, 0xff}
+	colorLine       = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	colorPiece      = color.RGBA{0xfb, 0xed, 0xc9, 0xff}
+	colorText       = color.RGBA{0x11, 0x11, 0x11, 0xff}
+	colorPromoted   = color.RGBA{0xc0, 0x10, 0x10, 0xff}
+	colorArrow      = color.NRGBA{0xb0, 0x10, 0x10, 0x99}
+)
+
+type arrow struct {
+	fromX, fromY, toX, toY int32
+}
+
+type Renderer struct {
+	pos      *shogi.Position
+	cellSize int
+	lastMove *shogi.Square
+	arrows   []arrow
+	black    string
+	white    string
+	caption  []string
+	// the number of the lines reserved for the caption.
+	captionLines int
+
+	lastX, lastY int32
+}
+
+type Option func(*Renderer)
+
+// SetCellSize sets the size of a square in pixels.
+func SetCellSize(n int) Option {
+	return func(r *Renderer) {
+		r.cellSize = n
+	}
+}
+
+// SetLastMove highlights the destination square of the last move, Step.dst.
+func SetLastMove(x, y int32) Option {
+	return func(r *Renderer) {
+		r.lastX, r.lastY = x, y
+	}
+}
+
+// AddArrow draws an arrow from the square to the square.
+func AddArrow(fromX, fromY, toX, toY int32) Option {
+	return func(r *Renderer) {
+		r.arrows = append(r.arrows, arrow{fromX, fromY, toX, toY})
+	}
+}
+
+// SetPlayers sets the names of the players.
+// The raster images draw only the names which the ASCII font can draw.
+func SetPlayers(black, white string) Option {
+	return func(r *Renderer) {
+		r.black, r.white = black, white
+	}
+}
+
+// SetCaption sets the text drawn under the board, such as the move and the notes.
+// The raster images skip the lines which the ASCII font cannot draw.
+func SetCaption(lines ...string) Option {
+	return func(r *Renderer) {
+		r.caption = append(r.caption, lines...)
+	}
+}
+
+// ReserveCaptionLines reserves the space of n caption lines, so that the frames have the same size.
+func ReserveCaptionLines(n int) Option {
+	return func(r *Renderer) {
+		r.captionLines = n
+	}
+}
+
+func wrap(lines []string, n int) []string {
+	var ret []string
+	for _, line := range lines {
+		rs := []rune(line)
+		for len(rs) > n {
+			ret = append(ret, string(rs[:n]))
+			rs = rs[n:]
+		}
+		ret = append(ret, string(rs))
+	}
+	return ret
+}
+
+func validXY(x, y int32) bool {
+	return 1 <= x && x <= 9 && 1 <= y && y <= 9
+}
+
+// New returns the renderer of the SFEN position.
+func New(position string, opts ...Option) (*Renderer, error) {
+	pos, err := shogi.FromSFEN(position)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Renderer{
+		pos:      pos,
+		cellSize: DefaultCellSize,
+	}
+	for _, f := range opts {
+		f(r)
+	}
+
+	r.caption = wrap(r.caption, captionRunes)
+	if len(r.caption) > maxCaptionLines {
+		r.caption = append(r.caption[:maxCaptionLines-1], "...")
+	}
+
+	if r.cellSize < 10 {
+		return nil, fmt.Errorf("cell size is too small: %d", r.cellSize)
+	}
+	if r.lastX != 0 || r.lastY != 0 {
+		if !validXY(r.lastX, r.lastY) {
+			return nil, ErrInvalidSquare
+		}
+		sq := shogi.NewSquare(int(r.lastX), int(r.lastY))
+		r.lastMove = &sq
+	}
+	for _, a := range r.arrows {
+		if !validXY(a.fromX, a.fromY) || !validXY(a.toX, a.toY) {
+			return nil, ErrInvalidSquare
+		}
+	}
+
+	return r, nil
+}
+
+type layout struct {
+	cell           int
+	width, height  int
+	boardX, boardY int
+	captionY       int
+}
+
+func (r *Renderer) layout() *layout {
+	c := r.cellSize
+	l := &layout{
+		cell:     c,
+		width:    10 * c,
+		height:   c + c/2 + 9*c + c,
+		boardX:   c / 2,
+		boardY:   c + c/2,
+		captionY: c + c/2 + 9*c + c,
+	}
+	n := len(r.caption)
+	if n < r.captionLines {
+		n = r.captionLines
+	}
+	if n > 0 {
+		l.height += n*c/2 + c/4
+	}
+	return l
+}
+
+// captionLine returns the top of the i-th caption line.
+func (l *layout) captionLine(i int) int {
+	return l.captionY + i*l.cell/2
+}
+
+func (l *layout) cellOrigin(x, y int) (int, int) {
+	return l.boardX + (9-x)*l.cell, l.boardY + (y-1)*l.cell
+}
+
+func (l *layout) center(x, y int) (float64, float64) {
+	ox, oy := l.cellOrigin(x, y)
+	return float64(ox) + float64(l.cell)/2, float64(oy) + float64(l.cell)/2
+}
+
+func (l *layout) stars() []point {
+	var ret []point
+	for _, i := range []int{3, 6} {
+		for _, j := range []int{3, 6} {
+			ret = append(ret, point{
+				float64(l.boardX + i*l.cell),
+				float64(l.boardY + j*l.cell),
+			})
+		}
+	}
+	return ret
+}
+
+func (l *layout) arrowPolygon(a arrow) []point {
+	fx, fy := l.center(int(a.fromX), int(a.fromY))
+	tx, ty := l.center(int(a.toX), int(a.toY))
+	c := float64(l.cell)
+
+	dx, dy := tx-fx, ty-fy
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return nil
+	}
+	ux, uy := dx/length, dy/length
+	nx, ny := -uy, ux
+
+	from := point{fx + ux*c*0.15, fy + uy*c*0.15}
+	tip := point{tx - ux*c*0.15, ty - uy*c*0.15}
+	head := c / 3
+	base := point{tip.x - ux*head, tip.y - uy*head}
+	w, hw := c/8/2, head/2
+
+	return []point{
+		{from.x + nx*w, from.y + ny*w},
+		{base.x + nx*w, base.y + ny*w},
+		{base.x + nx*hw, base.y + ny*hw},
+		tip,
+		{base.x - nx*hw, base.y - ny*hw},
+		{base.x - nx*w, base.y - ny*w},
+		{from.x - nx*w, from.y - ny*w},
+	}
+}
+
+// piecePolygon returns the pentagon of the piece in the cell at (x, y).
+func piecePolygon(x, y, c float64, color shogi.Color) []point {
+	rel := []point{{0.5, 0.1}, {0.8, 0.24}, {0.88, 0.92}, {0.12, 0.92}, {0.2, 0.24}}
+
+	ret := make([]point, len(rel))
+	for i, p := range rel {
+		if color == shogi.White {
+			p = point{1 - p.x, 1 - p.y}
+		}
+		ret[i] = point{x + p.x*c, y + p.y*c}
+	}
+	return ret
+}
+
+// shrink moves the points toward the centroid by d.
+func shrink(pts []point, d float64) []point {
+	var cx, cy float64
+	for _, p := range pts {
+		cx += p.x
+		cy += p.y
+	}
+	cx /= float64(len(pts))
+	cy /= float64(len(pts))
+
+	ret := make([]point, len(pts))
+	for i, p := range pts {
+		vx, vy := p.x-cx, p.y-cy
+		l := math.Hypot(vx, vy)
+		ret[i] = point{cx + vx*(l-d)/l, cy + vy*(l-d)/l}
+	}
+	return ret
+}
+
+func circle(c point, r float64) []point {
+	const n = 12
+	ret := make([]point, n)
+	for i := range ret {
+		a := 2 * math.Pi * float64(i) / n
+		ret[i] = point{c.x + r*math.Cos(a), c.y + r*math.Sin(a)}
+	}
+	return ret
+}
+
+// pieceLetter returns the label of the piece in the raster images, such as "P", "+R".
+func pieceLetter(t shogi.PieceType) string {
+	return shogi.Piece{Type: t, Color: shogi.Black}.SFEN()
+}
+
+type info struct {
+	x, y int
+	// text is drawn in SVG, ascii is drawn in the raster images.
+	text, ascii string
+}
+
+func (r *Renderer) infos(l *layout) []*info {
+	line := func(c shogi.Color, mark, asciiMark, name string) (string, string) {
+		var hand, asciiHand []string
+		for _, t := range shogi.HandTypes {
+			n := r.pos.Hands[c][t]
+			if n == 0 {
+				continue
+			}
+			s, a := t.Kanji(), pieceLetter(t)
+			if n > 1 {
+				s += fmt.Sprint(n)
+				a += fmt.Sprint(n)
+			}
+			hand = append(hand, s)
+			asciiHand = append(asciiHand, a)
+		}
+		if len(hand) == 0 {
+			hand = []string{"なし"}
+			asciiHand = []string{"-"}
+		}
+
+		text := mark + " " + name + "　持駒 " + strings.Join(hand, " ")
+		ascii := asciiMark + " "
+		if drawable(name) {
+			ascii += name + "  "
+		}
+		ascii += strings.Join(asciiHand, " ")
+		return text, ascii
+	}
+
+	wt, wa := line(shogi.White, "☖", "W:", r.white)
+	bt, ba := line(shogi.Black, "☗", "B:", r.black)
+	return []*info{
+		{x: l.boardX, y: l.cell / 2, text: wt, ascii: wa},
+		{x: l.boardX, y: l.boardY + 9*l.cell + l.cell/2, text: bt, ascii: ba},
+	}
+}
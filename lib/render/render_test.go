@@ -0,0 +1,208 @@
+package render
+
+import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+const testPosition = "lnsgkgsnl/1r5+B1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/7R1/LNSGKGSNL w B 1"
+
+func TestWriteSVG(t *testing.T) {
+	r, err := New(testPosition, SetLastMove(2, 2), AddArrow(2, 8, 2, 2), SetPlayers("先手<A>", "後手"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var b strings.Builder
+	if err := r.WriteSVG(&b); err != nil {
+		t.Fatalf("WriteSVG: %v", err)
+	}
+	svg := b.String()
+
+	for _, expected := range []string{
+		"<svg ",
+		">馬</text>",
+		`transform="rotate(180 `,
+		"☗ 先手&lt;A&gt;　持駒 角",
+		"☖ 後手　持駒 なし",
+		`fill="#f5a65b"`,
+		`fill-opacity="0.60"`,
+	} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("%q is not contained", expected)
+		}
+	}
+	// 39 pieces and an arrow.
+	if n := strings.Count(svg, "<polygon "); n != 40 {
+		t.Errorf("polygons: expected=%d actual=%d", 40, n)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	r, err := New(testPosition, SetCellSize(20))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := r.WritePNG(&b); err != nil {
+		t.Fatalf("WritePNG: %v", err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != 200 || size.Y != 230 {
+		t.Errorf("size: %v", size)
+	}
+
+	// the center of 5e is an empty square.
+	x, y := r.layout().center(5, 5)
+	if c := img.At(int(x), int(y)); c != colorBoard {
+		t.Errorf("5e: %v", c)
+	}
+}
+
+func TestNew_InvalidSquare(t *testing.T) {
+	if _, err := New(testPosition, SetLastMove(0, 10)); err != ErrInvalidSquare {
+		t.Errorf("SetLastMove: %v", err)
+	}
+	if _, err := New(testPosition, AddArrow(1, 1, 1, 10)); err != ErrInvalidSquare {
+		t.Errorf("AddArrow: %v", err)
+	}
+}
+
+func testSteps() []*documentpb.Step {
+	return []*documentpb.Step{
+		{Seq: 0, Position: "lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1"},
+		{
+			Seq:      1,
+			Position: "lnsgkgsnl/1r5b1/ppppppppp/9/9/2P6/PP1PPPPPP/1B5R1/LNSGKGSNL w - 1",
+			Src:      &documentpb.Pos{X: 7, Y: 7},
+			Dst:      &documentpb.Pos{X: 7, Y: 6},
+			Piece:    documentpb.Piece_FU,
+			Sfen:     "7g7f",
+			Notes:    []string{"a long note which is wrapped to fit the width of the image"},
+		},
+		{
+			Seq:      2,
+			Position: "lnsgkgsnl/1r5b1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/1B5R1/LNSGKGSNL b - 1",
+			Src:      &documentpb.Pos{X: 3, Y: 3},
+			Dst:      &documentpb.Pos{X: 3, Y: 4},
+			Piece:    documentpb.Piece_FU,
+			Sfen:     "3c3d",
+		},
+		{
+			Seq:            3,
+			Position:       "lnsgkgsnl/1r5b1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/1B5R1/LNSGKGSNL b - 1",
+			FinishedStatus: documentpb.FinishedStatus_SURRENDER,
+		},
+	}
+}
+
+func TestMoveText(t *testing.T) {
+	expected := []string{"0. start", "1. P7g7f", "2. P3c3d", "3. SURRENDER"}
+	for i, step := range testSteps() {
+		if actual := MoveText(step); actual != expected[i] {
+			t.Errorf("expected=%q actual=%q", expected[i], actual)
+		}
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	kifu := &documentpb.Kifu{
+		Players: []*documentpb.Player{
+			{Order: documentpb.Player_BLACK, Name: "A"},
+			{Order: documentpb.Player_WHITE, Name: "B"},
+		},
+	}
+
+	if n := FrameCount(testSteps(), 1, 2); n != 2 {
+		t.Errorf("FrameCount: %d", n)
+	}
+	if n := FrameCount(testSteps(), 2, 0); n != 2 {
+		t.Errorf("FrameCount to the last: %d", n)
+	}
+
+	frames, err := Frames(kifu, testSteps(), 1, 2, true, SetCellSize(20))
+	if err != nil {
+		t.Fatalf("Frames: %v", err)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("len(frames)=%d", len(frames))
+	}
+	if c := frames[0].caption; len(c) != 4 || c[0] != "1. P7g7f" {
+		t.Errorf("caption: %q", c)
+	}
+
+	var b bytes.Buffer
+	if err := WriteGIF(&b, frames, 50); err != nil {
+		t.Fatalf("WriteGIF: %v", err)
+	}
+	g, err := gif.DecodeAll(&b)
+	if err != nil {
+		t.Fatalf("gif.DecodeAll: %v", err)
+	}
+	if len(g.Image) != 2 || g.Delay[0] != 50 || g.Delay[1] != 150 {
+		t.Errorf("frames=%d delay=%v", len(g.Image), g.Delay)
+	}
+	if g.Image[0].Bounds() != g.Image[1].Bounds() {
+		t.Errorf("bounds: %v %v", g.Image[0].Bounds(), g.Image[1].Bounds())
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	r, err := New(testPosition, SetLastMove(2, 2), SetPlayers("先手", "後手"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var b strings.Builder
+	if err := r.WriteText(&b, false); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	lines := strings.Split(b.String(), "\n")
+
+	for i, expected := range map[int]string{
+		0:  "☖ 後手　持駒 なし",
+		3:  "|v香v桂v銀v金v玉v金v銀v桂v香|一",
+		4:  "| ・v飛 ・ ・ ・ ・ ・ 馬 ・|二",
+		11: "| 香 桂 銀 金 玉 金 銀 桂 香|九",
+		13: "☗ 先手　持駒 角",
+	} {
+		if lines[i] != expected {
+			t.Errorf("line %d: expected=%q actual=%q", i, expected, lines[i])
+		}
+	}
+
+	b.Reset()
+	if err := r.WriteText(&b, true); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	if !strings.Contains(b.String(), " "+ansiReverse+ansiRed+"馬"+ansiReset) {
+		t.Errorf("last move is not highlighted: %q", b.String())
+	}
+}
+
+func TestInfos_Drawable(t *testing.T) {
+	r, err := New(testPosition, SetPlayers("Player A", "羽生善治"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	infos := r.infos(r.layout())
+	if a := infos[0].ascii; strings.Contains(a, "?") || strings.Contains(a, "羽生") {
+		t.Errorf("white: %q", a)
+	}
+	if a := infos[1].ascii; !strings.Contains(a, "Player A") {
+		t.Errorf("black: %q", a)
+	}
+	if tx := infos[0].text; !strings.Contains(tx, "羽生善治") {
+		t.Errorf("white text: %q", tx)
+	}
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+)
+
+var rankNames = []string{"", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+
+func svgColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+}
+
+func svgOpacity(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("%.2f", float64(n.A)/0xff)
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func svgPoints(pts []point) string {
+	var ss []string
+	for _, p := range pts {
+		ss = append(ss, fmt.Sprintf("%.1f,%.1f", p.x, p.y))
+	}
+	return strings.Join(ss, " ")
+}
+
+// WriteSVG writes the board in SVG.
+func (r *Renderer) WriteSVG(w io.Writer) error {
+	l := r.layout()
+	c := l.cell
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", l.width, l.height, svgColor(colorBackground))
+
+	// players and hands
+	for _, info := range r.infos(l) {
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+			info.x, info.y, c*2/5, svgColor(colorText), escape(info.text))
+	}
+
+	// coordinates
+	for i := 1; i <= 9; i++ {
+		x, _ := l.center(i, 1)
+		fmt.Fprintf(b, `<text x="%.1f" y="%d" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%d</text>`+"\n",
+			x, l.boardY-c/4, c*7/20, svgColor(colorText), i)
+		_, y := l.center(1, i)
+		fmt.Fprintf(b, `<text x="%d" y="%.1f" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+			l.boardX+9*c+c/4, y, c*7/20, svgColor(colorText), rankNames[i])
+	}
+
+	// board
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s"/>`+"\n",
+		l.boardX, l.boardY, 9*c, 9*c, svgColor(colorBoard), svgColor(colorLine))
+	if sq := r.lastMove; sq != nil {
+		x, y := l.cellOrigin(sq.X(), sq.Y())
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, c, c, svgColor(colorLastMove))
+	}
+	for i := 0; i <= 9; i++ {
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n",
+			l.boardX+i*c, l.boardY, l.boardX+i*c, l.boardY+9*c, svgColor(colorLine))
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n",
+			l.boardX, l.boardY+i*c, l.boardX+9*c, l.boardY+i*c, svgColor(colorLine))
+	}
+	for _, p := range l.stars() {
+		fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`+"\n", p.x, p.y, float64(c)/16, svgColor(colorLine))
+	}
+
+	// pieces
+	for i, pc := range r.pos.Board {
+		if pc.Empty() {
+			continue
+		}
+		sq := shogi.Square(i)
+		x, y := l.cellOrigin(sq.X(), sq.Y())
+		cx, cy := l.center(sq.X(), sq.Y())
+
+		var rotate string
+		if pc.Color == shogi.White {
+			rotate = fmt.Sprintf(` transform="rotate(180 %.1f %.1f)"`, cx, cy)
+		}
+		fg := colorText
+		if pc.Type.Promoted() {
+			fg = colorPromoted
+		}
+
+		fmt.Fprintf(b, `<g%s><polygon points="%s" fill="%s" stroke="%s"/>`,
+			rotate, svgPoints(piecePolygon(float64(x), float64(y), float64(c), shogi.Black)), svgColor(colorPiece), svgColor(colorLine))
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text></g>`+"\n",
+			cx, cy+float64(c)/20, c*3/5, svgColor(fg), pc.Type.Kanji())
+	}
+
+	// arrows
+	for _, a := range r.arrows {
+		fmt.Fprintf(b, `<polygon points="%s" fill="%s" fill-opacity="%s"/>`+"\n",
+			svgPoints(l.arrowPolygon(a)), svgColor(colorArrow), svgOpacity(colorArrow))
+	}
+
+	for i, line := range r.caption {
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" dominant-baseline="hanging" fill="%s">%s</text>`+"\n",
+			l.boardX, l.captionLine(i), c*2/5, svgColor(colorText), escape(line))
+	}
+
+	fmt.Fprintln(b, `</svg>`)
+
+	return b.Flush()
+}
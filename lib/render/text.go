@@ -0,0 +1,68 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+)
+
+var fileNames = []string{"", "１", "２", "３", "４", "５", "６", "７", "８", "９"}
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiReverse = "\x1b[7m"
+	ansiRed     = "\x1b[31m"
+)
+
+// WriteText writes the board for terminals with the Japanese piece names.
+// The pieces of white are prefixed with "v" as in BOD.
+// If ansi is true, the last move is highlighted and the promoted pieces are colored with ANSI escape sequences.
+func (r *Renderer) WriteText(w io.Writer, ansi bool) error {
+	infos := r.infos(r.layout())
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintln(b, infos[0].text)
+	b.WriteString(" ")
+	for x := 9; x >= 1; x-- {
+		b.WriteString(" " + fileNames[x])
+	}
+	b.WriteString("\n+---------------------------+\n")
+	for y := 1; y <= 9; y++ {
+		b.WriteString("|")
+		for x := 9; x >= 1; x-- {
+			pc := r.pos.Get(x, y)
+
+			mark := " "
+			if !pc.Empty() && pc.Color == shogi.White {
+				mark = "v"
+			}
+			name := "・"
+			if !pc.Empty() {
+				name = pc.Type.Kanji()
+			}
+
+			if !ansi {
+				b.WriteString(mark + name)
+				continue
+			}
+			b.WriteString(mark)
+			if sq := r.lastMove; sq != nil && sq.X() == x && sq.Y() == y {
+				b.WriteString(ansiReverse)
+			}
+			if pc.Type.Promoted() {
+				b.WriteString(ansiRed)
+			}
+			b.WriteString(name + ansiReset)
+		}
+		fmt.Fprintf(b, "|%s\n", rankNames[y])
+	}
+	b.WriteString("+---------------------------+\n")
+	fmt.Fprintln(b, infos[1].text)
+	for _, line := range r.caption {
+		fmt.Fprintln(b, line)
+	}
+
+	return b.Flush()
+}
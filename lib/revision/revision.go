@@ -0,0 +1,164 @@
+package revision
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+const (
+	FieldStartTs      = "start_ts"
+	FieldEndTs        = "end_ts"
+	FieldHandicap     = "handicap"
+	FieldGameName     = "game_name"
+	FieldPlayers      = "players"
+	FieldNote         = "note"
+	FieldAliases      = "aliases"
+	FieldTags         = "tags"
+	FieldStepNotes    = "step_notes"
+	otherFieldsPrefix = "other_fields."
+)
+
+type Change struct {
+	Field    string
+	Seq      int32
+	OldValue string
+	NewValue string
+}
+
+func FromKifu(kifu *documentpb.Kifu, steps []*documentpb.Step, version int64) *documentpb.KifuRevision {
+	var stepNotes []*documentpb.StepNotes
+	for _, step := range steps {
+		if len(step.GetNotes()) == 0 {
+			continue
+		}
+
+		stepNotes = append(stepNotes, &documentpb.StepNotes{
+			Seq:   step.GetSeq(),
+			Notes: step.GetNotes(),
+		})
+	}
+
+	return &documentpb.KifuRevision{
+		KifuId:    kifu.GetKifuId(),
+		Version:   version,
+		Kifu:      kifu,
+		StepNotes: stepNotes,
+	}
+}
+
+func formatPlayers(players []*documentpb.Player) string {
+	var ss []string
+	for _, p := range players {
+		s := p.GetOrder().String() + ":" + p.GetName()
+		if p.GetNote() != "" {
+			s += "(" + p.GetNote() + ")"
+		}
+		ss = append(ss, s)
+	}
+	return strings.Join(ss, ",")
+}
+
+func formatTs(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", ts)
+}
+
+func stepNotesMap(rev *documentpb.KifuRevision) map[int32]string {
+	ret := make(map[int32]string)
+	for _, sn := range rev.GetStepNotes() {
+		ret[sn.GetSeq()] = strings.Join(sn.GetNotes(), "\n")
+	}
+	return ret
+}
+
+// Diff returns changes of metadata and step notes from `from` to `to`.
+func Diff(from, to *documentpb.KifuRevision) []*Change {
+	var ret []*Change
+	add := func(field string, seq int32, o, n string) {
+		if o == n {
+			return
+		}
+		ret = append(ret, &Change{
+			Field:    field,
+			Seq:      seq,
+			OldValue: o,
+			NewValue: n,
+		})
+	}
+
+	fk, tk := from.GetKifu(), to.GetKifu()
+
+	add(FieldStartTs, 0, formatTs(fk.GetStartTs()), formatTs(tk.GetStartTs()))
+	add(FieldEndTs, 0, formatTs(fk.GetEndTs()), formatTs(tk.GetEndTs()))
+	add(FieldHandicap, 0, fk.GetHandicap().String(), tk.GetHandicap().String())
+	add(FieldGameName, 0, fk.GetGameName(), tk.GetGameName())
+	add(FieldPlayers, 0, formatPlayers(fk.GetPlayers()), formatPlayers(tk.GetPlayers()))
+	add(FieldNote, 0, fk.GetNote(), tk.GetNote())
+	add(FieldAliases, 0, strings.Join(fk.GetAliases(), ","), strings.Join(tk.GetAliases(), ","))
+	add(FieldTags, 0, strings.Join(fk.GetTags(), ","), strings.Join(tk.GetTags(), ","))
+
+	var names []string
+	for k := range fk.GetOtherFields() {
+		names = append(names, k)
+	}
+	for k := range tk.GetOtherFields() {
+		if _, ok := fk.GetOtherFields()[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		add(otherFieldsPrefix+k, 0, fk.GetOtherFields()[k], tk.GetOtherFields()[k])
+	}
+
+	fn, tn := stepNotesMap(from), stepNotesMap(to)
+	var seqs []int32
+	for seq := range fn {
+		seqs = append(seqs, seq)
+	}
+	for seq := range tn {
+		if _, ok := fn[seq]; !ok {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	for _, seq := range seqs {
+		add(FieldStepNotes, seq, fn[seq], tn[seq])
+	}
+
+	return ret
+}
+
+// Apply restores metadata and step notes of rev onto kifu and steps.
+// The moves are kept as current.
+func Apply(rev *documentpb.KifuRevision, kifu *documentpb.Kifu, steps []*documentpb.Step) *documentpb.Kifu {
+	restored := proto.Clone(kifu).(*documentpb.Kifu)
+
+	rk := rev.GetKifu()
+	restored.StartTs = rk.GetStartTs()
+	restored.EndTs = rk.GetEndTs()
+	restored.Handicap = rk.GetHandicap()
+	restored.GameName = rk.GetGameName()
+	restored.Players = rk.GetPlayers()
+	restored.OtherFields = rk.GetOtherFields()
+	restored.Aliases = rk.GetAliases()
+	restored.Tags = rk.GetTags()
+	restored.Note = rk.GetNote()
+
+	notes := make(map[int32][]string)
+	for _, sn := range rev.GetStepNotes() {
+		notes[sn.GetSeq()] = sn.GetNotes()
+	}
+	for _, step := range steps {
+		step.Notes = notes[step.GetSeq()]
+	}
+
+	return restored
+}
@@ -0,0 +1,91 @@
+package revision
+
+import (
+	"testing"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func TestDiff(t *testing.T) {
+	from := &documentpb.KifuRevision{
+		Kifu: &documentpb.Kifu{
+			GameName: "old game",
+			Note:     "note",
+			OtherFields: map[string]string{
+				"場所": "道場",
+			},
+		},
+		StepNotes: []*documentpb.StepNotes{
+			{Seq: 3, Notes: []string{"a"}},
+		},
+	}
+	to := &documentpb.KifuRevision{
+		Kifu: &documentpb.Kifu{
+			GameName: "new game",
+			Note:     "note",
+			OtherFields: map[string]string{
+				"場所": "自宅",
+			},
+		},
+		StepNotes: []*documentpb.StepNotes{
+			{Seq: 5, Notes: []string{"b", "c"}},
+		},
+	}
+
+	changes := Diff(from, to)
+
+	expected := []Change{
+		{Field: FieldGameName, OldValue: "old game", NewValue: "new game"},
+		{Field: "other_fields.場所", OldValue: "道場", NewValue: "自宅"},
+		{Field: FieldStepNotes, Seq: 3, OldValue: "a", NewValue: ""},
+		{Field: FieldStepNotes, Seq: 5, OldValue: "", NewValue: "b\nc"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("len(changes): expected=%d actual=%d %v", len(expected), len(changes), changes)
+	}
+	for i, c := range changes {
+		if *c != expected[i] {
+			t.Errorf("changes[%d]: expected=%v actual=%v", i, expected[i], *c)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	kifu := &documentpb.Kifu{
+		UserId:   "user",
+		KifuId:   "kifu",
+		GameName: "current",
+		Sfen:     "position startpos moves 7g7f",
+	}
+	steps := []*documentpb.Step{
+		{Seq: 0},
+		{Seq: 1, Notes: []string{"current note"}},
+	}
+	rev := &documentpb.KifuRevision{
+		Kifu: &documentpb.Kifu{
+			GameName: "restored",
+			Sfen:     "position startpos moves 2g2f",
+		},
+		StepNotes: []*documentpb.StepNotes{
+			{Seq: 0, Notes: []string{"restored note"}},
+		},
+	}
+
+	restored := Apply(rev, kifu, steps)
+
+	if restored.GetGameName() != "restored" {
+		t.Errorf("GameName: %v", restored.GetGameName())
+	}
+	if restored.GetSfen() != kifu.GetSfen() || restored.GetUserId() != "user" || restored.GetKifuId() != "kifu" {
+		t.Errorf("identity was changed: %v", restored)
+	}
+	if kifu.GetGameName() != "current" {
+		t.Errorf("original kifu was modified: %v", kifu)
+	}
+	if len(steps[0].GetNotes()) != 1 || steps[0].GetNotes()[0] != "restored note" {
+		t.Errorf("steps[0].Notes: %v", steps[0].GetNotes())
+	}
+	if len(steps[1].GetNotes()) != 0 {
+		t.Errorf("steps[1].Notes: %v", steps[1].GetNotes())
+	}
+}
@@ -0,0 +1,197 @@
+// Package senkei classifies openings (戦型) of kifu.
+package senkei
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/yunomu/kansousen/lib/pattern"
+	"github.com/yunomu/kansousen/lib/position"
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+const (
+	// ScopeSide rules are written from the black side, and applied to each side.
+	ScopeSide = "side"
+	// ScopeGame rules label both sides. They also match with the colors swapped.
+	ScopeGame = "game"
+)
+
+// Rule labels a side or a game if any of the patterns appears within the first steps.
+type Rule struct {
+	Name     string             `json:"name"`
+	Category string             `json:"category,omitempty"`
+	Scope    string             `json:"scope"`
+	Patterns []*pattern.Pattern `json:"patterns"`
+}
+
+type Rules struct {
+	// MaxPly is the number of steps to inspect.
+	MaxPly int `json:"max_ply,omitempty"`
+	// DefaultCategory is the category of a side which no side rule matches.
+	DefaultCategory string  `json:"default_category,omitempty"`
+	Rules           []*Rule `json:"rules"`
+}
+
+//go:embed rules.json
+var defaultRules []byte
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() *Rules {
+	rules, err := LoadRules(bytes.NewReader(defaultRules))
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// LoadRules reads the rules file in JSON.
+func LoadRules(r io.Reader) (*Rules, error) {
+	var rules Rules
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule without name")
+		}
+		switch rule.Scope {
+		case ScopeSide, ScopeGame:
+		default:
+			return nil, fmt.Errorf("rule %v: invalid scope: %q", rule.Name, rule.Scope)
+		}
+		if len(rule.Patterns) == 0 {
+			return nil, fmt.Errorf("rule %v: no patterns", rule.Name)
+		}
+		for _, pat := range rule.Patterns {
+			if err := pat.Validate(); err != nil {
+				return nil, fmt.Errorf("rule %v: %v", rule.Name, err)
+			}
+		}
+	}
+
+	return &rules, nil
+}
+
+type Classifier struct {
+	maxPly          int
+	defaultCategory string
+	sideRules       []*Rule
+	gameRules       []*Rule
+}
+
+// NewClassifier returns the classifier of the rules.
+// Rules given earlier take precedence, so extensions should be given before DefaultRules().
+func NewClassifier(rules ...*Rules) *Classifier {
+	c := &Classifier{}
+	for _, rs := range rules {
+		if c.maxPly < rs.MaxPly {
+			c.maxPly = rs.MaxPly
+		}
+		if c.defaultCategory == "" {
+			c.defaultCategory = rs.DefaultCategory
+		}
+
+		for _, rule := range rs.Rules {
+			switch rule.Scope {
+			case ScopeSide:
+				c.sideRules = append(c.sideRules, rule)
+			case ScopeGame:
+				c.gameRules = append(c.gameRules, rule)
+			}
+		}
+	}
+
+	return c
+}
+
+func matchAny(pats []*pattern.Pattern, positions []*position.Position) bool {
+	for _, pos := range positions {
+		for _, pat := range pats {
+			if pat.Match(pos) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func flipPatterns(pats []*pattern.Pattern) []*pattern.Pattern {
+	var ret []*pattern.Pattern
+	for _, pat := range pats {
+		ret = append(ret, pat.ColorFlipped())
+	}
+	return ret
+}
+
+func (c *Classifier) classifySide(positions []*position.Position, flip bool) []string {
+	for _, rule := range c.sideRules {
+		pats := rule.Patterns
+		if flip {
+			pats = flipPatterns(pats)
+		}
+
+		if matchAny(pats, positions) {
+			var ret []string
+			if rule.Category != "" && rule.Category != rule.Name {
+				ret = append(ret, rule.Category)
+			}
+			return append(ret, rule.Name)
+		}
+	}
+
+	if c.defaultCategory != "" {
+		return []string{c.defaultCategory}
+	}
+	return nil
+}
+
+// Classify returns the strategies of black and white, and of the game, e.g. 角換わり.
+func (c *Classifier) Classify(steps []*documentpb.Step) (black, white, game []string) {
+	var positions []*position.Position
+	for _, step := range steps {
+		if c.maxPly > 0 && int(step.GetSeq()) > c.maxPly {
+			break
+		}
+
+		pos, err := position.Parse(step.GetPosition())
+		if err != nil {
+			continue
+		}
+		positions = append(positions, pos)
+	}
+
+	black = c.classifySide(positions, false)
+	white = c.classifySide(positions, true)
+
+	for _, rule := range c.gameRules {
+		if matchAny(rule.Patterns, positions) || matchAny(flipPatterns(rule.Patterns), positions) {
+			// the category, e.g. 居飛車, is of the sides, which are classified by the side rules.
+			game = append(game, rule.Name)
+			break
+		}
+	}
+
+	return black, white, game
+}
+
+// Apply sets the strategies of kifu.
+func (c *Classifier) Apply(kifu *documentpb.Kifu, steps []*documentpb.Step) {
+	kifu.BlackStrategies, kifu.WhiteStrategies, kifu.GameStrategies = c.Classify(steps)
+}
+
+// HasStrategy reports whether either side or the game of kifu has the strategy.
+func HasStrategy(kifu *documentpb.Kifu, strategy string) bool {
+	for _, ss := range [][]string{kifu.GetBlackStrategies(), kifu.GetWhiteStrategies(), kifu.GetGameStrategies()} {
+		for _, s := range ss {
+			if s == strategy {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package senkei
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yunomu/usi/sfen"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func playSteps(t *testing.T, moves string) []*documentpb.Step {
+	p := sfen.NewSurfaceStartpos()
+
+	var steps []*documentpb.Step
+	var buf strings.Builder
+	if err := p.PrintSFEN(&buf); err != nil {
+		t.Fatalf("PrintSFEN: %v", err)
+	}
+	steps = append(steps, &documentpb.Step{Seq: 0, Position: buf.String()})
+
+	for i, move := range strings.Fields(moves) {
+		if err := p.Move(move); err != nil {
+			t.Fatalf("Move(%v): %v", move, err)
+		}
+		buf.Reset()
+		p.SetStep(1)
+		if err := p.PrintSFEN(&buf); err != nil {
+			t.Fatalf("PrintSFEN: %v", err)
+		}
+		steps = append(steps, &documentpb.Step{Seq: int32(i + 1), Position: buf.String()})
+	}
+
+	return steps
+}
+
+func TestClassify(t *testing.T) {
+	c := NewClassifier(DefaultRules())
+
+	cases := []struct {
+		moves string
+		black []string
+		white []string
+		game  []string
+	}{
+		{
+			moves: "7g7f 3c3d 6g6f 8c8d 2h6h",
+			black: []string{"振り飛車", "四間飛車"},
+			white: []string{"居飛車"},
+		},
+		{
+			moves: "7g7f 3c3d 8h2b+ 3a2b",
+			black: []string{"居飛車"},
+			white: []string{"居飛車"},
+			game:  []string{"角換わり"},
+		},
+		{
+			moves: "7g7f 5c5d 2g2f 8b5b",
+			black: []string{"居飛車"},
+			white: []string{"振り飛車", "中飛車"},
+		},
+	}
+	for _, tc := range cases {
+		black, white, game := c.Classify(playSteps(t, tc.moves))
+		if !reflect.DeepEqual(black, tc.black) {
+			t.Errorf("%v: black: expected=%v actual=%v", tc.moves, tc.black, black)
+		}
+		if !reflect.DeepEqual(white, tc.white) {
+			t.Errorf("%v: white: expected=%v actual=%v", tc.moves, tc.white, white)
+		}
+		if !reflect.DeepEqual(game, tc.game) {
+			t.Errorf("%v: game: expected=%v actual=%v", tc.moves, tc.game, game)
+		}
+	}
+}
+
+func TestExtendRules(t *testing.T) {
+	ext, err := LoadRules(strings.NewReader(`{
+  "rules": [
+    {
+      "name": "角道オープン四間飛車",
+      "category": "振り飛車",
+      "scope": "side",
+      "patterns": [
+        {"squares": [{"x": 6, "y": 8, "piece": "R"}, {"x": 7, "y": 6, "piece": "P"}]}
+      ]
+    }
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+
+	c := NewClassifier(ext, DefaultRules())
+
+	black, _, _ := c.Classify(playSteps(t, "7g7f 3c3d 6g6f 8c8d 2h6h"))
+	expected := []string{"振り飛車", "角道オープン四間飛車"}
+	if !reflect.DeepEqual(black, expected) {
+		t.Errorf("expected=%v actual=%v", expected, black)
+	}
+
+	if _, err := LoadRules(strings.NewReader(`{"rules": [{"name": "x", "scope": "unknown", "patterns": [{"hands": {"P": 1}}]}]}`)); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,102 @@
+package shogi
+
+import (
+	"errors"
+)
+
+type Move struct {
+	From Square
+	To   Square
+	// Drop is the type of the dropped piece. NoPiece for moves on the board.
+	Drop    PieceType
+	Promote bool
+}
+
+func (m Move) IsDrop() bool {
+	return m.Drop != NoPiece
+}
+
+var ErrInvalidUSI = errors.New("invalid USI move")
+
+func parseUSISquare(s string) (Square, bool) {
+	if len(s) != 2 {
+		return 0, false
+	}
+	x := int(s[0] - '0')
+	y := int(s[1]-'a') + 1
+	if !validXY(x, y) {
+		return 0, false
+	}
+	return NewSquare(x, y), true
+}
+
+// ParseUSI parses the move in USI notation such as "7g7f", "8h2b+", "P*5e".
+func ParseUSI(s string) (Move, error) {
+	if len(s) == 4 && s[1] == '*' {
+		t, ok := typeFromLetter(s[:1])
+		if !ok || t == King {
+			return Move{}, ErrInvalidUSI
+		}
+		to, ok := parseUSISquare(s[2:])
+		if !ok {
+			return Move{}, ErrInvalidUSI
+		}
+		return Move{To: to, Drop: t}, nil
+	}
+
+	var promote bool
+	if len(s) == 5 && s[4] == '+' {
+		promote = true
+		s = s[:4]
+	}
+	if len(s) != 4 {
+		return Move{}, ErrInvalidUSI
+	}
+
+	from, ok := parseUSISquare(s[:2])
+	if !ok {
+		return Move{}, ErrInvalidUSI
+	}
+	to, ok := parseUSISquare(s[2:])
+	if !ok {
+		return Move{}, ErrInvalidUSI
+	}
+
+	return Move{From: from, To: to, Promote: promote}, nil
+}
+
+func (m Move) USI() string {
+	if m.IsDrop() {
+		return sfenLetters[m.Drop] + "*" + m.To.USI()
+	}
+
+	s := m.From.USI() + m.To.USI()
+	if m.Promote {
+		s += "+"
+	}
+	return s
+}
+
+// Play returns the position after m. m is not validated.
+func (p *Position) Play(m Move) *Position {
+	n := *p
+	side := p.Side
+
+	if m.IsDrop() {
+		n.Board[m.To] = Piece{Type: m.Drop, Color: side}
+		n.Hands[side][m.Drop]--
+	} else {
+		pc := n.Board[m.From]
+		if captured := n.Board[m.To]; !captured.Empty() && captured.Type != King {
+			n.Hands[side][captured.Type.Unpromote()]++
+		}
+		if m.Promote {
+			pc.Type = pc.Type.Promote()
+		}
+		n.Board[m.To] = pc
+		n.Board[m.From] = Piece{}
+	}
+	n.Side = side.Opponent()
+
+	return &n
+}
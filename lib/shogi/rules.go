@@ -0,0 +1,262 @@
+package shogi
+
+type dir struct {
+	dx, dy int
+}
+
+// Directions of the black pieces. Black moves toward y=1.
+var (
+	goldDirs = []dir{{0, -1}, {-1, -1}, {1, -1}, {-1, 0}, {1, 0}, {0, 1}}
+	kingDirs = []dir{{0, -1}, {-1, -1}, {1, -1}, {-1, 0}, {1, 0}, {0, 1}, {-1, 1}, {1, 1}}
+
+	stepDirs = [...][]dir{
+		Pawn:      {{0, -1}},
+		Knight:    {{-1, -2}, {1, -2}},
+		Silver:    {{0, -1}, {-1, -1}, {1, -1}, {-1, 1}, {1, 1}},
+		Gold:      goldDirs,
+		King:      kingDirs,
+		ProPawn:   goldDirs,
+		ProLance:  goldDirs,
+		ProKnight: goldDirs,
+		ProSilver: goldDirs,
+		Horse:     {{0, -1}, {-1, 0}, {1, 0}, {0, 1}},
+		Dragon:    {{-1, -1}, {1, -1}, {-1, 1}, {1, 1}},
+	}
+
+	slideDirs = [...][]dir{
+		Lance:  {{0, -1}},
+		Bishop: {{-1, -1}, {1, -1}, {-1, 1}, {1, 1}},
+		Rook:   {{0, -1}, {-1, 0}, {1, 0}, {0, 1}},
+		Horse:  {{-1, -1}, {1, -1}, {-1, 1}, {1, 1}},
+		Dragon: {{0, -1}, {-1, 0}, {1, 0}, {0, 1}},
+	}
+)
+
+func dirsOf(dirs [][]dir, t PieceType) []dir {
+	if int(t) < len(dirs) {
+		return dirs[t]
+	}
+	return nil
+}
+
+// destinations calls f for each square the piece at from can reach,
+// including squares occupied by own pieces.
+func (p *Position) destinations(from Square, f func(to Square)) {
+	pc := p.Board[from]
+	if pc.Empty() {
+		return
+	}
+
+	sign := 1
+	if pc.Color == White {
+		sign = -1
+	}
+	x, y := from.X(), from.Y()
+
+	for _, d := range dirsOf(stepDirs[:], pc.Type) {
+		tx, ty := x+d.dx*sign, y+d.dy*sign
+		if validXY(tx, ty) {
+			f(NewSquare(tx, ty))
+		}
+	}
+	for _, d := range dirsOf(slideDirs[:], pc.Type) {
+		tx, ty := x+d.dx*sign, y+d.dy*sign
+		for validXY(tx, ty) {
+			to := NewSquare(tx, ty)
+			f(to)
+			if !p.Board[to].Empty() {
+				break
+			}
+			tx, ty = tx+d.dx*sign, ty+d.dy*sign
+		}
+	}
+}
+
+// CanReach reports whether the piece at from can move to "to" regardless of pins.
+func (p *Position) CanReach(from, to Square) bool {
+	ok := false
+	p.destinations(from, func(sq Square) {
+		if sq == to {
+			ok = true
+		}
+	})
+	return ok
+}
+
+func hasDir(dirs []dir, d dir) bool {
+	for _, e := range dirs {
+		if e == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Attacked reports whether sq is attacked by the pieces of c.
+func (p *Position) Attacked(sq Square, c Color) bool {
+	sign := 1
+	if c == White {
+		sign = -1
+	}
+	x, y := sq.X(), sq.Y()
+
+	// The direction d is seen from the attacker, which moves from (x-dx, y-dy) to sq.
+	for _, d := range kingDirs {
+		d2 := dir{d.dx * sign, d.dy * sign}
+		for k := 1; ; k++ {
+			tx, ty := x-d2.dx*k, y-d2.dy*k
+			if !validXY(tx, ty) {
+				break
+			}
+			pc := p.Get(tx, ty)
+			if pc.Empty() {
+				continue
+			}
+			if pc.Color == c {
+				if k == 1 && hasDir(dirsOf(stepDirs[:], pc.Type), d) {
+					return true
+				}
+				if hasDir(dirsOf(slideDirs[:], pc.Type), d) {
+					return true
+				}
+			}
+			break
+		}
+	}
+
+	for _, d := range stepDirs[Knight] {
+		tx, ty := x-d.dx*sign, y-d.dy*sign
+		if !validXY(tx, ty) {
+			continue
+		}
+		if pc := p.Get(tx, ty); pc.Type == Knight && pc.Color == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InCheck reports whether the king of c is attacked.
+func (p *Position) InCheck(c Color) bool {
+	k, ok := p.KingSquare(c)
+	if !ok {
+		return false
+	}
+	return p.Attacked(k, c.Opponent())
+}
+
+// relY returns the rank seen from c, 1 is the farthest rank.
+func relY(y int, c Color) int {
+	if c == White {
+		return 10 - y
+	}
+	return y
+}
+
+// InPromotionZone reports whether sq is in the promotion zone of c.
+func InPromotionZone(sq Square, c Color) bool {
+	return relY(sq.Y(), c) <= 3
+}
+
+// CanPromote reports whether the piece of type t can promote by the move from -> to.
+func CanPromote(t PieceType, c Color, from, to Square) bool {
+	return t.CanPromote() && (InPromotionZone(from, c) || InPromotionZone(to, c))
+}
+
+// DeadEnd reports whether the unpromoted piece of type t has no move at sq.
+func DeadEnd(t PieceType, c Color, sq Square) bool {
+	y := relY(sq.Y(), c)
+	switch t {
+	case Pawn, Lance:
+		return y == 1
+	case Knight:
+		return y <= 2
+	}
+	return false
+}
+
+// Nifu reports whether c already has an unpromoted pawn on the file x.
+func (p *Position) Nifu(c Color, x int) bool {
+	for y := 1; y <= 9; y++ {
+		if pc := p.Get(x, y); pc.Type == Pawn && pc.Color == c {
+			return true
+		}
+	}
+	return false
+}
+
+// PseudoLegalMoves returns the moves of the side to move without checking
+// whether the own king is left in check.
+func (p *Position) PseudoLegalMoves() []Move {
+	side := p.Side
+	var moves []Move
+
+	for i, pc := range p.Board {
+		if pc.Empty() || pc.Color != side {
+			continue
+		}
+		from := Square(i)
+		p.destinations(from, func(to Square) {
+			if t := p.Board[to]; !t.Empty() && t.Color == side {
+				return
+			}
+			if CanPromote(pc.Type, side, from, to) {
+				moves = append(moves, Move{From: from, To: to, Promote: true})
+			}
+			if !DeadEnd(pc.Type, side, to) {
+				moves = append(moves, Move{From: from, To: to})
+			}
+		})
+	}
+
+	for _, t := range HandTypes {
+		if p.Hands[side][t] == 0 {
+			continue
+		}
+		for i, pc := range p.Board {
+			to := Square(i)
+			if !pc.Empty() || DeadEnd(t, side, to) {
+				continue
+			}
+			if t == Pawn && p.Nifu(side, to.X()) {
+				continue
+			}
+			moves = append(moves, Move{To: to, Drop: t})
+		}
+	}
+
+	return moves
+}
+
+func (p *Position) legalMoves(checkUchifuzume bool) []Move {
+	side := p.Side
+	var moves []Move
+	for _, m := range p.PseudoLegalMoves() {
+		next := p.Play(m)
+		if next.InCheck(side) {
+			continue
+		}
+		if checkUchifuzume && m.Drop == Pawn && next.uchifuzume() {
+			continue
+		}
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+// uchifuzume reports whether the side to move is checkmated.
+// It is called for the position just after a pawn drop.
+func (p *Position) uchifuzume() bool {
+	return p.InCheck(p.Side) && len(p.legalMoves(false)) == 0
+}
+
+// LegalMoves returns the legal moves of the side to move.
+func (p *Position) LegalMoves() []Move {
+	return p.legalMoves(true)
+}
+
+// IsCheckmate reports whether the side to move is checkmated.
+func (p *Position) IsCheckmate() bool {
+	return p.InCheck(p.Side) && len(p.LegalMoves()) == 0
+}
@@ -0,0 +1,326 @@
+// Package shogi implements the board and the rules of shogi.
+package shogi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yunomu/kansousen/lib/position"
+)
+
+type Color int8
+
+const (
+	Black Color = iota
+	White
+)
+
+func (c Color) Opponent() Color {
+	return c ^ 1
+}
+
+func (c Color) String() string {
+	if c == White {
+		return "WHITE"
+	}
+	return "BLACK"
+}
+
+type PieceType int8
+
+const (
+	NoPiece PieceType = iota
+	Pawn
+	Lance
+	Knight
+	Silver
+	Gold
+	Bishop
+	Rook
+	King
+	ProPawn
+	ProLance
+	ProKnight
+	ProSilver
+	Horse
+	Dragon
+)
+
+// HandTypes are the piece types which can be in hand.
+var HandTypes = []PieceType{Rook, Bishop, Gold, Silver, Knight, Lance, Pawn}
+
+func (t PieceType) CanPromote() bool {
+	switch t {
+	case Pawn, Lance, Knight, Silver, Bishop, Rook:
+		return true
+	}
+	return false
+}
+
+func (t PieceType) Promoted() bool {
+	return t >= ProPawn
+}
+
+func (t PieceType) Promote() PieceType {
+	switch t {
+	case Pawn:
+		return ProPawn
+	case Lance:
+		return ProLance
+	case Knight:
+		return ProKnight
+	case Silver:
+		return ProSilver
+	case Bishop:
+		return Horse
+	case Rook:
+		return Dragon
+	}
+	return t
+}
+
+func (t PieceType) Unpromote() PieceType {
+	switch t {
+	case ProPawn:
+		return Pawn
+	case ProLance:
+		return Lance
+	case ProKnight:
+		return Knight
+	case ProSilver:
+		return Silver
+	case Horse:
+		return Bishop
+	case Dragon:
+		return Rook
+	}
+	return t
+}
+
+var kanjiNames = map[PieceType]string{
+	Pawn:      "歩",
+	Lance:     "香",
+	Knight:    "桂",
+	Silver:    "銀",
+	Gold:      "金",
+	Bishop:    "角",
+	Rook:      "飛",
+	King:      "玉",
+	ProPawn:   "と",
+	ProLance:  "杏",
+	ProKnight: "圭",
+	ProSilver: "全",
+	Horse:     "馬",
+	Dragon:    "龍",
+}
+
+// Kanji returns the one letter name of t used in diagrams, such as "歩", "杏".
+func (t PieceType) Kanji() string {
+	return kanjiNames[t]
+}
+
+var sfenLetters = map[PieceType]string{
+	Pawn:   "P",
+	Lance:  "L",
+	Knight: "N",
+	Silver: "S",
+	Gold:   "G",
+	Bishop: "B",
+	Rook:   "R",
+	King:   "K",
+}
+
+func typeFromLetter(s string) (PieceType, bool) {
+	for t, l := range sfenLetters {
+		if l == s {
+			return t, true
+		}
+	}
+	return NoPiece, false
+}
+
+type Piece struct {
+	Type  PieceType
+	Color Color
+}
+
+func (p Piece) Empty() bool {
+	return p.Type == NoPiece
+}
+
+// SFEN returns the SFEN notation of p, such as "P", "+b".
+func (p Piece) SFEN() string {
+	if p.Empty() {
+		return ""
+	}
+
+	s := sfenLetters[p.Type.Unpromote()]
+	if p.Color == White {
+		s = string(s[0] + 'a' - 'A')
+	}
+	if p.Type.Promoted() {
+		s = "+" + s
+	}
+	return s
+}
+
+// Square is the index of the board in the SFEN order, from 9a to 1i.
+type Square int8
+
+func NewSquare(x, y int) Square {
+	return Square((y-1)*9 + (9 - x))
+}
+
+func validXY(x, y int) bool {
+	return 1 <= x && x <= 9 && 1 <= y && y <= 9
+}
+
+// X returns the file, 1-9.
+func (s Square) X() int {
+	return 9 - int(s)%9
+}
+
+// Y returns the rank, 1-9.
+func (s Square) Y() int {
+	return int(s)/9 + 1
+}
+
+func (s Square) USI() string {
+	return fmt.Sprintf("%d%c", s.X(), 'a'+s.Y()-1)
+}
+
+type Position struct {
+	Board [81]Piece
+	Hands [2][King]int
+	Side  Color
+}
+
+var ErrInvalidSFEN = errors.New("invalid SFEN")
+
+// FromSFEN parses SFEN position. "sfen" prefix and the move number are optional.
+func FromSFEN(s string) (*Position, error) {
+	pp, err := position.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Position{}
+	if pp.Side == "w" {
+		p.Side = White
+	}
+
+	for r, row := range pp.Board {
+		for f, piece := range row {
+			if piece == "" {
+				continue
+			}
+
+			pc, err := parsePiece(piece)
+			if err != nil {
+				return nil, err
+			}
+			p.Board[r*9+f] = pc
+		}
+	}
+
+	for piece, n := range pp.Hands {
+		pc, err := parsePiece(piece)
+		if err != nil || pc.Type.Promoted() || pc.Type == King {
+			return nil, ErrInvalidSFEN
+		}
+		p.Hands[pc.Color][pc.Type] += n
+	}
+
+	return p, nil
+}
+
+func parsePiece(s string) (Piece, error) {
+	promoted := false
+	if len(s) == 2 && s[0] == '+' {
+		promoted = true
+		s = s[1:]
+	}
+	if len(s) != 1 {
+		return Piece{}, ErrInvalidSFEN
+	}
+
+	color := Black
+	if 'a' <= s[0] && s[0] <= 'z' {
+		color = White
+		s = string(s[0] - 'a' + 'A')
+	}
+
+	t, ok := typeFromLetter(s)
+	if !ok {
+		return Piece{}, ErrInvalidSFEN
+	}
+	if promoted {
+		if !t.CanPromote() {
+			return Piece{}, ErrInvalidSFEN
+		}
+		t = t.Promote()
+	}
+
+	return Piece{Type: t, Color: color}, nil
+}
+
+// NewStartpos returns the initial position of the even game.
+func NewStartpos() *Position {
+	p, err := FromSFEN("lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1")
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// SFEN returns the SFEN position with the move number 1.
+func (p *Position) SFEN() string {
+	pp := &position.Position{
+		Side:  "b",
+		Hands: make(map[string]int),
+	}
+	if p.Side == White {
+		pp.Side = "w"
+	}
+	for i, pc := range p.Board {
+		pp.Board[i/9][i%9] = pc.SFEN()
+	}
+	for c := Black; c <= White; c++ {
+		for _, t := range HandTypes {
+			if n := p.Hands[c][t]; n > 0 {
+				pp.Hands[Piece{Type: t, Color: c}.SFEN()] = n
+			}
+		}
+	}
+
+	return pp.String() + " 1"
+}
+
+// Key returns the compact identifier of the position.
+func (p *Position) Key() string {
+	bs := make([]byte, 0, 81+14+1)
+	for _, pc := range p.Board {
+		bs = append(bs, byte(pc.Type)|byte(pc.Color)<<4)
+	}
+	for c := Black; c <= White; c++ {
+		for _, t := range HandTypes {
+			bs = append(bs, byte(p.Hands[c][t]))
+		}
+	}
+	bs = append(bs, byte(p.Side))
+	return string(bs)
+}
+
+func (p *Position) Get(x, y int) Piece {
+	return p.Board[NewSquare(x, y)]
+}
+
+// KingSquare returns the square of the king of c.
+func (p *Position) KingSquare(c Color) (Square, bool) {
+	for i, pc := range p.Board {
+		if pc.Type == King && pc.Color == c {
+			return Square(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,178 @@
+package shogi
+
+import (
+	"testing"
+)
+
+func TestSFEN(t *testing.T) {
+	for _, s := range []string{
+		"lnsgkgsnl/1r5b1/ppppppppp/9/9/9/PPPPPPPPP/1B5R1/LNSGKGSNL b - 1",
+		"lnsgkgsnl/1r5+B1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/7R1/LNSGKGSNL w B 1",
+		"4k4/9/9/9/9/9/9/9/4K4 b RB2G4Prb2g4s4n4l10p 1",
+	} {
+		p, err := FromSFEN(s)
+		if err != nil {
+			t.Fatalf("FromSFEN(%q): %v", s, err)
+		}
+		if actual := p.SFEN(); actual != s {
+			t.Errorf("SFEN: expected=%q actual=%q", s, actual)
+		}
+	}
+}
+
+func TestUSI(t *testing.T) {
+	for _, s := range []string{"7g7f", "8h2b+", "P*5e", "N*1c"} {
+		m, err := ParseUSI(s)
+		if err != nil {
+			t.Fatalf("ParseUSI(%q): %v", s, err)
+		}
+		if actual := m.USI(); actual != s {
+			t.Errorf("USI: expected=%q actual=%q", s, actual)
+		}
+	}
+
+	for _, s := range []string{"", "7g7", "0a1a", "K*5e", "7g7j"} {
+		if _, err := ParseUSI(s); err == nil {
+			t.Errorf("ParseUSI(%q): expected error", s)
+		}
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	if n := len(NewStartpos().LegalMoves()); n != 30 {
+		t.Errorf("startpos: expected=30 actual=%d", n)
+	}
+
+	p := NewStartpos()
+	for _, s := range []string{"7g7f", "3c3d", "8h2b+"} {
+		m, err := ParseUSI(s)
+		if err != nil {
+			t.Fatalf("ParseUSI: %v", err)
+		}
+		p = p.Play(m)
+	}
+	if p.Hands[Black][Bishop] != 1 {
+		t.Errorf("hand: %v", p.Hands[Black])
+	}
+	if actual, expected := p.SFEN(), "lnsgkgsnl/1r5+B1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/7R1/LNSGKGSNL w B 1"; actual != expected {
+		t.Errorf("SFEN: expected=%q actual=%q", expected, actual)
+	}
+}
+
+func hasMove(moves []Move, usi string) bool {
+	for _, m := range moves {
+		if m.USI() == usi {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLegalMoves_Rules(t *testing.T) {
+	tests := []struct {
+		name     string
+		sfen     string
+		move     string
+		expected bool
+	}{
+		{"nifu", "4k4/9/9/9/9/9/4P4/9/4K4 b P 1", "P*5e", false},
+		{"drop pawn", "4k4/9/9/9/9/9/4P4/9/4K4 b P 1", "P*4e", true},
+		{"dead pawn drop", "4k4/9/9/9/9/9/9/9/4K4 b P 1", "P*1a", false},
+		{"dead knight drop", "4k4/9/9/9/9/9/9/9/4K4 b N 1", "N*1b", false},
+		{"knight must promote", "4k4/9/9/4N4/9/9/9/9/4K4 b - 1", "5d4b", false},
+		{"knight promote", "4k4/9/9/4N4/9/9/9/9/4K4 b - 1", "5d4b+", true},
+		{"pinned", "4k4/9/9/9/4r4/9/9/4G4/4K4 b - 1", "5h4h", false},
+		{"uchifuzume", "kn7/9/1G7/9/9/9/9/9/8K b P 1", "P*9b", false},
+		{"pawn check", "k8/9/9/9/9/9/9/9/8K b P 1", "P*9b", true},
+	}
+	for _, test := range tests {
+		p, err := FromSFEN(test.sfen)
+		if err != nil {
+			t.Fatalf("%s: FromSFEN: %v", test.name, err)
+		}
+		if actual := hasMove(p.LegalMoves(), test.move); actual != test.expected {
+			t.Errorf("%s: %s expected=%v actual=%v", test.name, test.move, test.expected, actual)
+		}
+	}
+}
+
+func TestIsCheckmate(t *testing.T) {
+	p, err := FromSFEN("4k4/4G4/4P4/9/9/9/9/9/4K4 w - 1")
+	if err != nil {
+		t.Fatalf("FromSFEN: %v", err)
+	}
+	if !p.IsCheckmate() {
+		t.Errorf("expected checkmate")
+	}
+
+	p, err = FromSFEN("4k4/4G4/9/9/9/9/9/9/4K4 w - 1")
+	if err != nil {
+		t.Fatalf("FromSFEN: %v", err)
+	}
+	if p.IsCheckmate() {
+		t.Errorf("expected not checkmate")
+	}
+}
+
+func TestAttacked(t *testing.T) {
+	for _, s := range []string{
+		"lnsgkgsnl/1r5+B1/pppppp1pp/6p2/9/2P6/PP1PPPPPP/7R1/LNSGKGSNL w B 1",
+		"ln1g3+Rl/2sk1s3/p1ppppn1p/6p2/1p5P1/2P1P4/PP1P1PP1P/2S1+b2S1/LN1GKG1NL b BGrp 1",
+	} {
+		p, err := FromSFEN(s)
+		if err != nil {
+			t.Fatalf("FromSFEN: %v", err)
+		}
+
+		for sq := Square(0); sq < 81; sq++ {
+			for c := Black; c <= White; c++ {
+				expected := false
+				for i, pc := range p.Board {
+					if !pc.Empty() && pc.Color == c && p.CanReach(Square(i), sq) {
+						expected = true
+					}
+				}
+				if actual := p.Attacked(sq, c); actual != expected {
+					t.Errorf("%s: Attacked(%s, %v): expected=%v actual=%v", s, sq.USI(), c, expected, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		sfen     string
+		move     string
+		expected error
+	}{
+		{"4k4/9/9/9/9/9/4P4/9/4K4 b P 1", "P*4e", nil},
+		{"4k4/9/9/9/9/9/4P4/9/4K4 b P 1", "P*5e", ErrNifu},
+		{"4k4/9/9/9/9/9/4P4/9/4K4 b - 1", "P*4e", ErrNoPieceInHand},
+		{"4k4/9/9/9/9/9/4P4/9/4K4 b P 1", "P*5g", ErrDropOnPiece},
+		{"4k4/9/9/9/9/9/9/9/4K4 b N 1", "N*1b", ErrDeadPiece},
+		{"kn7/9/1G7/9/9/9/9/9/8K b P 1", "P*9b", ErrUchifuzume},
+		{"4k4/9/9/9/9/9/9/9/4K4 b - 1", "5e5d", ErrNoPiece},
+		{"4k4/9/9/9/9/9/9/9/4K4 b - 1", "5a5b", ErrNotOwnPiece},
+		{"4k4/9/9/9/9/9/4P4/4G4/4K4 b - 1", "5h5g", ErrOwnPieceOnDst},
+		{"4k4/9/9/9/9/9/4P4/9/4K4 b - 1", "5g5e", ErrUnreachable},
+		{"4k4/9/9/9/9/9/4P4/9/4K4 b - 1", "5g5f+", ErrCannotPromote},
+		{"4k4/9/4P4/9/9/9/9/9/4K4 b - 1", "5c5b+", nil},
+		{"3k5/9/4P4/9/9/9/9/9/4K4 b - 1", "5c5b", nil},
+		{"3k5/4P4/9/9/9/9/9/9/4K4 b - 1", "5b5a", ErrMustPromote},
+		{"4k4/9/9/9/4r4/9/9/4G4/4K4 b - 1", "5h4h", ErrLeftInCheck},
+	}
+	for _, test := range tests {
+		p, err := FromSFEN(test.sfen)
+		if err != nil {
+			t.Fatalf("FromSFEN: %v", err)
+		}
+		m, err := ParseUSI(test.move)
+		if err != nil {
+			t.Fatalf("ParseUSI: %v", err)
+		}
+		if actual := p.Validate(m); actual != test.expected {
+			t.Errorf("%s %s: expected=%v actual=%v", test.sfen, test.move, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package shogi
+
+import (
+	"errors"
+)
+
+var (
+	ErrNoPiece       = errors.New("no piece on the source square")
+	ErrNotOwnPiece   = errors.New("the piece is not of the side to move")
+	ErrOwnPieceOnDst = errors.New("own piece on the destination square")
+	ErrUnreachable   = errors.New("the piece cannot move to the destination square")
+	ErrCannotPromote = errors.New("the piece cannot promote")
+	ErrMustPromote   = errors.New("the piece must promote")
+	ErrNoPieceInHand = errors.New("no piece in hand")
+	ErrDropOnPiece   = errors.New("drop on the occupied square")
+	ErrDeadPiece     = errors.New("the piece has no move on the square")
+	ErrNifu          = errors.New("two pawns on the same file (nifu)")
+	ErrUchifuzume    = errors.New("checkmate by pawn drop (uchifuzume)")
+	ErrLeftInCheck   = errors.New("the king is left in check")
+)
+
+// Validate reports why m is illegal in the position. It returns nil for the legal move.
+func (p *Position) Validate(m Move) error {
+	side := p.Side
+
+	if m.IsDrop() {
+		switch {
+		case m.Drop == King || m.Drop.Promoted() || p.Hands[side][m.Drop] == 0:
+			return ErrNoPieceInHand
+		case !p.Board[m.To].Empty():
+			return ErrDropOnPiece
+		case DeadEnd(m.Drop, side, m.To):
+			return ErrDeadPiece
+		case m.Drop == Pawn && p.Nifu(side, m.To.X()):
+			return ErrNifu
+		}
+	} else {
+		pc := p.Board[m.From]
+		switch {
+		case pc.Empty():
+			return ErrNoPiece
+		case pc.Color != side:
+			return ErrNotOwnPiece
+		case !p.Board[m.To].Empty() && p.Board[m.To].Color == side:
+			return ErrOwnPieceOnDst
+		case !p.CanReach(m.From, m.To):
+			return ErrUnreachable
+		case m.Promote && !CanPromote(pc.Type, side, m.From, m.To):
+			return ErrCannotPromote
+		case !m.Promote && DeadEnd(pc.Type, side, m.To):
+			return ErrMustPromote
+		}
+	}
+
+	next := p.Play(m)
+	if next.InCheck(side) {
+		return ErrLeftInCheck
+	}
+	if m.Drop == Pawn && next.uchifuzume() {
+		return ErrUchifuzume
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package team
+
+import (
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+// PublicPoolId is the id of the team which everyone can join.
+const PublicPoolId = "public"
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMember reports whether userId is a member of t.
+// Invited users are not members until they accept the invitation.
+func IsMember(t *documentpb.Team, userId string) bool {
+	if userId == "" {
+		return false
+	}
+
+	return contains(t.GetMemberUserIds(), userId)
+}
+
+// IsInvited reports whether userId is invited to t and has not accepted yet.
+func IsInvited(t *documentpb.Team, userId string) bool {
+	if userId == "" {
+		return false
+	}
+
+	return contains(t.GetInvitedUserIds(), userId)
+}
+
+// AddMembers adds users to t. Users who are already members are ignored.
+func AddMembers(t *documentpb.Team, userIds ...string) {
+	for _, userId := range userIds {
+		if userId == "" || IsMember(t, userId) {
+			continue
+		}
+		t.MemberUserIds = append(t.MemberUserIds, userId)
+	}
+}
+
+// Invite invites users to t. Users who are already members or invited are ignored.
+func Invite(t *documentpb.Team, userIds ...string) {
+	for _, userId := range userIds {
+		if userId == "" || IsMember(t, userId) || IsInvited(t, userId) {
+			continue
+		}
+		t.InvitedUserIds = append(t.InvitedUserIds, userId)
+	}
+}
+
+// Accept makes the invited user a member of t.
+// It returns false if userId is not invited.
+func Accept(t *documentpb.Team, userId string) bool {
+	if !IsInvited(t, userId) {
+		return false
+	}
+
+	t.InvitedUserIds = remove(t.GetInvitedUserIds(), map[string]struct{}{userId: {}})
+	AddMembers(t, userId)
+	return true
+}
+
+func remove(ss []string, m map[string]struct{}) []string {
+	var ret []string
+	for _, s := range ss {
+		if _, ok := m[s]; ok {
+			continue
+		}
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// RemoveMembers removes users and their invitations from t. The owner is never removed.
+func RemoveMembers(t *documentpb.Team, userIds ...string) {
+	m := make(map[string]struct{})
+	for _, userId := range userIds {
+		if userId == t.GetOwnerUserId() {
+			continue
+		}
+		m[userId] = struct{}{}
+	}
+
+	t.MemberUserIds = remove(t.GetMemberUserIds(), m)
+	t.InvitedUserIds = remove(t.GetInvitedUserIds(), m)
+}
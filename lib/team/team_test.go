@@ -0,0 +1,73 @@
+package team
+
+import (
+	"reflect"
+	"testing"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func TestMembers(t *testing.T) {
+	team := &documentpb.Team{
+		OwnerUserId:   "owner",
+		MemberUserIds: []string{"owner"},
+	}
+
+	AddMembers(team, "a", "", "b", "a", "owner")
+
+	expected := []string{"owner", "a", "b"}
+	if !reflect.DeepEqual(team.MemberUserIds, expected) {
+		t.Errorf("AddMembers: expected=%v actual=%v", expected, team.MemberUserIds)
+	}
+
+	RemoveMembers(team, "owner", "a", "unknown")
+
+	expected = []string{"owner", "b"}
+	if !reflect.DeepEqual(team.MemberUserIds, expected) {
+		t.Errorf("RemoveMembers: expected=%v actual=%v", expected, team.MemberUserIds)
+	}
+
+	if !IsMember(team, "b") || IsMember(team, "a") || IsMember(team, "") {
+		t.Errorf("IsMember: %v", team.MemberUserIds)
+	}
+}
+
+func TestInvite(t *testing.T) {
+	team := &documentpb.Team{
+		OwnerUserId:   "owner",
+		MemberUserIds: []string{"owner"},
+	}
+
+	Invite(team, "a", "", "b", "a", "owner")
+
+	expected := []string{"a", "b"}
+	if !reflect.DeepEqual(team.InvitedUserIds, expected) {
+		t.Errorf("Invite: expected=%v actual=%v", expected, team.InvitedUserIds)
+	}
+	if IsMember(team, "a") || !IsInvited(team, "a") {
+		t.Errorf("invited user is a member: %v", team)
+	}
+
+	if Accept(team, "unknown") {
+		t.Errorf("Accept: not invited user accepted")
+	}
+	if !Accept(team, "a") {
+		t.Errorf("Accept: invited user not accepted")
+	}
+
+	expected = []string{"owner", "a"}
+	if !reflect.DeepEqual(team.MemberUserIds, expected) {
+		t.Errorf("Accept: expected=%v actual=%v", expected, team.MemberUserIds)
+	}
+	if IsInvited(team, "a") {
+		t.Errorf("Accept: invitation remains: %v", team.InvitedUserIds)
+	}
+
+	RemoveMembers(team, "b")
+	if len(team.InvitedUserIds) != 0 {
+		t.Errorf("RemoveMembers: invitation remains: %v", team.InvitedUserIds)
+	}
+	if Accept(team, "b") {
+		t.Errorf("Accept: removed invitation accepted")
+	}
+}
@@ -0,0 +1,126 @@
+package tsume
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+)
+
+// Move names use the two letters style for the promoted pieces.
+var movePieceNames = map[shogi.PieceType]string{
+	shogi.ProLance:  "成香",
+	shogi.ProKnight: "成桂",
+	shogi.ProSilver: "成銀",
+}
+
+var (
+	fileNames = []string{"", "１", "２", "３", "４", "５", "６", "７", "８", "９"}
+	rankNames = []string{"", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	numNames  = []string{"", "", "二", "三", "四", "五", "六", "七", "八", "九", "十",
+		"十一", "十二", "十三", "十四", "十五", "十六", "十七", "十八"}
+)
+
+func handString(p *shogi.Position, c shogi.Color) string {
+	var b strings.Builder
+	for _, t := range shogi.HandTypes {
+		n := p.Hands[c][t]
+		if n == 0 {
+			continue
+		}
+		b.WriteString(t.Kanji())
+		if n < len(numNames) {
+			b.WriteString(numNames[n])
+		}
+		b.WriteString("　")
+	}
+	if b.Len() == 0 {
+		return "なし"
+	}
+	return b.String()
+}
+
+// WriteBOD writes the position in BOD format.
+func WriteBOD(w io.Writer, p *shogi.Position) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "後手の持駒：%s\n", handString(p, shogi.White))
+	b.WriteString("  ９ ８ ７ ６ ５ ４ ３ ２ １\n")
+	b.WriteString("+---------------------------+\n")
+	for y := 1; y <= 9; y++ {
+		b.WriteString("|")
+		for x := 9; x >= 1; x-- {
+			pc := p.Get(x, y)
+			switch {
+			case pc.Empty():
+				b.WriteString(" ・")
+			case pc.Color == shogi.White:
+				b.WriteString("v" + pc.Type.Kanji())
+			default:
+				b.WriteString(" " + pc.Type.Kanji())
+			}
+		}
+		fmt.Fprintf(&b, "|%s\n", rankNames[y])
+	}
+	b.WriteString("+---------------------------+\n")
+	fmt.Fprintf(&b, "先手の持駒：%s\n", handString(p, shogi.Black))
+	if p.Side == shogi.White {
+		b.WriteString("後手番\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// MoveString returns the move in KIF notation such as "７六歩(77)".
+// prev is the previous move, used for "同".
+func MoveString(p *shogi.Position, m shogi.Move, prev *shogi.Move) string {
+	var b strings.Builder
+
+	if prev != nil && prev.To == m.To {
+		b.WriteString("同　")
+	} else {
+		b.WriteString(fileNames[m.To.X()] + rankNames[m.To.Y()])
+	}
+
+	if m.IsDrop() {
+		b.WriteString(m.Drop.Kanji() + "打")
+		return b.String()
+	}
+
+	t := p.Board[m.From].Type
+	if name, ok := movePieceNames[t]; ok {
+		b.WriteString(name)
+	} else {
+		b.WriteString(t.Kanji())
+	}
+	if m.Promote {
+		b.WriteString("成")
+	}
+	fmt.Fprintf(&b, "(%d%d)", m.From.X(), m.From.Y())
+
+	return b.String()
+}
+
+// WriteKIF writes the position and the moves from it in KIF format.
+func WriteKIF(w io.Writer, p *shogi.Position, moves []shogi.Move, headers ...string) error {
+	var b strings.Builder
+	for _, h := range headers {
+		b.WriteString(h + "\n")
+	}
+	if err := WriteBOD(&b, p); err != nil {
+		return err
+	}
+
+	b.WriteString("手数----指手---------消費時間--\n")
+	var prev *shogi.Move
+	for i, m := range moves {
+		fmt.Fprintf(&b, "%4d %s\n", i+1, MoveString(p, m, prev))
+		p = p.Play(m)
+		prev = &moves[i]
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
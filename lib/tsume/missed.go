@@ -0,0 +1,74 @@
+package tsume
+
+import (
+	"context"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+// MissedMate is the position where the side to move had a mate
+// but the played move did not keep it.
+type MissedMate struct {
+	// Seq is the seq of the step of the position.
+	Seq      int32
+	Position *shogi.Position
+	Mate     []shogi.Move
+	// Played is the USI move actually played. Empty if the game ended.
+	Played string
+}
+
+// FindMissedMates searches mates within maxPly plies in each position of steps.
+// It stops when ctx is done.
+func FindMissedMates(ctx context.Context, steps []*documentpb.Step, maxPly int, opts ...SolverOption) ([]*MissedMate, error) {
+	s := NewSolver(opts...)
+
+	var ret []*MissedMate
+	for i, step := range steps {
+		if step.GetSeq() != 0 && step.GetSfen() == "" {
+			// The step of the finished status has the same position as the previous one.
+			continue
+		}
+
+		p, err := shogi.FromSFEN(step.GetPosition())
+		if err != nil {
+			return nil, err
+		}
+
+		mate, err := s.Solve(ctx, p, maxPly)
+		if err != nil {
+			return nil, err
+		}
+		if mate == nil {
+			continue
+		}
+
+		var played string
+		if i+1 < len(steps) {
+			played = steps[i+1].GetSfen()
+		}
+		if played != "" {
+			m, err := shogi.ParseUSI(played)
+			if err != nil {
+				return nil, err
+			}
+			kept, err := s.IsMate(ctx, p.Play(m), maxPly-1)
+			if err != nil {
+				return nil, err
+			}
+			if kept {
+				continue
+			}
+		}
+
+		ret = append(ret, &MissedMate{
+			Seq:      step.GetSeq(),
+			Position: p,
+			Mate:     mate,
+			Played:   played,
+		})
+	}
+
+	return ret, nil
+}
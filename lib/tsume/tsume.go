@@ -0,0 +1,271 @@
+// Package tsume searches short mates with df-pn.
+package tsume
+
+import (
+	"context"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+)
+
+const (
+	infinity = 1 << 30
+
+	// the context is checked every ctxCheckInterval nodes.
+	ctxCheckInterval = 256
+
+	// DefaultMaxNodes is the default node limit of a search.
+	DefaultMaxNodes = 20000
+)
+
+type entry struct {
+	pn, dn int
+}
+
+type ttKey struct {
+	pos   string
+	depth int
+}
+
+type Solver struct {
+	maxNodes int
+
+	ctx   context.Context
+	err   error
+	nodes int
+	tt    map[ttKey]entry
+}
+
+type SolverOption func(*Solver)
+
+// SetMaxNodes sets the node limit of each Solve.
+func SetMaxNodes(n int) SolverOption {
+	return func(s *Solver) {
+		s.maxNodes = n
+	}
+}
+
+func NewSolver(opts ...SolverOption) *Solver {
+	s := &Solver{
+		maxNodes: DefaultMaxNodes,
+	}
+	for _, f := range opts {
+		f(s)
+	}
+	return s
+}
+
+func checks(p *shogi.Position) []shogi.Move {
+	var ret []shogi.Move
+	for _, m := range p.LegalMoves() {
+		if p.Play(m).InCheck(p.Side.Opponent()) {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
+
+func (s *Solver) children(p *shogi.Position, or bool) []shogi.Move {
+	if or {
+		return checks(p)
+	}
+	return p.LegalMoves()
+}
+
+func (s *Solver) lookup(p *shogi.Position, depth int) entry {
+	if e, ok := s.tt[ttKey{p.Key(), depth}]; ok {
+		return e
+	}
+	return entry{1, 1}
+}
+
+func add(a, b int) int {
+	if a+b >= infinity {
+		return infinity
+	}
+	return a + b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// mid expands the node until the proof number or the disproof number
+// reaches the threshold. depth is the number of remaining plies.
+func (s *Solver) mid(p *shogi.Position, depth int, or bool, thpn, thdn int) entry {
+	key := ttKey{p.Key(), depth}
+	s.nodes++
+	if s.nodes%ctxCheckInterval == 0 && s.err == nil {
+		s.err = s.ctx.Err()
+	}
+
+	if or && depth == 0 {
+		e := entry{infinity, 0}
+		s.tt[key] = e
+		return e
+	}
+
+	moves := s.children(p, or)
+	if len(moves) == 0 {
+		e := entry{0, infinity}
+		if or {
+			e = entry{infinity, 0}
+		}
+		s.tt[key] = e
+		return e
+	}
+	if depth == 0 {
+		e := entry{infinity, 0}
+		s.tt[key] = e
+		return e
+	}
+
+	nexts := make([]*shogi.Position, len(moves))
+	for i, m := range moves {
+		nexts[i] = p.Play(m)
+	}
+
+	for {
+		// For the OR node, "pn" of the children is minimized and "dn" is summed up.
+		// For the AND node, swap them.
+		best, second := -1, infinity
+		var cur entry
+		var bestChild entry
+		minPart, sumPart := infinity, 0
+		for i, next := range nexts {
+			c := s.lookup(next, depth-1)
+			m, n := c.pn, c.dn
+			if !or {
+				m, n = c.dn, c.pn
+			}
+			if m < minPart {
+				second = minPart
+				minPart = m
+				best = i
+				bestChild = c
+			} else if m < second {
+				second = m
+			}
+			sumPart = add(sumPart, n)
+		}
+		if or {
+			cur = entry{minPart, sumPart}
+		} else {
+			cur = entry{sumPart, minPart}
+		}
+		s.tt[key] = cur
+
+		if cur.pn >= thpn || cur.dn >= thdn || cur.pn == 0 || cur.dn == 0 || s.stopped() {
+			return cur
+		}
+
+		if or {
+			s.mid(nexts[best], depth-1, !or,
+				min(thpn, add(second, 1)),
+				add(thdn-cur.dn, bestChild.dn),
+			)
+		} else {
+			s.mid(nexts[best], depth-1, !or,
+				add(thpn-cur.pn, bestChild.pn),
+				min(thdn, add(second, 1)),
+			)
+		}
+	}
+}
+
+// pv returns the mating sequence from the proved node.
+// The attacker chooses the shortest mate and the defender chooses the longest.
+func (s *Solver) pv(p *shogi.Position, depth int, or bool) ([]shogi.Move, bool) {
+	if depth == 0 {
+		return nil, !or && len(p.LegalMoves()) == 0
+	}
+
+	var ret []shogi.Move
+	found := false
+	for _, m := range s.children(p, or) {
+		next := p.Play(m)
+		if s.lookup(next, depth-1).pn != 0 {
+			if !or {
+				return nil, false
+			}
+			continue
+		}
+
+		line, ok := s.pv(next, depth-1, !or)
+		if !ok {
+			continue
+		}
+		line = append([]shogi.Move{m}, line...)
+
+		if !found || (or && len(line) < len(ret)) || (!or && len(line) > len(ret)) {
+			ret = line
+			found = true
+		}
+	}
+
+	if !or && !found {
+		// No evasion; checkmated.
+		return nil, len(p.LegalMoves()) == 0
+	}
+	return ret, found
+}
+
+// stopped reports whether the search reached the node limit or the context is done.
+func (s *Solver) stopped() bool {
+	return s.nodes > s.maxNodes || s.err != nil
+}
+
+func (s *Solver) reset(ctx context.Context) {
+	s.ctx = ctx
+	s.err = nil
+	s.nodes = 0
+	s.tt = make(map[ttKey]entry)
+}
+
+// Solve searches the mate of the side to move within maxPly plies.
+// It returns the mating sequence, or nil if no mate was found.
+// The error is of the context.
+func (s *Solver) Solve(ctx context.Context, p *shogi.Position, maxPly int) ([]shogi.Move, error) {
+	s.reset(ctx)
+
+	for depth := 1; depth <= maxPly; depth += 2 {
+		e := s.mid(p, depth, true, infinity, infinity)
+		if s.err != nil {
+			return nil, s.err
+		}
+		if e.pn == 0 {
+			if line, ok := s.pv(p, depth, true); ok {
+				return line, nil
+			}
+			return nil, nil
+		}
+		if s.stopped() {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// IsMate reports whether the side to move is mated within maxPly plies,
+// that is, every move leads to the mate of the opponent.
+// The error is of the context.
+func (s *Solver) IsMate(ctx context.Context, p *shogi.Position, maxPly int) (bool, error) {
+	s.reset(ctx)
+
+	for depth := 0; depth <= maxPly; depth += 2 {
+		e := s.mid(p, depth, false, infinity, infinity)
+		if s.err != nil {
+			return false, s.err
+		}
+		if e.pn == 0 {
+			return true, nil
+		}
+		if s.stopped() {
+			return false, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,115 @@
+package tsume
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yunomu/kansousen/lib/shogi"
+
+	documentpb "github.com/yunomu/kansousen/proto/document"
+)
+
+func usiMoves(moves []shogi.Move) []string {
+	var ret []string
+	for _, m := range moves {
+		ret = append(ret, m.USI())
+	}
+	return ret
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		sfen     string
+		expected string
+	}{
+		{"4k4/9/4P4/9/9/9/9/9/4K4 b G 1", "G*5b"},
+		{"7kl/9/6G1p/9/9/9/9/9/4K4 b RS 1", "R*9a 2a1b S*2a"},
+		{"4k4/9/9/9/9/9/9/9/4K4 b 2G 1", ""},
+	}
+	for _, test := range tests {
+		p, err := shogi.FromSFEN(test.sfen)
+		if err != nil {
+			t.Fatalf("FromSFEN: %v", err)
+		}
+
+		mate, err := NewSolver().Solve(context.Background(), p, 7)
+		if err != nil {
+			t.Fatalf("Solve: %v", err)
+		}
+		if actual := strings.Join(usiMoves(mate), " "); actual != test.expected {
+			t.Errorf("%s: expected=%q actual=%q", test.sfen, test.expected, actual)
+		}
+
+		for _, m := range mate {
+			p = p.Play(m)
+		}
+		if mate != nil && !p.IsCheckmate() {
+			t.Errorf("%s: not checkmate: %s", test.sfen, p.SFEN())
+		}
+	}
+}
+
+func TestSolve_Canceled(t *testing.T) {
+	p, err := shogi.FromSFEN("4k4/9/9/9/9/9/9/9/4K4 b 2G 1")
+	if err != nil {
+		t.Fatalf("FromSFEN: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewSolver().Solve(ctx, p, 7); err != context.Canceled {
+		t.Errorf("expected context.Canceled: %v", err)
+	}
+}
+
+func TestFindMissedMates(t *testing.T) {
+	steps := []*documentpb.Step{
+		{Seq: 0, Position: "7kl/9/6G1p/9/9/9/9/9/4K4 b RS 1"},
+		{Seq: 1, Position: "7kl/9/6G1p/9/9/9/9/4K4/9 w RS 1", Sfen: "5i5h"},
+		{Seq: 2, Position: "8l/7k1/6G1p/9/9/9/9/4K4/9 b RS 1", Sfen: "2a2b"},
+		{Seq: 3, Position: "8l/7k1/6G1p/9/9/9/9/4K4/9 b RS 1", FinishedStatus: documentpb.FinishedStatus_SURRENDER},
+	}
+
+	missed, err := FindMissedMates(context.Background(), steps, 3)
+	if err != nil {
+		t.Fatalf("FindMissedMates: %v", err)
+	}
+	if len(missed) == 0 {
+		t.Fatalf("no missed mate")
+	}
+	if m := missed[0]; m.Seq != 0 || m.Played != "5i5h" || len(m.Mate) != 3 {
+		t.Errorf("missed[0]: seq=%d played=%s mate=%v", m.Seq, m.Played, usiMoves(m.Mate))
+	}
+}
+
+func TestWriteKIF(t *testing.T) {
+	p, err := shogi.FromSFEN("7kl/9/6G1p/9/9/9/9/9/4K4 b RS 1")
+	if err != nil {
+		t.Fatalf("FromSFEN: %v", err)
+	}
+	mate, err := NewSolver().Solve(context.Background(), p, 3)
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+
+	var b strings.Builder
+	if err := WriteKIF(&b, p, mate); err != nil {
+		t.Fatalf("WriteKIF: %v", err)
+	}
+
+	for _, expected := range []string{
+		"後手の持駒：なし\n",
+		"| ・ ・ ・ ・ ・ ・ ・v玉v香|一\n",
+		"| ・ ・ ・ ・ ・ ・ 金 ・v歩|三\n",
+		"先手の持駒：飛　銀　\n",
+		"   1 ９一飛打\n",
+		"   2 １二玉(21)\n",
+		"   3 ２一銀打\n",
+	} {
+		if !strings.Contains(b.String(), expected) {
+			t.Errorf("%q is not contained:\n%s", expected, b.String())
+		}
+	}
+}
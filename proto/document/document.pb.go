@@ -311,6 +311,99 @@ func (Piece_Id) EnumDescriptor() ([]byte, []int) {
 	return file_proto_document_proto_rawDescGZIP(), []int{3, 0}
 }
 
+type Share_Role int32
+
+const (
+	Share_READ Share_Role = 0
+)
+
+// Enum value maps for Share_Role.
+var (
+	Share_Role_name = map[int32]string{
+		0: "READ",
+	}
+	Share_Role_value = map[string]int32{
+		"READ": 0,
+	}
+)
+
+func (x Share_Role) Enum() *Share_Role {
+	p := new(Share_Role)
+	*p = x
+	return p
+}
+
+func (x Share_Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Share_Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_document_proto_enumTypes[4].Descriptor()
+}
+
+func (Share_Role) Type() protoreflect.EnumType {
+	return &file_proto_document_proto_enumTypes[4]
+}
+
+func (x Share_Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Share_Role.Descriptor instead.
+func (Share_Role) EnumDescriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type Repetition_Result int32
+
+const (
+	Repetition_NONE Repetition_Result = 0
+	Repetition_DRAW Repetition_Result = 1
+	// repetition by continuous checks (連続王手の千日手). the checking player loses.
+	Repetition_PERPETUAL_CHECK Repetition_Result = 2
+)
+
+// Enum value maps for Repetition_Result.
+var (
+	Repetition_Result_name = map[int32]string{
+		0: "NONE",
+		1: "DRAW",
+		2: "PERPETUAL_CHECK",
+	}
+	Repetition_Result_value = map[string]int32{
+		"NONE":            0,
+		"DRAW":            1,
+		"PERPETUAL_CHECK": 2,
+	}
+)
+
+func (x Repetition_Result) Enum() *Repetition_Result {
+	p := new(Repetition_Result)
+	*p = x
+	return p
+}
+
+func (x Repetition_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Repetition_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_document_proto_enumTypes[5].Descriptor()
+}
+
+func (Repetition_Result) Type() protoreflect.EnumType {
+	return &file_proto_document_proto_enumTypes[5]
+}
+
+func (x Repetition_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Repetition_Result.Descriptor instead.
+func (Repetition_Result) EnumDescriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{7, 0}
+}
+
 type Player struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -543,6 +636,61 @@ func (x *Pos) GetY() int32 {
 	return 0
 }
 
+type Share struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string     `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role   Share_Role `protobuf:"varint,2,opt,name=role,proto3,enum=document.Share_Role" json:"role,omitempty"`
+}
+
+func (x *Share) Reset() {
+	*x = Share{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_document_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Share) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Share) ProtoMessage() {}
+
+func (x *Share) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_document_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Share.ProtoReflect.Descriptor instead.
+func (*Share) Descriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Share) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Share) GetRole() Share_Role {
+	if x != nil {
+		return x.Role
+	}
+	return Share_READ
+}
+
 type Kifu struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -560,12 +708,28 @@ type Kifu struct {
 	Aliases     []string          `protobuf:"bytes,11,rep,name=aliases,proto3" json:"aliases,omitempty"`
 	CreatedTs   int64             `protobuf:"varint,12,opt,name=created_ts,json=createdTs,proto3" json:"created_ts,omitempty"`
 	Note        string            `protobuf:"bytes,13,opt,name=note,proto3" json:"note,omitempty"`
+	Tags        []string          `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	// users other than the owner who can access the kifu.
+	Shares []*Share `protobuf:"bytes,15,rep,name=shares,proto3" json:"shares,omitempty"`
+	// anyone who has the token can read the kifu. empty if not published.
+	LinkToken string `protobuf:"bytes,16,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
+	// union of features of the steps. see lib/pattern.
+	Features uint64 `protobuf:"varint,17,opt,name=features,proto3" json:"features,omitempty"`
+	// openings (戦型) of each side. see lib/senkei.
+	BlackStrategies []string `protobuf:"bytes,18,rep,name=black_strategies,json=blackStrategies,proto3" json:"black_strategies,omitempty"`
+	WhiteStrategies []string `protobuf:"bytes,19,rep,name=white_strategies,json=whiteStrategies,proto3" json:"white_strategies,omitempty"`
+	// openings (戦型) of the game, which are not of either side, e.g. 角換わり.
+	GameStrategies []string `protobuf:"bytes,22,rep,name=game_strategies,json=gameStrategies,proto3" json:"game_strategies,omitempty"`
+	// fourfold repetition (千日手) detected by replaying the steps.
+	Repetition *Repetition `protobuf:"bytes,20,opt,name=repetition,proto3" json:"repetition,omitempty"`
+	// inconsistencies between the recorded result and the steps.
+	Warnings []string `protobuf:"bytes,21,rep,name=warnings,proto3" json:"warnings,omitempty"`
 }
 
 func (x *Kifu) Reset() {
 	*x = Kifu{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_document_proto_msgTypes[5]
+		mi := &file_proto_document_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -578,7 +742,7 @@ func (x *Kifu) String() string {
 func (*Kifu) ProtoMessage() {}
 
 func (x *Kifu) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_document_proto_msgTypes[5]
+	mi := &file_proto_document_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +755,7 @@ func (x *Kifu) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Kifu.ProtoReflect.Descriptor instead.
 func (*Kifu) Descriptor() ([]byte, []int) {
-	return file_proto_document_proto_rawDescGZIP(), []int{5}
+	return file_proto_document_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Kifu) GetUserId() string {
@@ -678,6 +842,134 @@ func (x *Kifu) GetNote() string {
 	return ""
 }
 
+func (x *Kifu) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Kifu) GetShares() []*Share {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+func (x *Kifu) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
+}
+
+func (x *Kifu) GetFeatures() uint64 {
+	if x != nil {
+		return x.Features
+	}
+	return 0
+}
+
+func (x *Kifu) GetBlackStrategies() []string {
+	if x != nil {
+		return x.BlackStrategies
+	}
+	return nil
+}
+
+func (x *Kifu) GetWhiteStrategies() []string {
+	if x != nil {
+		return x.WhiteStrategies
+	}
+	return nil
+}
+
+func (x *Kifu) GetGameStrategies() []string {
+	if x != nil {
+		return x.GameStrategies
+	}
+	return nil
+}
+
+func (x *Kifu) GetRepetition() *Repetition {
+	if x != nil {
+		return x.Repetition
+	}
+	return nil
+}
+
+func (x *Kifu) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type Repetition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result Repetition_Result `protobuf:"varint,1,opt,name=result,proto3,enum=document.Repetition_Result" json:"result,omitempty"`
+	// seq of the step where the position appeared for the fourth time.
+	Seq int32 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// valid if result is PERPETUAL_CHECK.
+	CheckingPlayer Player_Order `protobuf:"varint,3,opt,name=checking_player,json=checkingPlayer,proto3,enum=document.Player_Order" json:"checking_player,omitempty"`
+}
+
+func (x *Repetition) Reset() {
+	*x = Repetition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_document_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Repetition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repetition) ProtoMessage() {}
+
+func (x *Repetition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_document_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repetition.ProtoReflect.Descriptor instead.
+func (*Repetition) Descriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Repetition) GetResult() Repetition_Result {
+	if x != nil {
+		return x.Result
+	}
+	return Repetition_NONE
+}
+
+func (x *Repetition) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Repetition) GetCheckingPlayer() Player_Order {
+	if x != nil {
+		return x.CheckingPlayer
+	}
+	return Player_BLACK
+}
+
 type Step struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -698,12 +990,16 @@ type Step struct {
 	TimestampSec   int32             `protobuf:"varint,14,opt,name=timestamp_sec,json=timestampSec,proto3" json:"timestamp_sec,omitempty"`
 	ThinkingSec    int32             `protobuf:"varint,15,opt,name=thinking_sec,json=thinkingSec,proto3" json:"thinking_sec,omitempty"`
 	Notes          []string          `protobuf:"bytes,16,rep,name=notes,proto3" json:"notes,omitempty"`
+	// features of the position. see lib/pattern.
+	Features uint64 `protobuf:"varint,17,opt,name=features,proto3" json:"features,omitempty"`
+	// castles formed for the first time at this step.
+	Castles []*Castle `protobuf:"bytes,18,rep,name=castles,proto3" json:"castles,omitempty"`
 }
 
 func (x *Step) Reset() {
 	*x = Step{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_document_proto_msgTypes[6]
+		mi := &file_proto_document_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -716,7 +1012,7 @@ func (x *Step) String() string {
 func (*Step) ProtoMessage() {}
 
 func (x *Step) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_document_proto_msgTypes[6]
+	mi := &file_proto_document_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -729,7 +1025,7 @@ func (x *Step) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Step.ProtoReflect.Descriptor instead.
 func (*Step) Descriptor() ([]byte, []int) {
-	return file_proto_document_proto_rawDescGZIP(), []int{6}
+	return file_proto_document_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Step) GetUserId() string {
@@ -837,6 +1133,298 @@ func (x *Step) GetNotes() []string {
 	return nil
 }
 
+func (x *Step) GetFeatures() uint64 {
+	if x != nil {
+		return x.Features
+	}
+	return 0
+}
+
+func (x *Step) GetCastles() []*Castle {
+	if x != nil {
+		return x.Castles
+	}
+	return nil
+}
+
+type Castle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Player Player_Order `protobuf:"varint,1,opt,name=player,proto3,enum=document.Player_Order" json:"player,omitempty"`
+	Name   string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Castle) Reset() {
+	*x = Castle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_document_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Castle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Castle) ProtoMessage() {}
+
+func (x *Castle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_document_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Castle.ProtoReflect.Descriptor instead.
+func (*Castle) Descriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Castle) GetPlayer() Player_Order {
+	if x != nil {
+		return x.Player
+	}
+	return Player_BLACK
+}
+
+func (x *Castle) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type StepNotes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq   int32    `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Notes []string `protobuf:"bytes,2,rep,name=notes,proto3" json:"notes,omitempty"`
+}
+
+func (x *StepNotes) Reset() {
+	*x = StepNotes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_document_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepNotes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepNotes) ProtoMessage() {}
+
+func (x *StepNotes) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_document_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepNotes.ProtoReflect.Descriptor instead.
+func (*StepNotes) Descriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StepNotes) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *StepNotes) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+type KifuRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId    string       `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Version   int64        `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	RevisedTs int64        `protobuf:"varint,3,opt,name=revised_ts,json=revisedTs,proto3" json:"revised_ts,omitempty"`
+	Kifu      *Kifu        `protobuf:"bytes,4,opt,name=kifu,proto3" json:"kifu,omitempty"`
+	StepNotes []*StepNotes `protobuf:"bytes,5,rep,name=step_notes,json=stepNotes,proto3" json:"step_notes,omitempty"`
+}
+
+func (x *KifuRevision) Reset() {
+	*x = KifuRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_document_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KifuRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KifuRevision) ProtoMessage() {}
+
+func (x *KifuRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_document_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KifuRevision.ProtoReflect.Descriptor instead.
+func (*KifuRevision) Descriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *KifuRevision) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *KifuRevision) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KifuRevision) GetRevisedTs() int64 {
+	if x != nil {
+		return x.RevisedTs
+	}
+	return 0
+}
+
+func (x *KifuRevision) GetKifu() *Kifu {
+	if x != nil {
+		return x.Kifu
+	}
+	return nil
+}
+
+func (x *KifuRevision) GetStepNotes() []*StepNotes {
+	if x != nil {
+		return x.StepNotes
+	}
+	return nil
+}
+
+type Team struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamId      string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OwnerUserId string `protobuf:"bytes,3,opt,name=owner_user_id,json=ownerUserId,proto3" json:"owner_user_id,omitempty"`
+	// users who accepted the invitation, and the owner.
+	MemberUserIds []string `protobuf:"bytes,4,rep,name=member_user_ids,json=memberUserIds,proto3" json:"member_user_ids,omitempty"`
+	CreatedTs     int64    `protobuf:"varint,5,opt,name=created_ts,json=createdTs,proto3" json:"created_ts,omitempty"`
+	// users who are invited but have not accepted yet.
+	InvitedUserIds []string `protobuf:"bytes,6,rep,name=invited_user_ids,json=invitedUserIds,proto3" json:"invited_user_ids,omitempty"`
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_document_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_document_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_proto_document_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Team) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *Team) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Team) GetOwnerUserId() string {
+	if x != nil {
+		return x.OwnerUserId
+	}
+	return ""
+}
+
+func (x *Team) GetMemberUserIds() []string {
+	if x != nil {
+		return x.MemberUserIds
+	}
+	return nil
+}
+
+func (x *Team) GetCreatedTs() int64 {
+	if x != nil {
+		return x.CreatedTs
+	}
+	return 0
+}
+
+func (x *Team) GetInvitedUserIds() []string {
+	if x != nil {
+		return x.InvitedUserIds
+	}
+	return nil
+}
+
 var File_proto_document_proto protoreflect.FileDescriptor
 
 var file_proto_document_proto_rawDesc = []byte{
@@ -888,68 +1476,145 @@ var file_proto_document_proto_rawDesc = []byte{
 	0x5f, 0x4b, 0x59, 0x4f, 0x55, 0x10, 0x0c, 0x12, 0x06, 0x0a, 0x02, 0x46, 0x55, 0x10, 0x0d, 0x12,
 	0x06, 0x0a, 0x02, 0x54, 0x4f, 0x10, 0x0e, 0x22, 0x21, 0x0a, 0x03, 0x50, 0x6f, 0x73, 0x12, 0x0c,
 	0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x22, 0xcb, 0x03, 0x0a, 0x04, 0x4b,
-	0x69, 0x66, 0x75, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
-	0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b,
-	0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x73,
-	0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x05, 0x65, 0x6e, 0x64, 0x54, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69,
-	0x63, 0x61, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x2e, 0x49, 0x64,
-	0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61,
-	0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67,
-	0x61, 0x6d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x73, 0x12, 0x42, 0x0a, 0x0c, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x2e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x6f, 0x74, 0x68, 0x65,
-	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x66, 0x65, 0x6e, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x66, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61,
-	0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6c,
-	0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x54, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x1a, 0x3e, 0x0a, 0x10, 0x4f, 0x74, 0x68, 0x65,
-	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe8, 0x03, 0x0a, 0x04, 0x53, 0x74, 0x65,
-	0x70, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69,
-	0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66,
-	0x75, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x1f, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
-	0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03, 0x73,
-	0x72, 0x63, 0x12, 0x1f, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03,
-	0x64, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69,
-	0x65, 0x63, 0x65, 0x2e, 0x49, 0x64, 0x52, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x12, 0x2e, 0x0a, 0x08, 0x63,
-	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e,
-	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x49,
-	0x64, 0x52, 0x08, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x12, 0x44, 0x0a, 0x0f, 0x66,
-	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0c,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x22, 0x62, 0x0a, 0x05, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x64, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65,
+	0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0x16, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x08,
+	0x0a, 0x04, 0x52, 0x45, 0x41, 0x44, 0x10, 0x00, 0x22, 0x04, 0x08, 0x01, 0x10, 0x01, 0x22, 0x94,
+	0x06, 0x0a, 0x04, 0x4b, 0x69, 0x66, 0x75, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x6e, 0x64, 0x54, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x68,
+	0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61,
+	0x70, 0x2e, 0x49, 0x64, 0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x12, 0x1b,
+	0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x42, 0x0a, 0x0c, 0x6f, 0x74, 0x68, 0x65, 0x72,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x2e, 0x4f, 0x74,
+	0x68, 0x65, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b,
+	0x6f, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x66, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x66, 0x65, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x12, 0x27, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6e,
+	0x6b, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c,
+	0x69, 0x6e, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f,
+	0x62, 0x6c, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x12,
+	0x29, 0x0a, 0x10, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x69, 0x65, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x77, 0x68, 0x69, 0x74, 0x65,
+	0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x18, 0x16, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x69, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x52, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc7, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
-	0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x49,
-	0x64, 0x52, 0x0e, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x66, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x73, 0x66, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x65, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x68,
-	0x69, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x74, 0x68, 0x69, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x63, 0x12, 0x14, 0x0a,
-	0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f,
-	0x74, 0x65, 0x73, 0x42, 0x10, 0x5a, 0x0e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x6f, 0x63,
-	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x3f, 0x0a, 0x0f, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x22, 0x31, 0x0a, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x44, 0x52, 0x41, 0x57, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x45,
+	0x52, 0x50, 0x45, 0x54, 0x55, 0x41, 0x4c, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x10, 0x02, 0x22,
+	0xb0, 0x04, 0x0a, 0x04, 0x53, 0x74, 0x65, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65,
+	0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x1f, 0x0a, 0x03, 0x64, 0x73, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x70, 0x69,
+	0x65, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x49, 0x64, 0x52, 0x05, 0x70,
+	0x69, 0x65, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x72,
+	0x6f, 0x70, 0x12, 0x2e, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x49, 0x64, 0x52, 0x08, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x64, 0x12, 0x44, 0x0a, 0x0f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x49, 0x64, 0x52, 0x0e, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x66, 0x65, 0x6e,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x66, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x65,
+	0x63, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x68, 0x69, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65,
+	0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x68, 0x69, 0x6e, 0x6b, 0x69, 0x6e,
+	0x67, 0x53, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x10, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x61, 0x73, 0x74, 0x6c, 0x65,
+	0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x43, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x52, 0x07, 0x63, 0x61, 0x73, 0x74, 0x6c,
+	0x65, 0x73, 0x22, 0x4c, 0x0a, 0x06, 0x43, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x06,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x2e, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x33, 0x0a, 0x09, 0x53, 0x74, 0x65, 0x70, 0x4e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x6e, 0x6f, 0x74, 0x65, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x0c, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x65, 0x64, 0x5f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x65, 0x64, 0x54, 0x73, 0x12, 0x22, 0x0a, 0x04, 0x6b, 0x69, 0x66, 0x75,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x04, 0x6b, 0x69, 0x66, 0x75, 0x12, 0x32, 0x0a, 0x0a,
+	0x73, 0x74, 0x65, 0x70, 0x5f, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x65, 0x70,
+	0x4e, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x09, 0x73, 0x74, 0x65, 0x70, 0x4e, 0x6f, 0x74, 0x65, 0x73,
+	0x22, 0xc8, 0x01, 0x0a, 0x04, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x65, 0x61,
+	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54,
+	0x73, 0x12, 0x28, 0x0a, 0x10, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x76,
+	0x69, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x42, 0x10, 0x5a, 0x0e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -964,37 +1629,54 @@ func file_proto_document_proto_rawDescGZIP() []byte {
 	return file_proto_document_proto_rawDescData
 }
 
-var file_proto_document_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_proto_document_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_document_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_proto_document_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_document_proto_goTypes = []interface{}{
 	(Player_Order)(0),      // 0: document.Player.Order
 	(FinishedStatus_Id)(0), // 1: document.FinishedStatus.Id
 	(Handicap_Id)(0),       // 2: document.Handicap.Id
 	(Piece_Id)(0),          // 3: document.Piece.Id
-	(*Player)(nil),         // 4: document.Player
-	(*FinishedStatus)(nil), // 5: document.FinishedStatus
-	(*Handicap)(nil),       // 6: document.Handicap
-	(*Piece)(nil),          // 7: document.Piece
-	(*Pos)(nil),            // 8: document.Pos
-	(*Kifu)(nil),           // 9: document.Kifu
-	(*Step)(nil),           // 10: document.Step
-	nil,                    // 11: document.Kifu.OtherFieldsEntry
+	(Share_Role)(0),        // 4: document.Share.Role
+	(Repetition_Result)(0), // 5: document.Repetition.Result
+	(*Player)(nil),         // 6: document.Player
+	(*FinishedStatus)(nil), // 7: document.FinishedStatus
+	(*Handicap)(nil),       // 8: document.Handicap
+	(*Piece)(nil),          // 9: document.Piece
+	(*Pos)(nil),            // 10: document.Pos
+	(*Share)(nil),          // 11: document.Share
+	(*Kifu)(nil),           // 12: document.Kifu
+	(*Repetition)(nil),     // 13: document.Repetition
+	(*Step)(nil),           // 14: document.Step
+	(*Castle)(nil),         // 15: document.Castle
+	(*StepNotes)(nil),      // 16: document.StepNotes
+	(*KifuRevision)(nil),   // 17: document.KifuRevision
+	(*Team)(nil),           // 18: document.Team
+	nil,                    // 19: document.Kifu.OtherFieldsEntry
 }
 var file_proto_document_proto_depIdxs = []int32{
 	0,  // 0: document.Player.order:type_name -> document.Player.Order
-	2,  // 1: document.Kifu.handicap:type_name -> document.Handicap.Id
-	4,  // 2: document.Kifu.players:type_name -> document.Player
-	11, // 3: document.Kifu.other_fields:type_name -> document.Kifu.OtherFieldsEntry
-	8,  // 4: document.Step.src:type_name -> document.Pos
-	8,  // 5: document.Step.dst:type_name -> document.Pos
-	3,  // 6: document.Step.piece:type_name -> document.Piece.Id
-	3,  // 7: document.Step.captured:type_name -> document.Piece.Id
-	1,  // 8: document.Step.finished_status:type_name -> document.FinishedStatus.Id
-	9,  // [9:9] is the sub-list for method output_type
-	9,  // [9:9] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	4,  // 1: document.Share.role:type_name -> document.Share.Role
+	2,  // 2: document.Kifu.handicap:type_name -> document.Handicap.Id
+	6,  // 3: document.Kifu.players:type_name -> document.Player
+	19, // 4: document.Kifu.other_fields:type_name -> document.Kifu.OtherFieldsEntry
+	11, // 5: document.Kifu.shares:type_name -> document.Share
+	13, // 6: document.Kifu.repetition:type_name -> document.Repetition
+	5,  // 7: document.Repetition.result:type_name -> document.Repetition.Result
+	0,  // 8: document.Repetition.checking_player:type_name -> document.Player.Order
+	10, // 9: document.Step.src:type_name -> document.Pos
+	10, // 10: document.Step.dst:type_name -> document.Pos
+	3,  // 11: document.Step.piece:type_name -> document.Piece.Id
+	3,  // 12: document.Step.captured:type_name -> document.Piece.Id
+	1,  // 13: document.Step.finished_status:type_name -> document.FinishedStatus.Id
+	15, // 14: document.Step.castles:type_name -> document.Castle
+	0,  // 15: document.Castle.player:type_name -> document.Player.Order
+	12, // 16: document.KifuRevision.kifu:type_name -> document.Kifu
+	16, // 17: document.KifuRevision.step_notes:type_name -> document.StepNotes
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_proto_document_proto_init() }
@@ -1064,7 +1746,7 @@ func file_proto_document_proto_init() {
 			}
 		}
 		file_proto_document_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Kifu); i {
+			switch v := v.(*Share); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1076,6 +1758,30 @@ func file_proto_document_proto_init() {
 			}
 		}
 		file_proto_document_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Kifu); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_document_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Repetition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_document_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Step); i {
 			case 0:
 				return &v.state
@@ -1087,14 +1793,62 @@ func file_proto_document_proto_init() {
 				return nil
 			}
 		}
+		file_proto_document_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Castle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_document_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepNotes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_document_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KifuRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_document_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Team); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_document_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   8,
+			NumEnums:      6,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
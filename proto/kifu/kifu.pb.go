@@ -25,6 +25,64 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// what to do when the user already has a kifu with the same moves.
+type PostKifuRequest_DuplicatePolicy int32
+
+const (
+	// store as a new kifu.
+	PostKifuRequest_CREATE PostKifuRequest_DuplicatePolicy = 0
+	// fail with DuplicateKifuError.
+	PostKifuRequest_REJECT PostKifuRequest_DuplicatePolicy = 1
+	// return the existing kifu without storing.
+	PostKifuRequest_RETURN_EXISTING PostKifuRequest_DuplicatePolicy = 2
+	// store the new kifu_id as an alias of the existing kifu.
+	// GetKifu with the alias returns the existing kifu.
+	PostKifuRequest_ALIAS PostKifuRequest_DuplicatePolicy = 3
+)
+
+// Enum value maps for PostKifuRequest_DuplicatePolicy.
+var (
+	PostKifuRequest_DuplicatePolicy_name = map[int32]string{
+		0: "CREATE",
+		1: "REJECT",
+		2: "RETURN_EXISTING",
+		3: "ALIAS",
+	}
+	PostKifuRequest_DuplicatePolicy_value = map[string]int32{
+		"CREATE":          0,
+		"REJECT":          1,
+		"RETURN_EXISTING": 2,
+		"ALIAS":           3,
+	}
+)
+
+func (x PostKifuRequest_DuplicatePolicy) Enum() *PostKifuRequest_DuplicatePolicy {
+	p := new(PostKifuRequest_DuplicatePolicy)
+	*p = x
+	return p
+}
+
+func (x PostKifuRequest_DuplicatePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostKifuRequest_DuplicatePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_kifu_proto_enumTypes[0].Descriptor()
+}
+
+func (PostKifuRequest_DuplicatePolicy) Type() protoreflect.EnumType {
+	return &file_proto_kifu_proto_enumTypes[0]
+}
+
+func (x PostKifuRequest_DuplicatePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostKifuRequest_DuplicatePolicy.Descriptor instead.
+func (PostKifuRequest_DuplicatePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type Piece_Id int32
 
 const (
@@ -94,11 +152,11 @@ func (x Piece_Id) String() string {
 }
 
 func (Piece_Id) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_kifu_proto_enumTypes[0].Descriptor()
+	return file_proto_kifu_proto_enumTypes[1].Descriptor()
 }
 
 func (Piece_Id) Type() protoreflect.EnumType {
-	return &file_proto_kifu_proto_enumTypes[0]
+	return &file_proto_kifu_proto_enumTypes[1]
 }
 
 func (x Piece_Id) Number() protoreflect.EnumNumber {
@@ -164,11 +222,11 @@ func (x FinishedStatus_Id) String() string {
 }
 
 func (FinishedStatus_Id) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_kifu_proto_enumTypes[1].Descriptor()
+	return file_proto_kifu_proto_enumTypes[2].Descriptor()
 }
 
 func (FinishedStatus_Id) Type() protoreflect.EnumType {
-	return &file_proto_kifu_proto_enumTypes[1]
+	return &file_proto_kifu_proto_enumTypes[2]
 }
 
 func (x FinishedStatus_Id) Number() protoreflect.EnumNumber {
@@ -180,12 +238,65 @@ func (FinishedStatus_Id) EnumDescriptor() ([]byte, []int) {
 	return file_proto_kifu_proto_rawDescGZIP(), []int{9, 0}
 }
 
+// positions are matched ignoring the move number in both modes.
+type GetSamePositionsRequest_Match int32
+
+const (
+	GetSamePositionsRequest_EXACT GetSamePositionsRequest_Match = 0
+	// left-right mirrored and color-flipped positions are also matched
+	// as allowed by mirror and color_flip.
+	GetSamePositionsRequest_NORMALIZED GetSamePositionsRequest_Match = 1
+)
+
+// Enum value maps for GetSamePositionsRequest_Match.
+var (
+	GetSamePositionsRequest_Match_name = map[int32]string{
+		0: "EXACT",
+		1: "NORMALIZED",
+	}
+	GetSamePositionsRequest_Match_value = map[string]int32{
+		"EXACT":      0,
+		"NORMALIZED": 1,
+	}
+)
+
+func (x GetSamePositionsRequest_Match) Enum() *GetSamePositionsRequest_Match {
+	p := new(GetSamePositionsRequest_Match)
+	*p = x
+	return p
+}
+
+func (x GetSamePositionsRequest_Match) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetSamePositionsRequest_Match) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_kifu_proto_enumTypes[3].Descriptor()
+}
+
+func (GetSamePositionsRequest_Match) Type() protoreflect.EnumType {
+	return &file_proto_kifu_proto_enumTypes[3]
+}
+
+func (x GetSamePositionsRequest_Match) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetSamePositionsRequest_Match.Descriptor instead.
+func (GetSamePositionsRequest_Match) EnumDescriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{13, 0}
+}
+
 type RecentKifuRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// only kifus which have all of the tags are returned.
+	Tags []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// only kifus in which either side or the game has the strategy (戦型) are returned.
+	Strategy string `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`
 }
 
 func (x *RecentKifuRequest) Reset() {
@@ -227,6 +338,20 @@ func (x *RecentKifuRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *RecentKifuRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RecentKifuRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
 type RecentKifuResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -286,7 +411,8 @@ type PostKifuRequest struct {
 	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
 	// valid values: UTF-8 | Shift_JIS
 	// required.
-	Encoding string `protobuf:"bytes,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	Encoding        string                          `protobuf:"bytes,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	DuplicatePolicy PostKifuRequest_DuplicatePolicy `protobuf:"varint,4,opt,name=duplicate_policy,json=duplicatePolicy,proto3,enum=kifu.PostKifuRequest_DuplicatePolicy" json:"duplicate_policy,omitempty"`
 }
 
 func (x *PostKifuRequest) Reset() {
@@ -342,6 +468,13 @@ func (x *PostKifuRequest) GetEncoding() string {
 	return ""
 }
 
+func (x *PostKifuRequest) GetDuplicatePolicy() PostKifuRequest_DuplicatePolicy {
+	if x != nil {
+		return x.DuplicatePolicy
+	}
+	return PostKifuRequest_CREATE
+}
+
 type PostKifuResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -349,6 +482,8 @@ type PostKifuResponse struct {
 
 	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
 	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// true if kifu_id is the existing kifu.
+	Duplicated bool `protobuf:"varint,3,opt,name=duplicated,proto3" json:"duplicated,omitempty"`
 }
 
 func (x *PostKifuResponse) Reset() {
@@ -397,6 +532,13 @@ func (x *PostKifuResponse) GetVersion() int64 {
 	return 0
 }
 
+func (x *PostKifuResponse) GetDuplicated() bool {
+	if x != nil {
+		return x.Duplicated
+	}
+	return false
+}
+
 type DeleteKifuRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -404,6 +546,8 @@ type DeleteKifuRequest struct {
 
 	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
 	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// move to the trash instead of deleting immediately.
+	Soft bool `protobuf:"varint,3,opt,name=soft,proto3" json:"soft,omitempty"`
 }
 
 func (x *DeleteKifuRequest) Reset() {
@@ -452,10 +596,20 @@ func (x *DeleteKifuRequest) GetVersion() int64 {
 	return 0
 }
 
+func (x *DeleteKifuRequest) GetSoft() bool {
+	if x != nil {
+		return x.Soft
+	}
+	return false
+}
+
 type DeleteKifuResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// new version of the trashed kifu. set when soft.
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (x *DeleteKifuResponse) Reset() {
@@ -490,12 +644,21 @@ func (*DeleteKifuResponse) Descriptor() ([]byte, []int) {
 	return file_proto_kifu_proto_rawDescGZIP(), []int{5}
 }
 
+func (x *DeleteKifuResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type GetKifuRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	// required to read a published kifu of other users.
+	LinkToken string `protobuf:"bytes,2,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
 }
 
 func (x *GetKifuRequest) Reset() {
@@ -537,6 +700,13 @@ func (x *GetKifuRequest) GetKifuId() string {
 	return ""
 }
 
+func (x *GetKifuRequest) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
+}
+
 type Pos struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -742,6 +912,25 @@ type GetKifuResponse struct {
 	Steps         []*GetKifuResponse_Step   `protobuf:"bytes,12,rep,name=steps,proto3" json:"steps,omitempty"`
 	Note          string                    `protobuf:"bytes,13,opt,name=note,proto3" json:"note,omitempty"`
 	Version       int64                     `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	Tags          []string                  `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
+	// access of the caller. valid values: OWNER | READ
+	Access string `protobuf:"bytes,16,opt,name=access,proto3" json:"access,omitempty"`
+	// following fields are set only for the owner.
+	Shares          []*Share                  `protobuf:"bytes,17,rep,name=shares,proto3" json:"shares,omitempty"`
+	LinkToken       string                    `protobuf:"bytes,18,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
+	BlackStrategies []string                  `protobuf:"bytes,19,rep,name=black_strategies,json=blackStrategies,proto3" json:"black_strategies,omitempty"`
+	WhiteStrategies []string                  `protobuf:"bytes,20,rep,name=white_strategies,json=whiteStrategies,proto3" json:"white_strategies,omitempty"`
+	GameStrategies  []string                  `protobuf:"bytes,26,rep,name=game_strategies,json=gameStrategies,proto3" json:"game_strategies,omitempty"`
+	Castles         []*GetKifuResponse_Castle `protobuf:"bytes,21,rep,name=castles,proto3" json:"castles,omitempty"`
+	// fourfold repetition (千日手). null if not detected.
+	Repetition *GetKifuResponse_Repetition `protobuf:"bytes,22,opt,name=repetition,proto3" json:"repetition,omitempty"`
+	Warnings   []string                    `protobuf:"bytes,23,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// points of each step after either king entered the promotion zone.
+	// only for games which end in the entering king (入玉) situation.
+	ImpassePoints []*GetKifuResponse_ImpassePoints `protobuf:"bytes,24,rep,name=impasse_points,json=impassePoints,proto3" json:"impasse_points,omitempty"`
+	// judgment of the last position by the 24-point rule.
+	// valid values: DRAW | BLACK_WIN | WHITE_WIN. empty unless both kings entered.
+	ImpasseResult string `protobuf:"bytes,25,opt,name=impasse_result,json=impasseResult,proto3" json:"impasse_result,omitempty"`
 }
 
 func (x *GetKifuResponse) Reset() {
@@ -874,95 +1063,117 @@ func (x *GetKifuResponse) GetVersion() int64 {
 	return 0
 }
 
-type GetSamePositionsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GetKifuResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
 
-	Position       string   `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
-	Steps          int32    `protobuf:"varint,2,opt,name=steps,proto3" json:"steps,omitempty"`
-	ExcludeKifuIds []string `protobuf:"bytes,3,rep,name=exclude_kifu_ids,json=excludeKifuIds,proto3" json:"exclude_kifu_ids,omitempty"`
+func (x *GetKifuResponse) GetAccess() string {
+	if x != nil {
+		return x.Access
+	}
+	return ""
 }
 
-func (x *GetSamePositionsRequest) Reset() {
-	*x = GetSamePositionsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_kifu_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GetKifuResponse) GetShares() []*Share {
+	if x != nil {
+		return x.Shares
 	}
+	return nil
 }
 
-func (x *GetSamePositionsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetKifuResponse) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
 }
 
-func (*GetSamePositionsRequest) ProtoMessage() {}
+func (x *GetKifuResponse) GetBlackStrategies() []string {
+	if x != nil {
+		return x.BlackStrategies
+	}
+	return nil
+}
 
-func (x *GetSamePositionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kifu_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GetKifuResponse) GetWhiteStrategies() []string {
+	if x != nil {
+		return x.WhiteStrategies
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetSamePositionsRequest.ProtoReflect.Descriptor instead.
-func (*GetSamePositionsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kifu_proto_rawDescGZIP(), []int{12}
+func (x *GetKifuResponse) GetGameStrategies() []string {
+	if x != nil {
+		return x.GameStrategies
+	}
+	return nil
 }
 
-func (x *GetSamePositionsRequest) GetPosition() string {
+func (x *GetKifuResponse) GetCastles() []*GetKifuResponse_Castle {
 	if x != nil {
-		return x.Position
+		return x.Castles
 	}
-	return ""
+	return nil
 }
 
-func (x *GetSamePositionsRequest) GetSteps() int32 {
+func (x *GetKifuResponse) GetRepetition() *GetKifuResponse_Repetition {
 	if x != nil {
-		return x.Steps
+		return x.Repetition
 	}
-	return 0
+	return nil
 }
 
-func (x *GetSamePositionsRequest) GetExcludeKifuIds() []string {
+func (x *GetKifuResponse) GetWarnings() []string {
 	if x != nil {
-		return x.ExcludeKifuIds
+		return x.Warnings
 	}
 	return nil
 }
 
-type GetSamePositionsResponse struct {
+func (x *GetKifuResponse) GetImpassePoints() []*GetKifuResponse_ImpassePoints {
+	if x != nil {
+		return x.ImpassePoints
+	}
+	return nil
+}
+
+func (x *GetKifuResponse) GetImpasseResult() string {
+	if x != nil {
+		return x.ImpasseResult
+	}
+	return ""
+}
+
+type Share struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Position string                           `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
-	Kifus    []*GetSamePositionsResponse_Kifu `protobuf:"bytes,2,rep,name=kifus,proto3" json:"kifus,omitempty"`
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// valid values: READ
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
 }
 
-func (x *GetSamePositionsResponse) Reset() {
-	*x = GetSamePositionsResponse{}
+func (x *Share) Reset() {
+	*x = Share{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_kifu_proto_msgTypes[13]
+		mi := &file_proto_kifu_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSamePositionsResponse) String() string {
+func (x *Share) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSamePositionsResponse) ProtoMessage() {}
+func (*Share) ProtoMessage() {}
 
-func (x *GetSamePositionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kifu_proto_msgTypes[13]
+func (x *Share) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,58 +1184,62 @@ func (x *GetSamePositionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSamePositionsResponse.ProtoReflect.Descriptor instead.
-func (*GetSamePositionsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_kifu_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use Share.ProtoReflect.Descriptor instead.
+func (*Share) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetSamePositionsResponse) GetPosition() string {
+func (x *Share) GetUserId() string {
 	if x != nil {
-		return x.Position
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *GetSamePositionsResponse) GetKifus() []*GetSamePositionsResponse_Kifu {
+func (x *Share) GetRole() string {
 	if x != nil {
-		return x.Kifus
+		return x.Role
 	}
-	return nil
+	return ""
 }
 
-type RecentKifuResponse_Kifu struct {
+type GetSamePositionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId        string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	KifuId        string   `protobuf:"bytes,2,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
-	StartTs       int64    `protobuf:"varint,3,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
-	Handicap      string   `protobuf:"bytes,4,opt,name=handicap,proto3" json:"handicap,omitempty"`
-	GameName      string   `protobuf:"bytes,5,opt,name=game_name,json=gameName,proto3" json:"game_name,omitempty"`
-	FirstPlayers  []string `protobuf:"bytes,6,rep,name=first_players,json=firstPlayers,proto3" json:"first_players,omitempty"`
-	SecondPlayers []string `protobuf:"bytes,7,rep,name=second_players,json=secondPlayers,proto3" json:"second_players,omitempty"`
-	Note          string   `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
-	Version       int64    `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	Position       string   `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Steps          int32    `protobuf:"varint,2,opt,name=steps,proto3" json:"steps,omitempty"`
+	ExcludeKifuIds []string `protobuf:"bytes,3,rep,name=exclude_kifu_ids,json=excludeKifuIds,proto3" json:"exclude_kifu_ids,omitempty"`
+	// search kifus of the team members instead of the caller's own kifus.
+	// "public" for the public pool. the caller must be a member.
+	TeamId string `protobuf:"bytes,4,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// maximum number of kifus. default: 100
+	Limit     int32                         `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Match     GetSamePositionsRequest_Match `protobuf:"varint,6,opt,name=match,proto3,enum=kifu.GetSamePositionsRequest_Match" json:"match,omitempty"`
+	Mirror    bool                          `protobuf:"varint,7,opt,name=mirror,proto3" json:"mirror,omitempty"`
+	ColorFlip bool                          `protobuf:"varint,8,opt,name=color_flip,json=colorFlip,proto3" json:"color_flip,omitempty"`
+	// only kifus in which either side or the game has the strategy (戦型) are returned.
+	Strategy string `protobuf:"bytes,9,opt,name=strategy,proto3" json:"strategy,omitempty"`
 }
 
-func (x *RecentKifuResponse_Kifu) Reset() {
-	*x = RecentKifuResponse_Kifu{}
+func (x *GetSamePositionsRequest) Reset() {
+	*x = GetSamePositionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_kifu_proto_msgTypes[14]
+		mi := &file_proto_kifu_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecentKifuResponse_Kifu) String() string {
+func (x *GetSamePositionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecentKifuResponse_Kifu) ProtoMessage() {}
+func (*GetSamePositionsRequest) ProtoMessage() {}
 
-func (x *RecentKifuResponse_Kifu) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kifu_proto_msgTypes[14]
+func (x *GetSamePositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1035,100 +1250,101 @@ func (x *RecentKifuResponse_Kifu) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecentKifuResponse_Kifu.ProtoReflect.Descriptor instead.
-func (*RecentKifuResponse_Kifu) Descriptor() ([]byte, []int) {
-	return file_proto_kifu_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use GetSamePositionsRequest.ProtoReflect.Descriptor instead.
+func (*GetSamePositionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RecentKifuResponse_Kifu) GetUserId() string {
+func (x *GetSamePositionsRequest) GetPosition() string {
 	if x != nil {
-		return x.UserId
+		return x.Position
 	}
 	return ""
 }
 
-func (x *RecentKifuResponse_Kifu) GetKifuId() string {
+func (x *GetSamePositionsRequest) GetSteps() int32 {
 	if x != nil {
-		return x.KifuId
+		return x.Steps
 	}
-	return ""
+	return 0
 }
 
-func (x *RecentKifuResponse_Kifu) GetStartTs() int64 {
+func (x *GetSamePositionsRequest) GetExcludeKifuIds() []string {
 	if x != nil {
-		return x.StartTs
+		return x.ExcludeKifuIds
 	}
-	return 0
+	return nil
 }
 
-func (x *RecentKifuResponse_Kifu) GetHandicap() string {
+func (x *GetSamePositionsRequest) GetTeamId() string {
 	if x != nil {
-		return x.Handicap
+		return x.TeamId
 	}
 	return ""
 }
 
-func (x *RecentKifuResponse_Kifu) GetGameName() string {
+func (x *GetSamePositionsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.GameName
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *RecentKifuResponse_Kifu) GetFirstPlayers() []string {
+func (x *GetSamePositionsRequest) GetMatch() GetSamePositionsRequest_Match {
 	if x != nil {
-		return x.FirstPlayers
+		return x.Match
 	}
-	return nil
+	return GetSamePositionsRequest_EXACT
 }
 
-func (x *RecentKifuResponse_Kifu) GetSecondPlayers() []string {
+func (x *GetSamePositionsRequest) GetMirror() bool {
 	if x != nil {
-		return x.SecondPlayers
+		return x.Mirror
 	}
-	return nil
+	return false
 }
 
-func (x *RecentKifuResponse_Kifu) GetNote() string {
+func (x *GetSamePositionsRequest) GetColorFlip() bool {
 	if x != nil {
-		return x.Note
+		return x.ColorFlip
 	}
-	return ""
+	return false
 }
 
-func (x *RecentKifuResponse_Kifu) GetVersion() int64 {
+func (x *GetSamePositionsRequest) GetStrategy() string {
 	if x != nil {
-		return x.Version
+		return x.Strategy
 	}
-	return 0
+	return ""
 }
 
-type GetKifuResponse_Player struct {
+type GetSamePositionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Note string `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	Position      string                                   `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Kifus         []*GetSamePositionsResponse_Kifu         `protobuf:"bytes,2,rep,name=kifus,proto3" json:"kifus,omitempty"`
+	Continuations []*GetSamePositionsResponse_Continuation `protobuf:"bytes,3,rep,name=continuations,proto3" json:"continuations,omitempty"`
 }
 
-func (x *GetKifuResponse_Player) Reset() {
-	*x = GetKifuResponse_Player{}
+func (x *GetSamePositionsResponse) Reset() {
+	*x = GetSamePositionsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_kifu_proto_msgTypes[15]
+		mi := &file_proto_kifu_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetKifuResponse_Player) String() string {
+func (x *GetSamePositionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetKifuResponse_Player) ProtoMessage() {}
+func (*GetSamePositionsResponse) ProtoMessage() {}
 
-func (x *GetKifuResponse_Player) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kifu_proto_msgTypes[15]
+func (x *GetSamePositionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1139,60 +1355,57 @@ func (x *GetKifuResponse_Player) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetKifuResponse_Player.ProtoReflect.Descriptor instead.
-func (*GetKifuResponse_Player) Descriptor() ([]byte, []int) {
-	return file_proto_kifu_proto_rawDescGZIP(), []int{11, 0}
+// Deprecated: Use GetSamePositionsResponse.ProtoReflect.Descriptor instead.
+func (*GetSamePositionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetKifuResponse_Player) GetName() string {
+func (x *GetSamePositionsResponse) GetPosition() string {
 	if x != nil {
-		return x.Name
+		return x.Position
 	}
 	return ""
 }
 
-func (x *GetKifuResponse_Player) GetNote() string {
+func (x *GetSamePositionsResponse) GetKifus() []*GetSamePositionsResponse_Kifu {
 	if x != nil {
-		return x.Note
+		return x.Kifus
 	}
-	return ""
+	return nil
 }
 
-type GetKifuResponse_Step struct {
+func (x *GetSamePositionsResponse) GetContinuations() []*GetSamePositionsResponse_Continuation {
+	if x != nil {
+		return x.Continuations
+	}
+	return nil
+}
+
+type ListKifuRevisionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Seq            int32             `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
-	Position       string            `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
-	Src            *Pos              `protobuf:"bytes,3,opt,name=src,proto3" json:"src,omitempty"`
-	Dst            *Pos              `protobuf:"bytes,4,opt,name=dst,proto3" json:"dst,omitempty"`
-	Piece          Piece_Id          `protobuf:"varint,5,opt,name=piece,proto3,enum=kifu.Piece_Id" json:"piece,omitempty"`
-	FinishedStatus FinishedStatus_Id `protobuf:"varint,6,opt,name=finished_status,json=finishedStatus,proto3,enum=kifu.FinishedStatus_Id" json:"finished_status,omitempty"`
-	Promoted       bool              `protobuf:"varint,7,opt,name=promoted,proto3" json:"promoted,omitempty"`
-	Captured       Piece_Id          `protobuf:"varint,8,opt,name=captured,proto3,enum=kifu.Piece_Id" json:"captured,omitempty"`
-	TimestampSec   int32             `protobuf:"varint,9,opt,name=timestamp_sec,json=timestampSec,proto3" json:"timestamp_sec,omitempty"`
-	ThinkingSec    int32             `protobuf:"varint,10,opt,name=thinking_sec,json=thinkingSec,proto3" json:"thinking_sec,omitempty"`
-	Notes          []string          `protobuf:"bytes,11,rep,name=notes,proto3" json:"notes,omitempty"`
+	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
 }
 
-func (x *GetKifuResponse_Step) Reset() {
-	*x = GetKifuResponse_Step{}
+func (x *ListKifuRevisionsRequest) Reset() {
+	*x = ListKifuRevisionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_kifu_proto_msgTypes[16]
+		mi := &file_proto_kifu_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetKifuResponse_Step) String() string {
+func (x *ListKifuRevisionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetKifuResponse_Step) ProtoMessage() {}
+func (*ListKifuRevisionsRequest) ProtoMessage() {}
 
-func (x *GetKifuResponse_Step) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kifu_proto_msgTypes[16]
+func (x *ListKifuRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1203,118 +1416,164 @@ func (x *GetKifuResponse_Step) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetKifuResponse_Step.ProtoReflect.Descriptor instead.
-func (*GetKifuResponse_Step) Descriptor() ([]byte, []int) {
-	return file_proto_kifu_proto_rawDescGZIP(), []int{11, 1}
+// Deprecated: Use ListKifuRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListKifuRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetKifuResponse_Step) GetSeq() int32 {
+func (x *ListKifuRevisionsRequest) GetKifuId() string {
 	if x != nil {
-		return x.Seq
+		return x.KifuId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetKifuResponse_Step) GetPosition() string {
-	if x != nil {
-		return x.Position
+type ListKifuRevisionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// newest first.
+	Revisions []*ListKifuRevisionsResponse_Revision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	Version   int64                                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *ListKifuRevisionsResponse) Reset() {
+	*x = ListKifuRevisionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *GetKifuResponse_Step) GetSrc() *Pos {
-	if x != nil {
-		return x.Src
+func (x *ListKifuRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKifuRevisionsResponse) ProtoMessage() {}
+
+func (x *ListKifuRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetKifuResponse_Step) GetDst() *Pos {
+// Deprecated: Use ListKifuRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListKifuRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListKifuRevisionsResponse) GetRevisions() []*ListKifuRevisionsResponse_Revision {
 	if x != nil {
-		return x.Dst
+		return x.Revisions
 	}
 	return nil
 }
 
-func (x *GetKifuResponse_Step) GetPiece() Piece_Id {
+func (x *ListKifuRevisionsResponse) GetVersion() int64 {
 	if x != nil {
-		return x.Piece
+		return x.Version
 	}
-	return Piece_NULL
+	return 0
 }
 
-func (x *GetKifuResponse_Step) GetFinishedStatus() FinishedStatus_Id {
-	if x != nil {
-		return x.FinishedStatus
-	}
-	return FinishedStatus_NOT_FINISHED
+type RestoreKifuRevisionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	// version of the revision to restore.
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// current version of the kifu.
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *GetKifuResponse_Step) GetPromoted() bool {
-	if x != nil {
-		return x.Promoted
+func (x *RestoreKifuRevisionRequest) Reset() {
+	*x = RestoreKifuRevisionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (x *GetKifuResponse_Step) GetCaptured() Piece_Id {
-	if x != nil {
-		return x.Captured
+func (x *RestoreKifuRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreKifuRevisionRequest) ProtoMessage() {}
+
+func (x *RestoreKifuRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return Piece_NULL
+	return mi.MessageOf(x)
 }
 
-func (x *GetKifuResponse_Step) GetTimestampSec() int32 {
+// Deprecated: Use RestoreKifuRevisionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreKifuRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RestoreKifuRevisionRequest) GetKifuId() string {
 	if x != nil {
-		return x.TimestampSec
+		return x.KifuId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetKifuResponse_Step) GetThinkingSec() int32 {
+func (x *RestoreKifuRevisionRequest) GetRevision() int64 {
 	if x != nil {
-		return x.ThinkingSec
+		return x.Revision
 	}
 	return 0
 }
 
-func (x *GetKifuResponse_Step) GetNotes() []string {
+func (x *RestoreKifuRevisionRequest) GetVersion() int64 {
 	if x != nil {
-		return x.Notes
+		return x.Version
 	}
-	return nil
+	return 0
 }
 
-type GetSamePositionsResponse_Step struct {
+type RestoreKifuRevisionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Seq            int32             `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
-	Src            *Pos              `protobuf:"bytes,2,opt,name=src,proto3" json:"src,omitempty"`
-	Dst            *Pos              `protobuf:"bytes,3,opt,name=dst,proto3" json:"dst,omitempty"`
-	Piece          Piece_Id          `protobuf:"varint,4,opt,name=piece,proto3,enum=kifu.Piece_Id" json:"piece,omitempty"`
-	Promoted       bool              `protobuf:"varint,5,opt,name=promoted,proto3" json:"promoted,omitempty"`
-	FinishedStatus FinishedStatus_Id `protobuf:"varint,6,opt,name=finished_status,json=finishedStatus,proto3,enum=kifu.FinishedStatus_Id" json:"finished_status,omitempty"`
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *GetSamePositionsResponse_Step) Reset() {
-	*x = GetSamePositionsResponse_Step{}
+func (x *RestoreKifuRevisionResponse) Reset() {
+	*x = RestoreKifuRevisionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_kifu_proto_msgTypes[17]
+		mi := &file_proto_kifu_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSamePositionsResponse_Step) String() string {
+func (x *RestoreKifuRevisionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSamePositionsResponse_Step) ProtoMessage() {}
+func (*RestoreKifuRevisionResponse) ProtoMessage() {}
 
-func (x *GetSamePositionsResponse_Step) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kifu_proto_msgTypes[17]
+func (x *RestoreKifuRevisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1325,81 +1584,81 @@ func (x *GetSamePositionsResponse_Step) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSamePositionsResponse_Step.ProtoReflect.Descriptor instead.
-func (*GetSamePositionsResponse_Step) Descriptor() ([]byte, []int) {
-	return file_proto_kifu_proto_rawDescGZIP(), []int{13, 0}
+// Deprecated: Use RestoreKifuRevisionResponse.ProtoReflect.Descriptor instead.
+func (*RestoreKifuRevisionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetSamePositionsResponse_Step) GetSeq() int32 {
+func (x *RestoreKifuRevisionResponse) GetVersion() int64 {
 	if x != nil {
-		return x.Seq
+		return x.Version
 	}
 	return 0
 }
 
-func (x *GetSamePositionsResponse_Step) GetSrc() *Pos {
-	if x != nil {
-		return x.Src
-	}
-	return nil
+type ListTrashRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (x *GetSamePositionsResponse_Step) GetDst() *Pos {
-	if x != nil {
-		return x.Dst
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *GetSamePositionsResponse_Step) GetPiece() Piece_Id {
-	if x != nil {
-		return x.Piece
-	}
-	return Piece_NULL
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GetSamePositionsResponse_Step) GetPromoted() bool {
-	if x != nil {
-		return x.Promoted
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *GetSamePositionsResponse_Step) GetFinishedStatus() FinishedStatus_Id {
-	if x != nil {
-		return x.FinishedStatus
-	}
-	return FinishedStatus_NOT_FINISHED
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{19}
 }
 
-type GetSamePositionsResponse_Kifu struct {
+type ListTrashResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string                           `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	KifuId string                           `protobuf:"bytes,2,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
-	Seq    int32                            `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
-	Steps  []*GetSamePositionsResponse_Step `protobuf:"bytes,4,rep,name=steps,proto3" json:"steps,omitempty"`
+	Kifus []*ListTrashResponse_Kifu `protobuf:"bytes,1,rep,name=kifus,proto3" json:"kifus,omitempty"`
 }
 
-func (x *GetSamePositionsResponse_Kifu) Reset() {
-	*x = GetSamePositionsResponse_Kifu{}
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_kifu_proto_msgTypes[18]
+		mi := &file_proto_kifu_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSamePositionsResponse_Kifu) String() string {
+func (x *ListTrashResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSamePositionsResponse_Kifu) ProtoMessage() {}
+func (*ListTrashResponse) ProtoMessage() {}
 
-func (x *GetSamePositionsResponse_Kifu) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kifu_proto_msgTypes[18]
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1410,283 +1669,5622 @@ func (x *GetSamePositionsResponse_Kifu) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSamePositionsResponse_Kifu.ProtoReflect.Descriptor instead.
-func (*GetSamePositionsResponse_Kifu) Descriptor() ([]byte, []int) {
-	return file_proto_kifu_proto_rawDescGZIP(), []int{13, 1}
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetSamePositionsResponse_Kifu) GetUserId() string {
+func (x *ListTrashResponse) GetKifus() []*ListTrashResponse_Kifu {
 	if x != nil {
-		return x.UserId
+		return x.Kifus
 	}
-	return ""
+	return nil
 }
 
-func (x *GetSamePositionsResponse_Kifu) GetKifuId() string {
-	if x != nil {
-		return x.KifuId
-	}
-	return ""
+type RestoreKifuRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *GetSamePositionsResponse_Kifu) GetSeq() int32 {
-	if x != nil {
-		return x.Seq
+func (x *RestoreKifuRequest) Reset() {
+	*x = RestoreKifuRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *GetSamePositionsResponse_Kifu) GetSteps() []*GetSamePositionsResponse_Step {
-	if x != nil {
-		return x.Steps
+func (x *RestoreKifuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreKifuRequest) ProtoMessage() {}
+
+func (x *RestoreKifuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreKifuRequest.ProtoReflect.Descriptor instead.
+func (*RestoreKifuRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RestoreKifuRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *RestoreKifuRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RestoreKifuResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *RestoreKifuResponse) Reset() {
+	*x = RestoreKifuResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreKifuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreKifuResponse) ProtoMessage() {}
+
+func (x *RestoreKifuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreKifuResponse.ProtoReflect.Descriptor instead.
+func (*RestoreKifuResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RestoreKifuResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type AddTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string   `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Tags    []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Version int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddTagsRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
 	}
 	return nil
 }
 
-var File_proto_kifu_proto protoreflect.FileDescriptor
+func (x *AddTagsRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
 
-var file_proto_kifu_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x04, 0x6b, 0x69, 0x66, 0x75, 0x22, 0x29, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x65,
-	0x6e, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x22, 0xd2, 0x02, 0x0a, 0x12, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x4b, 0x69,
-	0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x6b, 0x69,
-	0x66, 0x75, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x69, 0x66, 0x75,
-	0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x1a,
-	0x86, 0x02, 0x0a, 0x04, 0x4b, 0x69, 0x66, 0x75, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x54, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61,
-	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61,
-	0x70, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23,
-	0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18,
-	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x70, 0x6c,
-	0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f,
-	0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5f, 0x0a, 0x0f, 0x50, 0x6f, 0x73, 0x74,
-	0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
-	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1a, 0x0a,
-	0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x45, 0x0a, 0x10, 0x50, 0x6f, 0x73,
-	0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a,
-	0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x22, 0x46, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x29,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x22, 0x21, 0x0a, 0x03, 0x50, 0x6f, 0x73,
-	0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c,
-	0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x22, 0xaa, 0x01, 0x0a,
-	0x05, 0x50, 0x69, 0x65, 0x63, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x02, 0x49, 0x64, 0x12, 0x08, 0x0a,
-	0x04, 0x4e, 0x55, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x59, 0x4f, 0x4b, 0x55,
-	0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x48, 0x49, 0x53, 0x48, 0x41, 0x10, 0x02, 0x12, 0x07, 0x0a,
-	0x03, 0x52, 0x59, 0x55, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x4b, 0x41, 0x4b, 0x55, 0x10, 0x04,
-	0x12, 0x07, 0x0a, 0x03, 0x55, 0x4d, 0x41, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x49, 0x4e,
-	0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x49, 0x4e, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x4e,
-	0x41, 0x52, 0x49, 0x5f, 0x47, 0x49, 0x4e, 0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45, 0x49,
-	0x10, 0x09, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x41, 0x52, 0x49, 0x5f, 0x4b, 0x45, 0x49, 0x10, 0x0a,
-	0x12, 0x08, 0x0a, 0x04, 0x4b, 0x59, 0x4f, 0x55, 0x10, 0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x41,
-	0x52, 0x49, 0x5f, 0x4b, 0x59, 0x4f, 0x55, 0x10, 0x0c, 0x12, 0x06, 0x0a, 0x02, 0x46, 0x55, 0x10,
-	0x0d, 0x12, 0x06, 0x0a, 0x02, 0x54, 0x4f, 0x10, 0x0e, 0x22, 0xb7, 0x01, 0x0a, 0x0e, 0x46, 0x69,
-	0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa4, 0x01, 0x0a,
-	0x02, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53,
-	0x48, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x53, 0x50, 0x45, 0x4e, 0x44,
-	0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x10,
-	0x02, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x52, 0x41, 0x57, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x52,
-	0x45, 0x50, 0x45, 0x54, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x52, 0x41, 0x57, 0x10, 0x04,
-	0x12, 0x0d, 0x0a, 0x09, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x4d, 0x41, 0x54, 0x45, 0x10, 0x05, 0x12,
-	0x13, 0x0a, 0x0f, 0x4f, 0x56, 0x45, 0x52, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x4c, 0x49, 0x4d,
-	0x49, 0x54, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x4f, 0x55, 0x4c, 0x5f, 0x4c, 0x4f, 0x53,
-	0x53, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f, 0x55, 0x4c, 0x5f, 0x57, 0x49, 0x4e, 0x10,
-	0x08, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x59, 0x55, 0x47, 0x59, 0x4f, 0x4b, 0x55, 0x5f, 0x57, 0x49,
-	0x4e, 0x10, 0x09, 0x22, 0x31, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xaa, 0x07, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4b, 0x69,
-	0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x74,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x6e, 0x64, 0x54, 0x73, 0x12, 0x1a,
-	0x0a, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61,
-	0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67,
-	0x61, 0x6d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74,
-	0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x0c, 0x66, 0x69,
-	0x72, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x43, 0x0a, 0x0e, 0x73, 0x65,
-	0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66,
-	0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x52, 0x0d, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12,
-	0x2e, 0x0a, 0x0c, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
-	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x0b, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12,
-	0x12, 0x0a, 0x04, 0x73, 0x66, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73,
-	0x66, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74,
-	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x54, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73,
-	0x74, 0x65, 0x70, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x1a, 0x30, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x6f, 0x74, 0x65, 0x1a, 0xfc, 0x02, 0x0a, 0x04, 0x53, 0x74, 0x65, 0x70, 0x12, 0x10, 0x0a,
-	0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
-	0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x03, 0x73,
-	0x72, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e,
-	0x50, 0x6f, 0x73, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x1b, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50, 0x6f, 0x73,
-	0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50, 0x69, 0x65, 0x63,
-	0x65, 0x2e, 0x49, 0x64, 0x52, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x0f, 0x66,
-	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x46, 0x69, 0x6e, 0x69,
-	0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x49, 0x64, 0x52, 0x0e, 0x66,
-	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x08, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x08, 0x63, 0x61, 0x70,
-	0x74, 0x75, 0x72, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6b, 0x69,
-	0x66, 0x75, 0x2e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x49, 0x64, 0x52, 0x08, 0x63, 0x61, 0x70,
-	0x74, 0x75, 0x72, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x65, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x68,
-	0x69, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x74, 0x68, 0x69, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x63, 0x12, 0x14, 0x0a,
-	0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f,
-	0x74, 0x65, 0x73, 0x22, 0x75, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x65, 0x50, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
-	0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73,
-	0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6b, 0x69, 0x66, 0x75,
-	0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c,
-	0x75, 0x64, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x73, 0x22, 0xd2, 0x03, 0x0a, 0x18, 0x47,
-	0x65, 0x74, 0x53, 0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d,
-	0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x1a, 0xd6,
-	0x01, 0x0a, 0x04, 0x53, 0x74, 0x65, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1b, 0x0a, 0x03, 0x73, 0x72, 0x63,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50, 0x6f,
-	0x73, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x1b, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03,
-	0x64, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e,
-	0x49, 0x64, 0x52, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
-	0x6d, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x72, 0x6f,
-	0x6d, 0x6f, 0x74, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
-	0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17,
-	0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x2e, 0x49, 0x64, 0x52, 0x0e, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
-	0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x85, 0x01, 0x0a, 0x04, 0x4b, 0x69, 0x66, 0x75,
-	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66,
-	0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75,
-	0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x03, 0x73, 0x65, 0x71, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x61,
-	0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x42,
-	0x0c, 0x5a, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x69, 0x66, 0x75, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+type AddTagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-var (
-	file_proto_kifu_proto_rawDescOnce sync.Once
-	file_proto_kifu_proto_rawDescData = file_proto_kifu_proto_rawDesc
-)
+func (x *AddTagsResponse) Reset() {
+	*x = AddTagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_proto_kifu_proto_rawDescGZIP() []byte {
-	file_proto_kifu_proto_rawDescOnce.Do(func() {
-		file_proto_kifu_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_kifu_proto_rawDescData)
-	})
-	return file_proto_kifu_proto_rawDescData
+func (x *AddTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_proto_kifu_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_kifu_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
-var file_proto_kifu_proto_goTypes = []interface{}{
-	(Piece_Id)(0),                         // 0: kifu.Piece.Id
-	(FinishedStatus_Id)(0),                // 1: kifu.FinishedStatus.Id
-	(*RecentKifuRequest)(nil),             // 2: kifu.RecentKifuRequest
-	(*RecentKifuResponse)(nil),            // 3: kifu.RecentKifuResponse
-	(*PostKifuRequest)(nil),               // 4: kifu.PostKifuRequest
-	(*PostKifuResponse)(nil),              // 5: kifu.PostKifuResponse
-	(*DeleteKifuRequest)(nil),             // 6: kifu.DeleteKifuRequest
-	(*DeleteKifuResponse)(nil),            // 7: kifu.DeleteKifuResponse
-	(*GetKifuRequest)(nil),                // 8: kifu.GetKifuRequest
-	(*Pos)(nil),                           // 9: kifu.Pos
-	(*Piece)(nil),                         // 10: kifu.Piece
-	(*FinishedStatus)(nil),                // 11: kifu.FinishedStatus
-	(*Value)(nil),                         // 12: kifu.Value
-	(*GetKifuResponse)(nil),               // 13: kifu.GetKifuResponse
-	(*GetSamePositionsRequest)(nil),       // 14: kifu.GetSamePositionsRequest
-	(*GetSamePositionsResponse)(nil),      // 15: kifu.GetSamePositionsResponse
-	(*RecentKifuResponse_Kifu)(nil),       // 16: kifu.RecentKifuResponse.Kifu
-	(*GetKifuResponse_Player)(nil),        // 17: kifu.GetKifuResponse.Player
-	(*GetKifuResponse_Step)(nil),          // 18: kifu.GetKifuResponse.Step
-	(*GetSamePositionsResponse_Step)(nil), // 19: kifu.GetSamePositionsResponse.Step
-	(*GetSamePositionsResponse_Kifu)(nil), // 20: kifu.GetSamePositionsResponse.Kifu
+func (*AddTagsResponse) ProtoMessage() {}
+
+func (x *AddTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_proto_kifu_proto_depIdxs = []int32{
-	16, // 0: kifu.RecentKifuResponse.kifus:type_name -> kifu.RecentKifuResponse.Kifu
-	17, // 1: kifu.GetKifuResponse.first_players:type_name -> kifu.GetKifuResponse.Player
-	17, // 2: kifu.GetKifuResponse.second_players:type_name -> kifu.GetKifuResponse.Player
-	12, // 3: kifu.GetKifuResponse.other_fields:type_name -> kifu.Value
-	18, // 4: kifu.GetKifuResponse.steps:type_name -> kifu.GetKifuResponse.Step
-	20, // 5: kifu.GetSamePositionsResponse.kifus:type_name -> kifu.GetSamePositionsResponse.Kifu
-	9,  // 6: kifu.GetKifuResponse.Step.src:type_name -> kifu.Pos
-	9,  // 7: kifu.GetKifuResponse.Step.dst:type_name -> kifu.Pos
-	0,  // 8: kifu.GetKifuResponse.Step.piece:type_name -> kifu.Piece.Id
-	1,  // 9: kifu.GetKifuResponse.Step.finished_status:type_name -> kifu.FinishedStatus.Id
-	0,  // 10: kifu.GetKifuResponse.Step.captured:type_name -> kifu.Piece.Id
-	9,  // 11: kifu.GetSamePositionsResponse.Step.src:type_name -> kifu.Pos
-	9,  // 12: kifu.GetSamePositionsResponse.Step.dst:type_name -> kifu.Pos
-	0,  // 13: kifu.GetSamePositionsResponse.Step.piece:type_name -> kifu.Piece.Id
-	1,  // 14: kifu.GetSamePositionsResponse.Step.finished_status:type_name -> kifu.FinishedStatus.Id
-	19, // 15: kifu.GetSamePositionsResponse.Kifu.steps:type_name -> kifu.GetSamePositionsResponse.Step
-	16, // [16:16] is the sub-list for method output_type
-	16, // [16:16] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+
+// Deprecated: Use AddTagsResponse.ProtoReflect.Descriptor instead.
+func (*AddTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{24}
 }
 
-func init() { file_proto_kifu_proto_init() }
-func file_proto_kifu_proto_init() {
-	if File_proto_kifu_proto != nil {
-		return
+func (x *AddTagsResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_kifu_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RecentKifuRequest); i {
+	return 0
+}
+
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string   `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Tags    []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Version int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RemoveTagsRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RemoveTagsRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RemoveTagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *RemoveTagsResponse) Reset() {
+	*x = RemoveTagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsResponse) ProtoMessage() {}
+
+func (x *RemoveTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveTagsResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListKifuByTagRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag   string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Limit int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_page_token of the previous response.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListKifuByTagRequest) Reset() {
+	*x = ListKifuByTagRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListKifuByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKifuByTagRequest) ProtoMessage() {}
+
+func (x *ListKifuByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKifuByTagRequest.ProtoReflect.Descriptor instead.
+func (*ListKifuByTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListKifuByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListKifuByTagRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListKifuByTagRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListKifuByTagResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kifus []*RecentKifuResponse_Kifu `protobuf:"bytes,1,rep,name=kifus,proto3" json:"kifus,omitempty"`
+	// empty if there are no more kifus.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListKifuByTagResponse) Reset() {
+	*x = ListKifuByTagResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListKifuByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKifuByTagResponse) ProtoMessage() {}
+
+func (x *ListKifuByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKifuByTagResponse.ProtoReflect.Descriptor instead.
+func (*ListKifuByTagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListKifuByTagResponse) GetKifus() []*RecentKifuResponse_Kifu {
+	if x != nil {
+		return x.Kifus
+	}
+	return nil
+}
+
+func (x *ListKifuByTagResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ShareKifuRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// valid values: READ
+	Role    string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Version int64  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *ShareKifuRequest) Reset() {
+	*x = ShareKifuRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareKifuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareKifuRequest) ProtoMessage() {}
+
+func (x *ShareKifuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareKifuRequest.ProtoReflect.Descriptor instead.
+func (*ShareKifuRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ShareKifuRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *ShareKifuRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareKifuRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ShareKifuRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ShareKifuResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *ShareKifuResponse) Reset() {
+	*x = ShareKifuResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareKifuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareKifuResponse) ProtoMessage() {}
+
+func (x *ShareKifuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareKifuResponse.ProtoReflect.Descriptor instead.
+func (*ShareKifuResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ShareKifuResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UnshareKifuRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	UserId  string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Version int64  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UnshareKifuRequest) Reset() {
+	*x = UnshareKifuRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnshareKifuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareKifuRequest) ProtoMessage() {}
+
+func (x *UnshareKifuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareKifuRequest.ProtoReflect.Descriptor instead.
+func (*UnshareKifuRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UnshareKifuRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *UnshareKifuRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnshareKifuRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UnshareKifuResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UnshareKifuResponse) Reset() {
+	*x = UnshareKifuResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnshareKifuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareKifuResponse) ProtoMessage() {}
+
+func (x *UnshareKifuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareKifuResponse.ProtoReflect.Descriptor instead.
+func (*UnshareKifuResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UnshareKifuResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateKifuLinkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *CreateKifuLinkRequest) Reset() {
+	*x = CreateKifuLinkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateKifuLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKifuLinkRequest) ProtoMessage() {}
+
+func (x *CreateKifuLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKifuLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateKifuLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateKifuLinkRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *CreateKifuLinkRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateKifuLinkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LinkToken string `protobuf:"bytes,1,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
+	Version   int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *CreateKifuLinkResponse) Reset() {
+	*x = CreateKifuLinkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateKifuLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKifuLinkResponse) ProtoMessage() {}
+
+func (x *CreateKifuLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKifuLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateKifuLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateKifuLinkResponse) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
+}
+
+func (x *CreateKifuLinkResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteKifuLinkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *DeleteKifuLinkRequest) Reset() {
+	*x = DeleteKifuLinkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteKifuLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKifuLinkRequest) ProtoMessage() {}
+
+func (x *DeleteKifuLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKifuLinkRequest.ProtoReflect.Descriptor instead.
+func (*DeleteKifuLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteKifuLinkRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *DeleteKifuLinkRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteKifuLinkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *DeleteKifuLinkResponse) Reset() {
+	*x = DeleteKifuLinkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteKifuLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKifuLinkResponse) ProtoMessage() {}
+
+func (x *DeleteKifuLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKifuLinkResponse.ProtoReflect.Descriptor instead.
+func (*DeleteKifuLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteKifuLinkResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateTeamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the caller is always a member.
+	// the invited users become members when they accept with AcceptTeamInvitation.
+	InviteUserIds []string `protobuf:"bytes,2,rep,name=invite_user_ids,json=inviteUserIds,proto3" json:"invite_user_ids,omitempty"`
+}
+
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateTeamRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTeamRequest) GetInviteUserIds() []string {
+	if x != nil {
+		return x.InviteUserIds
+	}
+	return nil
+}
+
+type CreateTeamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamId  string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *CreateTeamResponse) Reset() {
+	*x = CreateTeamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamResponse) ProtoMessage() {}
+
+func (x *CreateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamResponse.ProtoReflect.Descriptor instead.
+func (*CreateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateTeamResponse) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *CreateTeamResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetTeamRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type GetTeamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamId         string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Name           string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OwnerUserId    string   `protobuf:"bytes,3,opt,name=owner_user_id,json=ownerUserId,proto3" json:"owner_user_id,omitempty"`
+	MemberUserIds  []string `protobuf:"bytes,4,rep,name=member_user_ids,json=memberUserIds,proto3" json:"member_user_ids,omitempty"`
+	CreatedTs      int64    `protobuf:"varint,5,opt,name=created_ts,json=createdTs,proto3" json:"created_ts,omitempty"`
+	Version        int64    `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	InvitedUserIds []string `protobuf:"bytes,7,rep,name=invited_user_ids,json=invitedUserIds,proto3" json:"invited_user_ids,omitempty"`
+}
+
+func (x *GetTeamResponse) Reset() {
+	*x = GetTeamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamResponse) ProtoMessage() {}
+
+func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetTeamResponse) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *GetTeamResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTeamResponse) GetOwnerUserId() string {
+	if x != nil {
+		return x.OwnerUserId
+	}
+	return ""
+}
+
+func (x *GetTeamResponse) GetMemberUserIds() []string {
+	if x != nil {
+		return x.MemberUserIds
+	}
+	return nil
+}
+
+func (x *GetTeamResponse) GetCreatedTs() int64 {
+	if x != nil {
+		return x.CreatedTs
+	}
+	return 0
+}
+
+func (x *GetTeamResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetTeamResponse) GetInvitedUserIds() []string {
+	if x != nil {
+		return x.InvitedUserIds
+	}
+	return nil
+}
+
+type UpdateTeamMembersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// the invited users become members when they accept with AcceptTeamInvitation.
+	InviteUserIds []string `protobuf:"bytes,2,rep,name=invite_user_ids,json=inviteUserIds,proto3" json:"invite_user_ids,omitempty"`
+	// removes the members and cancels the invitations.
+	RemoveUserIds []string `protobuf:"bytes,3,rep,name=remove_user_ids,json=removeUserIds,proto3" json:"remove_user_ids,omitempty"`
+	Version       int64    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UpdateTeamMembersRequest) Reset() {
+	*x = UpdateTeamMembersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTeamMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamMembersRequest) ProtoMessage() {}
+
+func (x *UpdateTeamMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamMembersRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTeamMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UpdateTeamMembersRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *UpdateTeamMembersRequest) GetInviteUserIds() []string {
+	if x != nil {
+		return x.InviteUserIds
+	}
+	return nil
+}
+
+func (x *UpdateTeamMembersRequest) GetRemoveUserIds() []string {
+	if x != nil {
+		return x.RemoveUserIds
+	}
+	return nil
+}
+
+func (x *UpdateTeamMembersRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateTeamMembersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UpdateTeamMembersResponse) Reset() {
+	*x = UpdateTeamMembersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTeamMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamMembersResponse) ProtoMessage() {}
+
+func (x *UpdateTeamMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamMembersResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTeamMembersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateTeamMembersResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type AcceptTeamInvitationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+}
+
+func (x *AcceptTeamInvitationRequest) Reset() {
+	*x = AcceptTeamInvitationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcceptTeamInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTeamInvitationRequest) ProtoMessage() {}
+
+func (x *AcceptTeamInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTeamInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AcceptTeamInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AcceptTeamInvitationRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type AcceptTeamInvitationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *AcceptTeamInvitationResponse) Reset() {
+	*x = AcceptTeamInvitationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcceptTeamInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTeamInvitationResponse) ProtoMessage() {}
+
+func (x *AcceptTeamInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTeamInvitationResponse.ProtoReflect.Descriptor instead.
+func (*AcceptTeamInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AcceptTeamInvitationResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// LeaveTeamRequest leaves the team, or declines the invitation.
+// The owner cannot leave the team.
+type LeaveTeamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+}
+
+func (x *LeaveTeamRequest) Reset() {
+	*x = LeaveTeamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaveTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTeamRequest) ProtoMessage() {}
+
+func (x *LeaveTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTeamRequest.ProtoReflect.Descriptor instead.
+func (*LeaveTeamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *LeaveTeamRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type LeaveTeamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LeaveTeamResponse) Reset() {
+	*x = LeaveTeamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaveTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTeamResponse) ProtoMessage() {}
+
+func (x *LeaveTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTeamResponse.ProtoReflect.Descriptor instead.
+func (*LeaveTeamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{46}
+}
+
+type JoinPublicPoolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *JoinPublicPoolRequest) Reset() {
+	*x = JoinPublicPoolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinPublicPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinPublicPoolRequest) ProtoMessage() {}
+
+func (x *JoinPublicPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinPublicPoolRequest.ProtoReflect.Descriptor instead.
+func (*JoinPublicPoolRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{47}
+}
+
+type JoinPublicPoolResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *JoinPublicPoolResponse) Reset() {
+	*x = JoinPublicPoolResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinPublicPoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinPublicPoolResponse) ProtoMessage() {}
+
+func (x *JoinPublicPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinPublicPoolResponse.ProtoReflect.Descriptor instead.
+func (*JoinPublicPoolResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{48}
+}
+
+type LeavePublicPoolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LeavePublicPoolRequest) Reset() {
+	*x = LeavePublicPoolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeavePublicPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeavePublicPoolRequest) ProtoMessage() {}
+
+func (x *LeavePublicPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeavePublicPoolRequest.ProtoReflect.Descriptor instead.
+func (*LeavePublicPoolRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{49}
+}
+
+type LeavePublicPoolResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LeavePublicPoolResponse) Reset() {
+	*x = LeavePublicPoolResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeavePublicPoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeavePublicPoolResponse) ProtoMessage() {}
+
+func (x *LeavePublicPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeavePublicPoolResponse.ProtoReflect.Descriptor instead.
+func (*LeavePublicPoolResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{50}
+}
+
+type SearchPatternRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Squares []*SearchPatternRequest_Square `protobuf:"bytes,1,rep,name=squares,proto3" json:"squares,omitempty"`
+	Hands   []*SearchPatternRequest_Hand   `protobuf:"bytes,2,rep,name=hands,proto3" json:"hands,omitempty"`
+	// also match the pattern with the colors swapped.
+	EitherSide bool `protobuf:"varint,3,opt,name=either_side,json=eitherSide,proto3" json:"either_side,omitempty"`
+	// maximum number of matches. default: 20
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// only kifus in which either side or the game has the strategy (戦型) are searched.
+	Strategy string `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// next_page_token of the previous response.
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *SearchPatternRequest) Reset() {
+	*x = SearchPatternRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPatternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPatternRequest) ProtoMessage() {}
+
+func (x *SearchPatternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPatternRequest.ProtoReflect.Descriptor instead.
+func (*SearchPatternRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SearchPatternRequest) GetSquares() []*SearchPatternRequest_Square {
+	if x != nil {
+		return x.Squares
+	}
+	return nil
+}
+
+func (x *SearchPatternRequest) GetHands() []*SearchPatternRequest_Hand {
+	if x != nil {
+		return x.Hands
+	}
+	return nil
+}
+
+func (x *SearchPatternRequest) GetEitherSide() bool {
+	if x != nil {
+		return x.EitherSide
+	}
+	return false
+}
+
+func (x *SearchPatternRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchPatternRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *SearchPatternRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// matches are ordered by the upload time, the most recent first.
+// A page can have fewer matches than the limit, since a request searches a bounded number of kifus.
+type SearchPatternResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Matches []*SearchPatternResponse_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	// empty if all kifus are searched.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *SearchPatternResponse) Reset() {
+	*x = SearchPatternResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPatternResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPatternResponse) ProtoMessage() {}
+
+func (x *SearchPatternResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPatternResponse.ProtoReflect.Descriptor instead.
+func (*SearchPatternResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SearchPatternResponse) GetMatches() []*SearchPatternResponse_Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *SearchPatternResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetKifuStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only kifus in which either side or the game has the strategy (戦型) are counted.
+	Strategy string `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+}
+
+func (x *GetKifuStatsRequest) Reset() {
+	*x = GetKifuStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuStatsRequest) ProtoMessage() {}
+
+func (x *GetKifuStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetKifuStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetKifuStatsRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+type GetKifuStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total int32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// most played first.
+	Strategies []*GetKifuStatsResponse_Strategy `protobuf:"bytes,2,rep,name=strategies,proto3" json:"strategies,omitempty"`
+}
+
+func (x *GetKifuStatsResponse) Reset() {
+	*x = GetKifuStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuStatsResponse) ProtoMessage() {}
+
+func (x *GetKifuStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetKifuStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetKifuStatsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetKifuStatsResponse) GetStrategies() []*GetKifuStatsResponse_Strategy {
+	if x != nil {
+		return x.Strategies
+	}
+	return nil
+}
+
+type FindMissedMatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	// maximum length of the mates in plies. default 5.
+	MaxPly int32 `protobuf:"varint,2,opt,name=max_ply,json=maxPly,proto3" json:"max_ply,omitempty"`
+}
+
+func (x *FindMissedMatesRequest) Reset() {
+	*x = FindMissedMatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindMissedMatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMissedMatesRequest) ProtoMessage() {}
+
+func (x *FindMissedMatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMissedMatesRequest.ProtoReflect.Descriptor instead.
+func (*FindMissedMatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *FindMissedMatesRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *FindMissedMatesRequest) GetMaxPly() int32 {
+	if x != nil {
+		return x.MaxPly
+	}
+	return 0
+}
+
+type FindMissedMatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MissedMates []*FindMissedMatesResponse_MissedMate `protobuf:"bytes,1,rep,name=missed_mates,json=missedMates,proto3" json:"missed_mates,omitempty"`
+}
+
+func (x *FindMissedMatesResponse) Reset() {
+	*x = FindMissedMatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindMissedMatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMissedMatesResponse) ProtoMessage() {}
+
+func (x *FindMissedMatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMissedMatesResponse.ProtoReflect.Descriptor instead.
+func (*FindMissedMatesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *FindMissedMatesResponse) GetMissedMates() []*FindMissedMatesResponse_MissedMate {
+	if x != nil {
+		return x.MissedMates
+	}
+	return nil
+}
+
+type RenderPositionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Seq    int32  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// required if the caller cannot read the kifu otherwise.
+	LinkToken string `protobuf:"bytes,3,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
+	// valid values: SVG | PNG. default: SVG
+	// PNG has only an ASCII font, so the player names in other letters are drawn only in SVG.
+	Format string                         `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	Arrows []*RenderPositionRequest_Arrow `protobuf:"bytes,5,rep,name=arrows,proto3" json:"arrows,omitempty"`
+	// size of a square in pixels. default: 40
+	CellSize int32 `protobuf:"varint,6,opt,name=cell_size,json=cellSize,proto3" json:"cell_size,omitempty"`
+}
+
+func (x *RenderPositionRequest) Reset() {
+	*x = RenderPositionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderPositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPositionRequest) ProtoMessage() {}
+
+func (x *RenderPositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPositionRequest.ProtoReflect.Descriptor instead.
+func (*RenderPositionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RenderPositionRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *RenderPositionRequest) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *RenderPositionRequest) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
+}
+
+func (x *RenderPositionRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *RenderPositionRequest) GetArrows() []*RenderPositionRequest_Arrow {
+	if x != nil {
+		return x.Arrows
+	}
+	return nil
+}
+
+func (x *RenderPositionRequest) GetCellSize() int32 {
+	if x != nil {
+		return x.CellSize
+	}
+	return 0
+}
+
+type RenderPositionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// image/svg+xml | image/png
+	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// base64 encoded image.
+	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RenderPositionResponse) Reset() {
+	*x = RenderPositionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderPositionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPositionResponse) ProtoMessage() {}
+
+func (x *RenderPositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPositionResponse.ProtoReflect.Descriptor instead.
+func (*RenderPositionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RenderPositionResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *RenderPositionResponse) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type ExportAnimationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	// required if the caller cannot read the kifu otherwise.
+	LinkToken string `protobuf:"bytes,2,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
+	// range of the steps. end_seq 0 means the last step.
+	StartSeq int32 `protobuf:"varint,3,opt,name=start_seq,json=startSeq,proto3" json:"start_seq,omitempty"`
+	EndSeq   int32 `protobuf:"varint,4,opt,name=end_seq,json=endSeq,proto3" json:"end_seq,omitempty"`
+	// draw the notes of the steps under the board.
+	// GIF and PNG have only an ASCII font, so the notes in other letters are drawn only in SVG.
+	Notes bool `protobuf:"varint,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	// valid values: GIF | PNG | SVG. default: GIF
+	// PNG and SVG return the frames instead of the animation.
+	Format string `protobuf:"bytes,6,opt,name=format,proto3" json:"format,omitempty"`
+	// display time of a frame in milliseconds, rounded to 10 milliseconds for GIF. default: 1000
+	DelayMs int32 `protobuf:"varint,7,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+	// size of a square in pixels. default: 40
+	CellSize int32 `protobuf:"varint,8,opt,name=cell_size,json=cellSize,proto3" json:"cell_size,omitempty"`
+}
+
+func (x *ExportAnimationRequest) Reset() {
+	*x = ExportAnimationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportAnimationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAnimationRequest) ProtoMessage() {}
+
+func (x *ExportAnimationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAnimationRequest.ProtoReflect.Descriptor instead.
+func (*ExportAnimationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ExportAnimationRequest) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *ExportAnimationRequest) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
+}
+
+func (x *ExportAnimationRequest) GetStartSeq() int32 {
+	if x != nil {
+		return x.StartSeq
+	}
+	return 0
+}
+
+func (x *ExportAnimationRequest) GetEndSeq() int32 {
+	if x != nil {
+		return x.EndSeq
+	}
+	return 0
+}
+
+func (x *ExportAnimationRequest) GetNotes() bool {
+	if x != nil {
+		return x.Notes
+	}
+	return false
+}
+
+func (x *ExportAnimationRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportAnimationRequest) GetDelayMs() int32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *ExportAnimationRequest) GetCellSize() int32 {
+	if x != nil {
+		return x.CellSize
+	}
+	return 0
+}
+
+type ExportAnimationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// image/gif | image/png | image/svg+xml
+	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// base64 encoded animated GIF.
+	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// base64 encoded frames for PNG and SVG, in the order of the steps.
+	Frames []string `protobuf:"bytes,3,rep,name=frames,proto3" json:"frames,omitempty"`
+}
+
+func (x *ExportAnimationResponse) Reset() {
+	*x = ExportAnimationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportAnimationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAnimationResponse) ProtoMessage() {}
+
+func (x *ExportAnimationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAnimationResponse.ProtoReflect.Descriptor instead.
+func (*ExportAnimationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ExportAnimationResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportAnimationResponse) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ExportAnimationResponse) GetFrames() []string {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+// admin only.
+type BulkDeleteKifuRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuIds []string `protobuf:"bytes,1,rep,name=kifu_ids,json=kifuIds,proto3" json:"kifu_ids,omitempty"`
+	// delete all kifu of the user in addition to kifu_ids.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// move to the trash instead of deleting.
+	Soft bool `protobuf:"varint,3,opt,name=soft,proto3" json:"soft,omitempty"`
+}
+
+func (x *BulkDeleteKifuRequest) Reset() {
+	*x = BulkDeleteKifuRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkDeleteKifuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteKifuRequest) ProtoMessage() {}
+
+func (x *BulkDeleteKifuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteKifuRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteKifuRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *BulkDeleteKifuRequest) GetKifuIds() []string {
+	if x != nil {
+		return x.KifuIds
+	}
+	return nil
+}
+
+func (x *BulkDeleteKifuRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BulkDeleteKifuRequest) GetSoft() bool {
+	if x != nil {
+		return x.Soft
+	}
+	return false
+}
+
+type BulkDeleteKifuResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeletedKifuIds []string                          `protobuf:"bytes,1,rep,name=deleted_kifu_ids,json=deletedKifuIds,proto3" json:"deleted_kifu_ids,omitempty"`
+	Failures       []*BulkDeleteKifuResponse_Failure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *BulkDeleteKifuResponse) Reset() {
+	*x = BulkDeleteKifuResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkDeleteKifuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteKifuResponse) ProtoMessage() {}
+
+func (x *BulkDeleteKifuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteKifuResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteKifuResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *BulkDeleteKifuResponse) GetDeletedKifuIds() []string {
+	if x != nil {
+		return x.DeletedKifuIds
+	}
+	return nil
+}
+
+func (x *BulkDeleteKifuResponse) GetFailures() []*BulkDeleteKifuResponse_Failure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// admin only. RecentKifu of any user.
+type ListUserKifuRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListUserKifuRequest) Reset() {
+	*x = ListUserKifuRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListUserKifuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserKifuRequest) ProtoMessage() {}
+
+func (x *ListUserKifuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserKifuRequest.ProtoReflect.Descriptor instead.
+func (*ListUserKifuRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListUserKifuRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserKifuRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListUserKifuResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kifus []*RecentKifuResponse_Kifu `protobuf:"bytes,1,rep,name=kifus,proto3" json:"kifus,omitempty"`
+}
+
+func (x *ListUserKifuResponse) Reset() {
+	*x = ListUserKifuResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListUserKifuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserKifuResponse) ProtoMessage() {}
+
+func (x *ListUserKifuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserKifuResponse.ProtoReflect.Descriptor instead.
+func (*ListUserKifuResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListUserKifuResponse) GetKifus() []*RecentKifuResponse_Kifu {
+	if x != nil {
+		return x.Kifus
+	}
+	return nil
+}
+
+// admin only. recompute the index keys, e.g. after an index is added.
+type ReindexKifuRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuIds []string `protobuf:"bytes,1,rep,name=kifu_ids,json=kifuIds,proto3" json:"kifu_ids,omitempty"`
+	// reindex all kifu of the user in addition to kifu_ids.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *ReindexKifuRequest) Reset() {
+	*x = ReindexKifuRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexKifuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexKifuRequest) ProtoMessage() {}
+
+func (x *ReindexKifuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexKifuRequest.ProtoReflect.Descriptor instead.
+func (*ReindexKifuRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ReindexKifuRequest) GetKifuIds() []string {
+	if x != nil {
+		return x.KifuIds
+	}
+	return nil
+}
+
+func (x *ReindexKifuRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ReindexKifuResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReindexedKifuIds []string                       `protobuf:"bytes,1,rep,name=reindexed_kifu_ids,json=reindexedKifuIds,proto3" json:"reindexed_kifu_ids,omitempty"`
+	Failures         []*ReindexKifuResponse_Failure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *ReindexKifuResponse) Reset() {
+	*x = ReindexKifuResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexKifuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexKifuResponse) ProtoMessage() {}
+
+func (x *ReindexKifuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexKifuResponse.ProtoReflect.Descriptor instead.
+func (*ReindexKifuResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ReindexKifuResponse) GetReindexedKifuIds() []string {
+	if x != nil {
+		return x.ReindexedKifuIds
+	}
+	return nil
+}
+
+func (x *ReindexKifuResponse) GetFailures() []*ReindexKifuResponse_Failure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type RecentKifuResponse_Kifu struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KifuId          string   `protobuf:"bytes,2,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	StartTs         int64    `protobuf:"varint,3,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
+	Handicap        string   `protobuf:"bytes,4,opt,name=handicap,proto3" json:"handicap,omitempty"`
+	GameName        string   `protobuf:"bytes,5,opt,name=game_name,json=gameName,proto3" json:"game_name,omitempty"`
+	FirstPlayers    []string `protobuf:"bytes,6,rep,name=first_players,json=firstPlayers,proto3" json:"first_players,omitempty"`
+	SecondPlayers   []string `protobuf:"bytes,7,rep,name=second_players,json=secondPlayers,proto3" json:"second_players,omitempty"`
+	Note            string   `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	Version         int64    `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	Tags            []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	BlackStrategies []string `protobuf:"bytes,11,rep,name=black_strategies,json=blackStrategies,proto3" json:"black_strategies,omitempty"`
+	WhiteStrategies []string `protobuf:"bytes,12,rep,name=white_strategies,json=whiteStrategies,proto3" json:"white_strategies,omitempty"`
+	GameStrategies  []string `protobuf:"bytes,13,rep,name=game_strategies,json=gameStrategies,proto3" json:"game_strategies,omitempty"`
+}
+
+func (x *RecentKifuResponse_Kifu) Reset() {
+	*x = RecentKifuResponse_Kifu{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecentKifuResponse_Kifu) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentKifuResponse_Kifu) ProtoMessage() {}
+
+func (x *RecentKifuResponse_Kifu) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentKifuResponse_Kifu.ProtoReflect.Descriptor instead.
+func (*RecentKifuResponse_Kifu) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *RecentKifuResponse_Kifu) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecentKifuResponse_Kifu) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *RecentKifuResponse_Kifu) GetStartTs() int64 {
+	if x != nil {
+		return x.StartTs
+	}
+	return 0
+}
+
+func (x *RecentKifuResponse_Kifu) GetHandicap() string {
+	if x != nil {
+		return x.Handicap
+	}
+	return ""
+}
+
+func (x *RecentKifuResponse_Kifu) GetGameName() string {
+	if x != nil {
+		return x.GameName
+	}
+	return ""
+}
+
+func (x *RecentKifuResponse_Kifu) GetFirstPlayers() []string {
+	if x != nil {
+		return x.FirstPlayers
+	}
+	return nil
+}
+
+func (x *RecentKifuResponse_Kifu) GetSecondPlayers() []string {
+	if x != nil {
+		return x.SecondPlayers
+	}
+	return nil
+}
+
+func (x *RecentKifuResponse_Kifu) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *RecentKifuResponse_Kifu) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RecentKifuResponse_Kifu) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RecentKifuResponse_Kifu) GetBlackStrategies() []string {
+	if x != nil {
+		return x.BlackStrategies
+	}
+	return nil
+}
+
+func (x *RecentKifuResponse_Kifu) GetWhiteStrategies() []string {
+	if x != nil {
+		return x.WhiteStrategies
+	}
+	return nil
+}
+
+func (x *RecentKifuResponse_Kifu) GetGameStrategies() []string {
+	if x != nil {
+		return x.GameStrategies
+	}
+	return nil
+}
+
+type GetKifuResponse_Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Note string `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *GetKifuResponse_Player) Reset() {
+	*x = GetKifuResponse_Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuResponse_Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuResponse_Player) ProtoMessage() {}
+
+func (x *GetKifuResponse_Player) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuResponse_Player.ProtoReflect.Descriptor instead.
+func (*GetKifuResponse_Player) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *GetKifuResponse_Player) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetKifuResponse_Player) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type GetKifuResponse_Step struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq            int32             `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Position       string            `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	Src            *Pos              `protobuf:"bytes,3,opt,name=src,proto3" json:"src,omitempty"`
+	Dst            *Pos              `protobuf:"bytes,4,opt,name=dst,proto3" json:"dst,omitempty"`
+	Piece          Piece_Id          `protobuf:"varint,5,opt,name=piece,proto3,enum=kifu.Piece_Id" json:"piece,omitempty"`
+	FinishedStatus FinishedStatus_Id `protobuf:"varint,6,opt,name=finished_status,json=finishedStatus,proto3,enum=kifu.FinishedStatus_Id" json:"finished_status,omitempty"`
+	Promoted       bool              `protobuf:"varint,7,opt,name=promoted,proto3" json:"promoted,omitempty"`
+	Captured       Piece_Id          `protobuf:"varint,8,opt,name=captured,proto3,enum=kifu.Piece_Id" json:"captured,omitempty"`
+	TimestampSec   int32             `protobuf:"varint,9,opt,name=timestamp_sec,json=timestampSec,proto3" json:"timestamp_sec,omitempty"`
+	ThinkingSec    int32             `protobuf:"varint,10,opt,name=thinking_sec,json=thinkingSec,proto3" json:"thinking_sec,omitempty"`
+	Notes          []string          `protobuf:"bytes,11,rep,name=notes,proto3" json:"notes,omitempty"`
+}
+
+func (x *GetKifuResponse_Step) Reset() {
+	*x = GetKifuResponse_Step{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuResponse_Step) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuResponse_Step) ProtoMessage() {}
+
+func (x *GetKifuResponse_Step) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuResponse_Step.ProtoReflect.Descriptor instead.
+func (*GetKifuResponse_Step) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{11, 1}
+}
+
+func (x *GetKifuResponse_Step) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_Step) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
+func (x *GetKifuResponse_Step) GetSrc() *Pos {
+	if x != nil {
+		return x.Src
+	}
+	return nil
+}
+
+func (x *GetKifuResponse_Step) GetDst() *Pos {
+	if x != nil {
+		return x.Dst
+	}
+	return nil
+}
+
+func (x *GetKifuResponse_Step) GetPiece() Piece_Id {
+	if x != nil {
+		return x.Piece
+	}
+	return Piece_NULL
+}
+
+func (x *GetKifuResponse_Step) GetFinishedStatus() FinishedStatus_Id {
+	if x != nil {
+		return x.FinishedStatus
+	}
+	return FinishedStatus_NOT_FINISHED
+}
+
+func (x *GetKifuResponse_Step) GetPromoted() bool {
+	if x != nil {
+		return x.Promoted
+	}
+	return false
+}
+
+func (x *GetKifuResponse_Step) GetCaptured() Piece_Id {
+	if x != nil {
+		return x.Captured
+	}
+	return Piece_NULL
+}
+
+func (x *GetKifuResponse_Step) GetTimestampSec() int32 {
+	if x != nil {
+		return x.TimestampSec
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_Step) GetThinkingSec() int32 {
+	if x != nil {
+		return x.ThinkingSec
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_Step) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// castles (囲い) in the order of formation.
+type GetKifuResponse_Castle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq int32 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// valid values: BLACK | WHITE
+	Player string `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Name   string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetKifuResponse_Castle) Reset() {
+	*x = GetKifuResponse_Castle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuResponse_Castle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuResponse_Castle) ProtoMessage() {}
+
+func (x *GetKifuResponse_Castle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuResponse_Castle.ProtoReflect.Descriptor instead.
+func (*GetKifuResponse_Castle) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{11, 2}
+}
+
+func (x *GetKifuResponse_Castle) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_Castle) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *GetKifuResponse_Castle) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetKifuResponse_Repetition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// valid values: DRAW | PERPETUAL_CHECK
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// seq of the step where the position appeared for the fourth time.
+	Seq int32 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// valid values: BLACK | WHITE. only for PERPETUAL_CHECK.
+	CheckingPlayer string `protobuf:"bytes,3,opt,name=checking_player,json=checkingPlayer,proto3" json:"checking_player,omitempty"`
+}
+
+func (x *GetKifuResponse_Repetition) Reset() {
+	*x = GetKifuResponse_Repetition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuResponse_Repetition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuResponse_Repetition) ProtoMessage() {}
+
+func (x *GetKifuResponse_Repetition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuResponse_Repetition.ProtoReflect.Descriptor instead.
+func (*GetKifuResponse_Repetition) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{11, 3}
+}
+
+func (x *GetKifuResponse_Repetition) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GetKifuResponse_Repetition) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_Repetition) GetCheckingPlayer() string {
+	if x != nil {
+		return x.CheckingPlayer
+	}
+	return ""
+}
+
+type GetKifuResponse_ImpassePoints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq          int32 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	BlackEntered bool  `protobuf:"varint,2,opt,name=black_entered,json=blackEntered,proto3" json:"black_entered,omitempty"`
+	// pieces in the promotion zone excluding the king.
+	BlackPieces int32 `protobuf:"varint,3,opt,name=black_pieces,json=blackPieces,proto3" json:"black_pieces,omitempty"`
+	// points of the pieces in the promotion zone and in hand (27-point declaration).
+	BlackPoints int32 `protobuf:"varint,4,opt,name=black_points,json=blackPoints,proto3" json:"black_points,omitempty"`
+	// points of all the pieces (24-point rule).
+	BlackTotal   int32 `protobuf:"varint,5,opt,name=black_total,json=blackTotal,proto3" json:"black_total,omitempty"`
+	WhiteEntered bool  `protobuf:"varint,6,opt,name=white_entered,json=whiteEntered,proto3" json:"white_entered,omitempty"`
+	WhitePieces  int32 `protobuf:"varint,7,opt,name=white_pieces,json=whitePieces,proto3" json:"white_pieces,omitempty"`
+	WhitePoints  int32 `protobuf:"varint,8,opt,name=white_points,json=whitePoints,proto3" json:"white_points,omitempty"`
+	WhiteTotal   int32 `protobuf:"varint,9,opt,name=white_total,json=whiteTotal,proto3" json:"white_total,omitempty"`
+	// the side to move can declare the win by the 27-point rule.
+	Declarable bool `protobuf:"varint,10,opt,name=declarable,proto3" json:"declarable,omitempty"`
+}
+
+func (x *GetKifuResponse_ImpassePoints) Reset() {
+	*x = GetKifuResponse_ImpassePoints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuResponse_ImpassePoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuResponse_ImpassePoints) ProtoMessage() {}
+
+func (x *GetKifuResponse_ImpassePoints) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuResponse_ImpassePoints.ProtoReflect.Descriptor instead.
+func (*GetKifuResponse_ImpassePoints) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{11, 4}
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetBlackEntered() bool {
+	if x != nil {
+		return x.BlackEntered
+	}
+	return false
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetBlackPieces() int32 {
+	if x != nil {
+		return x.BlackPieces
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetBlackPoints() int32 {
+	if x != nil {
+		return x.BlackPoints
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetBlackTotal() int32 {
+	if x != nil {
+		return x.BlackTotal
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetWhiteEntered() bool {
+	if x != nil {
+		return x.WhiteEntered
+	}
+	return false
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetWhitePieces() int32 {
+	if x != nil {
+		return x.WhitePieces
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetWhitePoints() int32 {
+	if x != nil {
+		return x.WhitePoints
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetWhiteTotal() int32 {
+	if x != nil {
+		return x.WhiteTotal
+	}
+	return 0
+}
+
+func (x *GetKifuResponse_ImpassePoints) GetDeclarable() bool {
+	if x != nil {
+		return x.Declarable
+	}
+	return false
+}
+
+type GetSamePositionsResponse_Step struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq            int32             `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Src            *Pos              `protobuf:"bytes,2,opt,name=src,proto3" json:"src,omitempty"`
+	Dst            *Pos              `protobuf:"bytes,3,opt,name=dst,proto3" json:"dst,omitempty"`
+	Piece          Piece_Id          `protobuf:"varint,4,opt,name=piece,proto3,enum=kifu.Piece_Id" json:"piece,omitempty"`
+	Promoted       bool              `protobuf:"varint,5,opt,name=promoted,proto3" json:"promoted,omitempty"`
+	FinishedStatus FinishedStatus_Id `protobuf:"varint,6,opt,name=finished_status,json=finishedStatus,proto3,enum=kifu.FinishedStatus_Id" json:"finished_status,omitempty"`
+}
+
+func (x *GetSamePositionsResponse_Step) Reset() {
+	*x = GetSamePositionsResponse_Step{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSamePositionsResponse_Step) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSamePositionsResponse_Step) ProtoMessage() {}
+
+func (x *GetSamePositionsResponse_Step) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSamePositionsResponse_Step.ProtoReflect.Descriptor instead.
+func (*GetSamePositionsResponse_Step) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *GetSamePositionsResponse_Step) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GetSamePositionsResponse_Step) GetSrc() *Pos {
+	if x != nil {
+		return x.Src
+	}
+	return nil
+}
+
+func (x *GetSamePositionsResponse_Step) GetDst() *Pos {
+	if x != nil {
+		return x.Dst
+	}
+	return nil
+}
+
+func (x *GetSamePositionsResponse_Step) GetPiece() Piece_Id {
+	if x != nil {
+		return x.Piece
+	}
+	return Piece_NULL
+}
+
+func (x *GetSamePositionsResponse_Step) GetPromoted() bool {
+	if x != nil {
+		return x.Promoted
+	}
+	return false
+}
+
+func (x *GetSamePositionsResponse_Step) GetFinishedStatus() FinishedStatus_Id {
+	if x != nil {
+		return x.FinishedStatus
+	}
+	return FinishedStatus_NOT_FINISHED
+}
+
+type GetSamePositionsResponse_Kifu struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string                           `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KifuId string                           `protobuf:"bytes,2,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Seq    int32                            `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	Steps  []*GetSamePositionsResponse_Step `protobuf:"bytes,4,rep,name=steps,proto3" json:"steps,omitempty"`
+	// the position of the kifu is mirrored / color-flipped from the requested position.
+	// steps are as recorded in the kifu, continuations are converted to the requested position.
+	Mirrored     bool `protobuf:"varint,5,opt,name=mirrored,proto3" json:"mirrored,omitempty"`
+	ColorFlipped bool `protobuf:"varint,6,opt,name=color_flipped,json=colorFlipped,proto3" json:"color_flipped,omitempty"`
+}
+
+func (x *GetSamePositionsResponse_Kifu) Reset() {
+	*x = GetSamePositionsResponse_Kifu{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSamePositionsResponse_Kifu) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSamePositionsResponse_Kifu) ProtoMessage() {}
+
+func (x *GetSamePositionsResponse_Kifu) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSamePositionsResponse_Kifu.ProtoReflect.Descriptor instead.
+func (*GetSamePositionsResponse_Kifu) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{14, 1}
+}
+
+func (x *GetSamePositionsResponse_Kifu) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSamePositionsResponse_Kifu) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *GetSamePositionsResponse_Kifu) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GetSamePositionsResponse_Kifu) GetSteps() []*GetSamePositionsResponse_Step {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *GetSamePositionsResponse_Kifu) GetMirrored() bool {
+	if x != nil {
+		return x.Mirrored
+	}
+	return false
+}
+
+func (x *GetSamePositionsResponse_Kifu) GetColorFlipped() bool {
+	if x != nil {
+		return x.ColorFlipped
+	}
+	return false
+}
+
+// next moves from the position, most played first.
+type GetSamePositionsResponse_Continuation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Move    *GetSamePositionsResponse_Step `protobuf:"bytes,1,opt,name=move,proto3" json:"move,omitempty"`
+	UserIds []string                       `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Count   int32                          `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *GetSamePositionsResponse_Continuation) Reset() {
+	*x = GetSamePositionsResponse_Continuation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSamePositionsResponse_Continuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSamePositionsResponse_Continuation) ProtoMessage() {}
+
+func (x *GetSamePositionsResponse_Continuation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSamePositionsResponse_Continuation.ProtoReflect.Descriptor instead.
+func (*GetSamePositionsResponse_Continuation) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{14, 2}
+}
+
+func (x *GetSamePositionsResponse_Continuation) GetMove() *GetSamePositionsResponse_Step {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+func (x *GetSamePositionsResponse_Continuation) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *GetSamePositionsResponse_Continuation) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListKifuRevisionsResponse_Change struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// e.g. game_name, players, other_fields.{name}, step_notes
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// set when field is step_notes.
+	Seq      int32  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	OldValue string `protobuf:"bytes,3,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue string `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+}
+
+func (x *ListKifuRevisionsResponse_Change) Reset() {
+	*x = ListKifuRevisionsResponse_Change{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListKifuRevisionsResponse_Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKifuRevisionsResponse_Change) ProtoMessage() {}
+
+func (x *ListKifuRevisionsResponse_Change) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKifuRevisionsResponse_Change.ProtoReflect.Descriptor instead.
+func (*ListKifuRevisionsResponse_Change) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{16, 0}
+}
+
+func (x *ListKifuRevisionsResponse_Change) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ListKifuRevisionsResponse_Change) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ListKifuRevisionsResponse_Change) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *ListKifuRevisionsResponse_Change) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+type ListKifuRevisionsResponse_Revision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version   int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	RevisedTs int64 `protobuf:"varint,2,opt,name=revised_ts,json=revisedTs,proto3" json:"revised_ts,omitempty"`
+	// changes from this revision to the next newer revision (or the current kifu).
+	Changes []*ListKifuRevisionsResponse_Change `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *ListKifuRevisionsResponse_Revision) Reset() {
+	*x = ListKifuRevisionsResponse_Revision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListKifuRevisionsResponse_Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKifuRevisionsResponse_Revision) ProtoMessage() {}
+
+func (x *ListKifuRevisionsResponse_Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKifuRevisionsResponse_Revision.ProtoReflect.Descriptor instead.
+func (*ListKifuRevisionsResponse_Revision) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{16, 1}
+}
+
+func (x *ListKifuRevisionsResponse_Revision) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ListKifuRevisionsResponse_Revision) GetRevisedTs() int64 {
+	if x != nil {
+		return x.RevisedTs
+	}
+	return 0
+}
+
+func (x *ListKifuRevisionsResponse_Revision) GetChanges() []*ListKifuRevisionsResponse_Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type ListTrashResponse_Kifu struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId        string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KifuId        string   `protobuf:"bytes,2,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	StartTs       int64    `protobuf:"varint,3,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
+	Handicap      string   `protobuf:"bytes,4,opt,name=handicap,proto3" json:"handicap,omitempty"`
+	GameName      string   `protobuf:"bytes,5,opt,name=game_name,json=gameName,proto3" json:"game_name,omitempty"`
+	FirstPlayers  []string `protobuf:"bytes,6,rep,name=first_players,json=firstPlayers,proto3" json:"first_players,omitempty"`
+	SecondPlayers []string `protobuf:"bytes,7,rep,name=second_players,json=secondPlayers,proto3" json:"second_players,omitempty"`
+	Note          string   `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	Version       int64    `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	TrashedTs     int64    `protobuf:"varint,10,opt,name=trashed_ts,json=trashedTs,proto3" json:"trashed_ts,omitempty"`
+	// the kifu is purged after this time.
+	PurgeTs int64 `protobuf:"varint,11,opt,name=purge_ts,json=purgeTs,proto3" json:"purge_ts,omitempty"`
+}
+
+func (x *ListTrashResponse_Kifu) Reset() {
+	*x = ListTrashResponse_Kifu{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrashResponse_Kifu) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse_Kifu) ProtoMessage() {}
+
+func (x *ListTrashResponse_Kifu) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse_Kifu.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse_Kifu) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *ListTrashResponse_Kifu) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTrashResponse_Kifu) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *ListTrashResponse_Kifu) GetStartTs() int64 {
+	if x != nil {
+		return x.StartTs
+	}
+	return 0
+}
+
+func (x *ListTrashResponse_Kifu) GetHandicap() string {
+	if x != nil {
+		return x.Handicap
+	}
+	return ""
+}
+
+func (x *ListTrashResponse_Kifu) GetGameName() string {
+	if x != nil {
+		return x.GameName
+	}
+	return ""
+}
+
+func (x *ListTrashResponse_Kifu) GetFirstPlayers() []string {
+	if x != nil {
+		return x.FirstPlayers
+	}
+	return nil
+}
+
+func (x *ListTrashResponse_Kifu) GetSecondPlayers() []string {
+	if x != nil {
+		return x.SecondPlayers
+	}
+	return nil
+}
+
+func (x *ListTrashResponse_Kifu) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ListTrashResponse_Kifu) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ListTrashResponse_Kifu) GetTrashedTs() int64 {
+	if x != nil {
+		return x.TrashedTs
+	}
+	return 0
+}
+
+func (x *ListTrashResponse_Kifu) GetPurgeTs() int64 {
+	if x != nil {
+		return x.PurgeTs
+	}
+	return 0
+}
+
+// x is the file and y is the rank, both 1-9.
+// piece is a SFEN piece, uppercase for black and lowercase for white. e.g. "K", "+b".
+// empty piece requires the square to be empty.
+type SearchPatternRequest_Square struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X     int32  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y     int32  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Piece string `protobuf:"bytes,3,opt,name=piece,proto3" json:"piece,omitempty"`
+}
+
+func (x *SearchPatternRequest_Square) Reset() {
+	*x = SearchPatternRequest_Square{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPatternRequest_Square) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPatternRequest_Square) ProtoMessage() {}
+
+func (x *SearchPatternRequest_Square) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPatternRequest_Square.ProtoReflect.Descriptor instead.
+func (*SearchPatternRequest_Square) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{51, 0}
+}
+
+func (x *SearchPatternRequest_Square) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *SearchPatternRequest_Square) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *SearchPatternRequest_Square) GetPiece() string {
+	if x != nil {
+		return x.Piece
+	}
+	return ""
+}
+
+// minimum number of pieces in hand. piece is a SFEN piece. e.g. "P", "b".
+type SearchPatternRequest_Hand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Piece string `protobuf:"bytes,1,opt,name=piece,proto3" json:"piece,omitempty"`
+	Min   int32  `protobuf:"varint,2,opt,name=min,proto3" json:"min,omitempty"`
+}
+
+func (x *SearchPatternRequest_Hand) Reset() {
+	*x = SearchPatternRequest_Hand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPatternRequest_Hand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPatternRequest_Hand) ProtoMessage() {}
+
+func (x *SearchPatternRequest_Hand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPatternRequest_Hand.ProtoReflect.Descriptor instead.
+func (*SearchPatternRequest_Hand) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{51, 1}
+}
+
+func (x *SearchPatternRequest_Hand) GetPiece() string {
+	if x != nil {
+		return x.Piece
+	}
+	return ""
+}
+
+func (x *SearchPatternRequest_Hand) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+type SearchPatternResponse_Match struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	// the first step which matches the pattern.
+	Seq           int32    `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	ColorFlipped  bool     `protobuf:"varint,3,opt,name=color_flipped,json=colorFlipped,proto3" json:"color_flipped,omitempty"`
+	StartTs       int64    `protobuf:"varint,4,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
+	GameName      string   `protobuf:"bytes,5,opt,name=game_name,json=gameName,proto3" json:"game_name,omitempty"`
+	FirstPlayers  []string `protobuf:"bytes,6,rep,name=first_players,json=firstPlayers,proto3" json:"first_players,omitempty"`
+	SecondPlayers []string `protobuf:"bytes,7,rep,name=second_players,json=secondPlayers,proto3" json:"second_players,omitempty"`
+}
+
+func (x *SearchPatternResponse_Match) Reset() {
+	*x = SearchPatternResponse_Match{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPatternResponse_Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPatternResponse_Match) ProtoMessage() {}
+
+func (x *SearchPatternResponse_Match) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPatternResponse_Match.ProtoReflect.Descriptor instead.
+func (*SearchPatternResponse_Match) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{52, 0}
+}
+
+func (x *SearchPatternResponse_Match) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *SearchPatternResponse_Match) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SearchPatternResponse_Match) GetColorFlipped() bool {
+	if x != nil {
+		return x.ColorFlipped
+	}
+	return false
+}
+
+func (x *SearchPatternResponse_Match) GetStartTs() int64 {
+	if x != nil {
+		return x.StartTs
+	}
+	return 0
+}
+
+func (x *SearchPatternResponse_Match) GetGameName() string {
+	if x != nil {
+		return x.GameName
+	}
+	return ""
+}
+
+func (x *SearchPatternResponse_Match) GetFirstPlayers() []string {
+	if x != nil {
+		return x.FirstPlayers
+	}
+	return nil
+}
+
+func (x *SearchPatternResponse_Match) GetSecondPlayers() []string {
+	if x != nil {
+		return x.SecondPlayers
+	}
+	return nil
+}
+
+type GetKifuStatsResponse_Strategy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BlackCount int32  `protobuf:"varint,2,opt,name=black_count,json=blackCount,proto3" json:"black_count,omitempty"`
+	WhiteCount int32  `protobuf:"varint,3,opt,name=white_count,json=whiteCount,proto3" json:"white_count,omitempty"`
+	// count of the kifus whose game has the strategy, e.g. 角換わり.
+	GameCount int32 `protobuf:"varint,4,opt,name=game_count,json=gameCount,proto3" json:"game_count,omitempty"`
+}
+
+func (x *GetKifuStatsResponse_Strategy) Reset() {
+	*x = GetKifuStatsResponse_Strategy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKifuStatsResponse_Strategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKifuStatsResponse_Strategy) ProtoMessage() {}
+
+func (x *GetKifuStatsResponse_Strategy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKifuStatsResponse_Strategy.ProtoReflect.Descriptor instead.
+func (*GetKifuStatsResponse_Strategy) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{54, 0}
+}
+
+func (x *GetKifuStatsResponse_Strategy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetKifuStatsResponse_Strategy) GetBlackCount() int32 {
+	if x != nil {
+		return x.BlackCount
+	}
+	return 0
+}
+
+func (x *GetKifuStatsResponse_Strategy) GetWhiteCount() int32 {
+	if x != nil {
+		return x.WhiteCount
+	}
+	return 0
+}
+
+func (x *GetKifuStatsResponse_Strategy) GetGameCount() int32 {
+	if x != nil {
+		return x.GameCount
+	}
+	return 0
+}
+
+type FindMissedMatesResponse_MissedMate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// seq of the step whose position had the mate.
+	Seq int32 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// SFEN position.
+	Position string `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	// mating sequence in USI.
+	MateMoves []string `protobuf:"bytes,3,rep,name=mate_moves,json=mateMoves,proto3" json:"mate_moves,omitempty"`
+	// the move actually played in USI. empty if the game ended.
+	PlayedMove string `protobuf:"bytes,4,opt,name=played_move,json=playedMove,proto3" json:"played_move,omitempty"`
+}
+
+func (x *FindMissedMatesResponse_MissedMate) Reset() {
+	*x = FindMissedMatesResponse_MissedMate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindMissedMatesResponse_MissedMate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMissedMatesResponse_MissedMate) ProtoMessage() {}
+
+func (x *FindMissedMatesResponse_MissedMate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMissedMatesResponse_MissedMate.ProtoReflect.Descriptor instead.
+func (*FindMissedMatesResponse_MissedMate) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{56, 0}
+}
+
+func (x *FindMissedMatesResponse_MissedMate) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *FindMissedMatesResponse_MissedMate) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
+func (x *FindMissedMatesResponse_MissedMate) GetMateMoves() []string {
+	if x != nil {
+		return x.MateMoves
+	}
+	return nil
+}
+
+func (x *FindMissedMatesResponse_MissedMate) GetPlayedMove() string {
+	if x != nil {
+		return x.PlayedMove
+	}
+	return ""
+}
+
+type RenderPositionRequest_Arrow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From *Pos `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   *Pos `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *RenderPositionRequest_Arrow) Reset() {
+	*x = RenderPositionRequest_Arrow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderPositionRequest_Arrow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPositionRequest_Arrow) ProtoMessage() {}
+
+func (x *RenderPositionRequest_Arrow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPositionRequest_Arrow.ProtoReflect.Descriptor instead.
+func (*RenderPositionRequest_Arrow) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{57, 0}
+}
+
+func (x *RenderPositionRequest_Arrow) GetFrom() *Pos {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *RenderPositionRequest_Arrow) GetTo() *Pos {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type BulkDeleteKifuResponse_Failure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *BulkDeleteKifuResponse_Failure) Reset() {
+	*x = BulkDeleteKifuResponse_Failure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkDeleteKifuResponse_Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteKifuResponse_Failure) ProtoMessage() {}
+
+func (x *BulkDeleteKifuResponse_Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteKifuResponse_Failure.ProtoReflect.Descriptor instead.
+func (*BulkDeleteKifuResponse_Failure) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{62, 0}
+}
+
+func (x *BulkDeleteKifuResponse_Failure) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *BulkDeleteKifuResponse_Failure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ReindexKifuResponse_Failure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KifuId  string `protobuf:"bytes,1,opt,name=kifu_id,json=kifuId,proto3" json:"kifu_id,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ReindexKifuResponse_Failure) Reset() {
+	*x = ReindexKifuResponse_Failure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kifu_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexKifuResponse_Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexKifuResponse_Failure) ProtoMessage() {}
+
+func (x *ReindexKifuResponse_Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kifu_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexKifuResponse_Failure.ProtoReflect.Descriptor instead.
+func (*ReindexKifuResponse_Failure) Descriptor() ([]byte, []int) {
+	return file_proto_kifu_proto_rawDescGZIP(), []int{66, 0}
+}
+
+func (x *ReindexKifuResponse_Failure) GetKifuId() string {
+	if x != nil {
+		return x.KifuId
+	}
+	return ""
+}
+
+func (x *ReindexKifuResponse_Failure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_proto_kifu_proto protoreflect.FileDescriptor
+
+var file_proto_kifu_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x04, 0x6b, 0x69, 0x66, 0x75, 0x22, 0x59, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x22, 0xe5, 0x03, 0x0a, 0x12, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x4b, 0x69,
+	0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x6b, 0x69,
+	0x66, 0x75, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x69, 0x66, 0x75,
+	0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x1a,
+	0x99, 0x03, 0x0a, 0x04, 0x4b, 0x69, 0x66, 0x75, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61,
+	0x70, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f,
+	0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x29, 0x0a, 0x10,
+	0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x72,
+	0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x68, 0x69, 0x74, 0x65,
+	0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69,
+	0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x67, 0x61, 0x6d,
+	0x65, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x22, 0xfc, 0x01, 0x0a, 0x0f,
+	0x50, 0x6f, 0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x50, 0x0a,
+	0x10, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50,
+	0x6f, 0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f,
+	0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
+	0x49, 0x0a, 0x0f, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45,
+	0x54, 0x55, 0x52, 0x4e, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12,
+	0x09, 0x0a, 0x05, 0x41, 0x4c, 0x49, 0x41, 0x53, 0x10, 0x03, 0x22, 0x65, 0x0a, 0x10, 0x50, 0x6f,
+	0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0x5a, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x66,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x6f, 0x66, 0x74, 0x22, 0x2e, 0x0a,
+	0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x48, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6e, 0x6b,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x69,
+	0x6e, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x21, 0x0a, 0x03, 0x50, 0x6f, 0x73, 0x12, 0x0c,
+	0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x22, 0xaa, 0x01, 0x0a, 0x05, 0x50,
+	0x69, 0x65, 0x63, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x02, 0x49, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x4e,
+	0x55, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x59, 0x4f, 0x4b, 0x55, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x48, 0x49, 0x53, 0x48, 0x41, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x52,
+	0x59, 0x55, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x4b, 0x41, 0x4b, 0x55, 0x10, 0x04, 0x12, 0x07,
+	0x0a, 0x03, 0x55, 0x4d, 0x41, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x49, 0x4e, 0x10, 0x06,
+	0x12, 0x07, 0x0a, 0x03, 0x47, 0x49, 0x4e, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x41, 0x52,
+	0x49, 0x5f, 0x47, 0x49, 0x4e, 0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45, 0x49, 0x10, 0x09,
+	0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x41, 0x52, 0x49, 0x5f, 0x4b, 0x45, 0x49, 0x10, 0x0a, 0x12, 0x08,
+	0x0a, 0x04, 0x4b, 0x59, 0x4f, 0x55, 0x10, 0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x41, 0x52, 0x49,
+	0x5f, 0x4b, 0x59, 0x4f, 0x55, 0x10, 0x0c, 0x12, 0x06, 0x0a, 0x02, 0x46, 0x55, 0x10, 0x0d, 0x12,
+	0x06, 0x0a, 0x02, 0x54, 0x4f, 0x10, 0x0e, 0x22, 0xb7, 0x01, 0x0a, 0x0e, 0x46, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x02, 0x49,
+	0x64, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x01,
+	0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x10, 0x02, 0x12,
+	0x08, 0x0a, 0x04, 0x44, 0x52, 0x41, 0x57, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x50,
+	0x45, 0x54, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x52, 0x41, 0x57, 0x10, 0x04, 0x12, 0x0d,
+	0x0a, 0x09, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x4d, 0x41, 0x54, 0x45, 0x10, 0x05, 0x12, 0x13, 0x0a,
+	0x0f, 0x4f, 0x56, 0x45, 0x52, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54,
+	0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x4f, 0x55, 0x4c, 0x5f, 0x4c, 0x4f, 0x53, 0x53, 0x10,
+	0x07, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f, 0x55, 0x4c, 0x5f, 0x57, 0x49, 0x4e, 0x10, 0x08, 0x12,
+	0x10, 0x0a, 0x0c, 0x4e, 0x59, 0x55, 0x47, 0x59, 0x4f, 0x4b, 0x55, 0x5f, 0x57, 0x49, 0x4e, 0x10,
+	0x09, 0x22, 0x31, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0xa7, 0x0f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x6e, 0x64, 0x54, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x6d,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b,
+	0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x43, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x0d,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a,
+	0x0c, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x0b, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x66, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x66, 0x65,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x73, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x73,
+	0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65,
+	0x70, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x06,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6b,
+	0x69, 0x66, 0x75, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x29, 0x0a, 0x10, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x69, 0x65, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x6c, 0x61, 0x63,
+	0x6b, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x77,
+	0x68, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x18,
+	0x14, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0e, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x12,
+	0x36, 0x0a, 0x07, 0x63, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x52, 0x07,
+	0x63, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x65, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x69,
+	0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x52, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x4a, 0x0a, 0x0e, 0x69, 0x6d, 0x70, 0x61, 0x73, 0x73, 0x65,
+	0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x73, 0x73, 0x65, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x52, 0x0d, 0x69, 0x6d, 0x70, 0x61, 0x73, 0x73, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6d, 0x70, 0x61, 0x73, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6d, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0x30, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x1a, 0xfc, 0x02, 0x0a, 0x04, 0x53,
+	0x74, 0x65, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1b, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x1b,
+	0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69,
+	0x66, 0x75, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x70,
+	0x69, 0x65, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6b, 0x69, 0x66,
+	0x75, 0x2e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x49, 0x64, 0x52, 0x05, 0x70, 0x69, 0x65, 0x63,
+	0x65, 0x12, 0x40, 0x0a, 0x0f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6b, 0x69, 0x66,
+	0x75, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x2e, 0x49, 0x64, 0x52, 0x0e, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x12,
+	0x2a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0e, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x49,
+	0x64, 0x52, 0x08, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x65, 0x63,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x68, 0x69, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x68, 0x69, 0x6e, 0x6b, 0x69, 0x6e, 0x67,
+	0x53, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x1a, 0x46, 0x0a, 0x06, 0x43, 0x61, 0x73,
+	0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x1a, 0x5f, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x1a, 0xd9, 0x02, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x61, 0x73, 0x73, 0x65, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x62,
+	0x6c, 0x61, 0x63, 0x6b, 0x45, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62,
+	0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x50, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x77, 0x68, 0x69, 0x74, 0x65,
+	0x45, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x68, 0x69, 0x74, 0x65,
+	0x5f, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x77,
+	0x68, 0x69, 0x74, 0x65, 0x50, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x68,
+	0x69, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x77, 0x68, 0x69, 0x74, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x77, 0x68, 0x69, 0x74, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1e,
+	0x0a, 0x0a, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x34,
+	0x0a, 0x05, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x22, 0xd6, 0x02, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x65,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x65,
+	0x70, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6b, 0x69,
+	0x66, 0x75, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07,
+	0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x39, 0x0a, 0x05, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6b, 0x69, 0x66,
+	0x75, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x66, 0x6c, 0x69, 0x70, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x46, 0x6c, 0x69, 0x70, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x22, 0x22, 0x0a, 0x05, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x00, 0x12, 0x0e, 0x0a,
+	0x0a, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x01, 0x22, 0xe0, 0x05,
+	0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x05, 0x6b, 0x69, 0x66, 0x75,
+	0x73, 0x12, 0x51, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xd6, 0x01, 0x0a, 0x04, 0x53, 0x74, 0x65, 0x70, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
+	0x1b, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b,
+	0x69, 0x66, 0x75, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x1b, 0x0a, 0x03,
+	0x64, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69, 0x66, 0x75,
+	0x2e, 0x50, 0x6f, 0x73, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x69, 0x65,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e,
+	0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x49, 0x64, 0x52, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0f, 0x66,
+	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x46, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x49, 0x64, 0x52, 0x0e, 0x66,
+	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0xc6, 0x01,
+	0x0a, 0x04, 0x4b, 0x69, 0x66, 0x75, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x74,
+	0x65, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x69, 0x66, 0x75,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05,
+	0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x65,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x66, 0x6c, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x46,
+	0x6c, 0x69, 0x70, 0x70, 0x65, 0x64, 0x1a, 0x78, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x33, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b,
+	0x69, 0x66, 0x75, 0x49, 0x64, 0x22, 0xf1, 0x02, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x4b, 0x69,
+	0x66, 0x75, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x6a, 0x0a, 0x06, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x6c, 0x64, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x1a, 0x85, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x76, 0x69,
+	0x73, 0x65, 0x64, 0x5f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x65, 0x64, 0x54, 0x73, 0x12, 0x40, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x1a, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x37, 0x0a, 0x1b, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x12, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x8a, 0x03, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x73,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x6b, 0x69, 0x66,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x1a, 0xc0, 0x02,
+	0x0a, 0x04, 0x4b, 0x69, 0x66, 0x75, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x70, 0x12,
+	0x1b, 0x0a, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x73, 0x68, 0x65,
+	0x64, 0x5f, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x72, 0x61, 0x73,
+	0x68, 0x65, 0x64, 0x54, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x75, 0x72, 0x67, 0x65, 0x5f, 0x74,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x75, 0x72, 0x67, 0x65, 0x54, 0x73,
+	0x22, 0x47, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x13, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x57, 0x0a, 0x0e, 0x41, 0x64,
+	0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b,
+	0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x2b, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x5a, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2e, 0x0a, 0x12,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x74, 0x0a, 0x15, 0x4c,
+	0x69, 0x73, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4b, 0x69,
+	0x66, 0x75, 0x52, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x72, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2d, 0x0a, 0x11, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4b, 0x69,
+	0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x12, 0x55, 0x6e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x4b,
+	0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69,
+	0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66,
+	0x75, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x13, 0x55, 0x6e, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4b, 0x69, 0x66, 0x75, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x51, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4b, 0x69, 0x66,
+	0x75, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4b, 0x69, 0x66, 0x75, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x32, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x69, 0x66, 0x75,
+	0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4f, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x26, 0x0a, 0x0f, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x47, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22, 0xed, 0x01, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x28, 0x0a, 0x10, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x76,
+	0x69, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x18,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x65, 0x61, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x76, 0x69,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x35, 0x0a, 0x19, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x1b, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x54, 0x65, 0x61, 0x6d,
+	0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x1c, 0x41, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x54, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2b, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x54, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x65, 0x61, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x4a, 0x6f, 0x69, 0x6e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x18, 0x0a, 0x16, 0x4a, 0x6f, 0x69, 0x6e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x0a, 0x16, 0x4c, 0x65, 0x61,
+	0x76, 0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe8,
+	0x02, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x07, 0x73, 0x71, 0x75, 0x61, 0x72,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x07, 0x73, 0x71, 0x75,
+	0x61, 0x72, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x05, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x52, 0x05, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65,
+	0x69, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x65, 0x69, 0x74, 0x68, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x3a, 0x0a,
+	0x06, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x01, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x1a, 0x2e, 0x0a, 0x04, 0x48, 0x61, 0x6e,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x22, 0xda, 0x02, 0x0a, 0x15, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0xdb, 0x01, 0x0a, 0x05, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x66, 0x6c, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x46, 0x6c, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0x31, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66,
+	0x75, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x22, 0xf2, 0x01, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x4b, 0x69, 0x66, 0x75, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x43, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b,
+	0x69, 0x66, 0x75, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x69, 0x66, 0x75, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x79, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x69, 0x65, 0x73, 0x1a, 0x7f, 0x0a,
+	0x08, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x77, 0x68, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4a,
+	0x0a, 0x16, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x4d, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x50, 0x6c, 0x79, 0x22, 0xe2, 0x01, 0x0a, 0x17, 0x46,
+	0x69, 0x6e, 0x64, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x4d, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64,
+	0x5f, 0x6d, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b,
+	0x69, 0x66, 0x75, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x4d, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x69, 0x73, 0x73,
+	0x65, 0x64, 0x4d, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x4d, 0x61,
+	0x74, 0x65, 0x73, 0x1a, 0x7a, 0x0a, 0x0a, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x4d, 0x61, 0x74,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x73, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x22,
+	0x94, 0x02, 0x0a, 0x15, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66,
+	0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75,
+	0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x73, 0x65, 0x71, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x39, 0x0a, 0x06, 0x61,
+	0x72, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x69,
+	0x66, 0x75, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x52, 0x06,
+	0x61, 0x72, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x65, 0x6c, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x1a, 0x41, 0x0a, 0x05, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x12, 0x1d, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69, 0x66,
+	0x75, 0x2e, 0x50, 0x6f, 0x73, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x19, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x50,
+	0x6f, 0x73, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x16, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xec, 0x01, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x69, 0x6e, 0x6b, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x5f, 0x73,
+	0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x71,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x6c,
+	0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x65,
+	0x6c, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x68, 0x0a, 0x17, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x41, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73,
+	0x22, 0x5f, 0x0a, 0x15, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x69,
+	0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x69, 0x66,
+	0x75, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x69, 0x66,
+	0x75, 0x49, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x6f, 0x66, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x6f, 0x66,
+	0x74, 0x22, 0xc2, 0x01, 0x0a, 0x16, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4b,
+	0x69, 0x66, 0x75, 0x49, 0x64, 0x73, 0x12, 0x40, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x08,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x1a, 0x3c, 0x0a, 0x07, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4b, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x69, 0x66, 0x75, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4b, 0x69,
+	0x66, 0x75, 0x52, 0x05, 0x6b, 0x69, 0x66, 0x75, 0x73, 0x22, 0x48, 0x0a, 0x12, 0x52, 0x65, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0xc0, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b,
+	0x69, 0x66, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x72,
+	0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x5f, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x65, 0x64, 0x4b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x08, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x69,
+	0x66, 0x75, 0x2e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x69, 0x66, 0x75, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x08,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x1a, 0x3c, 0x0a, 0x07, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x69, 0x66, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x69, 0x66, 0x75, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x0c, 0x5a, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x6b, 0x69, 0x66, 0x75, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_kifu_proto_rawDescOnce sync.Once
+	file_proto_kifu_proto_rawDescData = file_proto_kifu_proto_rawDesc
+)
+
+func file_proto_kifu_proto_rawDescGZIP() []byte {
+	file_proto_kifu_proto_rawDescOnce.Do(func() {
+		file_proto_kifu_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_kifu_proto_rawDescData)
+	})
+	return file_proto_kifu_proto_rawDescData
+}
+
+var file_proto_kifu_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_kifu_proto_msgTypes = make([]protoimpl.MessageInfo, 87)
+var file_proto_kifu_proto_goTypes = []interface{}{
+	(PostKifuRequest_DuplicatePolicy)(0),          // 0: kifu.PostKifuRequest.DuplicatePolicy
+	(Piece_Id)(0),                                 // 1: kifu.Piece.Id
+	(FinishedStatus_Id)(0),                        // 2: kifu.FinishedStatus.Id
+	(GetSamePositionsRequest_Match)(0),            // 3: kifu.GetSamePositionsRequest.Match
+	(*RecentKifuRequest)(nil),                     // 4: kifu.RecentKifuRequest
+	(*RecentKifuResponse)(nil),                    // 5: kifu.RecentKifuResponse
+	(*PostKifuRequest)(nil),                       // 6: kifu.PostKifuRequest
+	(*PostKifuResponse)(nil),                      // 7: kifu.PostKifuResponse
+	(*DeleteKifuRequest)(nil),                     // 8: kifu.DeleteKifuRequest
+	(*DeleteKifuResponse)(nil),                    // 9: kifu.DeleteKifuResponse
+	(*GetKifuRequest)(nil),                        // 10: kifu.GetKifuRequest
+	(*Pos)(nil),                                   // 11: kifu.Pos
+	(*Piece)(nil),                                 // 12: kifu.Piece
+	(*FinishedStatus)(nil),                        // 13: kifu.FinishedStatus
+	(*Value)(nil),                                 // 14: kifu.Value
+	(*GetKifuResponse)(nil),                       // 15: kifu.GetKifuResponse
+	(*Share)(nil),                                 // 16: kifu.Share
+	(*GetSamePositionsRequest)(nil),               // 17: kifu.GetSamePositionsRequest
+	(*GetSamePositionsResponse)(nil),              // 18: kifu.GetSamePositionsResponse
+	(*ListKifuRevisionsRequest)(nil),              // 19: kifu.ListKifuRevisionsRequest
+	(*ListKifuRevisionsResponse)(nil),             // 20: kifu.ListKifuRevisionsResponse
+	(*RestoreKifuRevisionRequest)(nil),            // 21: kifu.RestoreKifuRevisionRequest
+	(*RestoreKifuRevisionResponse)(nil),           // 22: kifu.RestoreKifuRevisionResponse
+	(*ListTrashRequest)(nil),                      // 23: kifu.ListTrashRequest
+	(*ListTrashResponse)(nil),                     // 24: kifu.ListTrashResponse
+	(*RestoreKifuRequest)(nil),                    // 25: kifu.RestoreKifuRequest
+	(*RestoreKifuResponse)(nil),                   // 26: kifu.RestoreKifuResponse
+	(*AddTagsRequest)(nil),                        // 27: kifu.AddTagsRequest
+	(*AddTagsResponse)(nil),                       // 28: kifu.AddTagsResponse
+	(*RemoveTagsRequest)(nil),                     // 29: kifu.RemoveTagsRequest
+	(*RemoveTagsResponse)(nil),                    // 30: kifu.RemoveTagsResponse
+	(*ListKifuByTagRequest)(nil),                  // 31: kifu.ListKifuByTagRequest
+	(*ListKifuByTagResponse)(nil),                 // 32: kifu.ListKifuByTagResponse
+	(*ShareKifuRequest)(nil),                      // 33: kifu.ShareKifuRequest
+	(*ShareKifuResponse)(nil),                     // 34: kifu.ShareKifuResponse
+	(*UnshareKifuRequest)(nil),                    // 35: kifu.UnshareKifuRequest
+	(*UnshareKifuResponse)(nil),                   // 36: kifu.UnshareKifuResponse
+	(*CreateKifuLinkRequest)(nil),                 // 37: kifu.CreateKifuLinkRequest
+	(*CreateKifuLinkResponse)(nil),                // 38: kifu.CreateKifuLinkResponse
+	(*DeleteKifuLinkRequest)(nil),                 // 39: kifu.DeleteKifuLinkRequest
+	(*DeleteKifuLinkResponse)(nil),                // 40: kifu.DeleteKifuLinkResponse
+	(*CreateTeamRequest)(nil),                     // 41: kifu.CreateTeamRequest
+	(*CreateTeamResponse)(nil),                    // 42: kifu.CreateTeamResponse
+	(*GetTeamRequest)(nil),                        // 43: kifu.GetTeamRequest
+	(*GetTeamResponse)(nil),                       // 44: kifu.GetTeamResponse
+	(*UpdateTeamMembersRequest)(nil),              // 45: kifu.UpdateTeamMembersRequest
+	(*UpdateTeamMembersResponse)(nil),             // 46: kifu.UpdateTeamMembersResponse
+	(*AcceptTeamInvitationRequest)(nil),           // 47: kifu.AcceptTeamInvitationRequest
+	(*AcceptTeamInvitationResponse)(nil),          // 48: kifu.AcceptTeamInvitationResponse
+	(*LeaveTeamRequest)(nil),                      // 49: kifu.LeaveTeamRequest
+	(*LeaveTeamResponse)(nil),                     // 50: kifu.LeaveTeamResponse
+	(*JoinPublicPoolRequest)(nil),                 // 51: kifu.JoinPublicPoolRequest
+	(*JoinPublicPoolResponse)(nil),                // 52: kifu.JoinPublicPoolResponse
+	(*LeavePublicPoolRequest)(nil),                // 53: kifu.LeavePublicPoolRequest
+	(*LeavePublicPoolResponse)(nil),               // 54: kifu.LeavePublicPoolResponse
+	(*SearchPatternRequest)(nil),                  // 55: kifu.SearchPatternRequest
+	(*SearchPatternResponse)(nil),                 // 56: kifu.SearchPatternResponse
+	(*GetKifuStatsRequest)(nil),                   // 57: kifu.GetKifuStatsRequest
+	(*GetKifuStatsResponse)(nil),                  // 58: kifu.GetKifuStatsResponse
+	(*FindMissedMatesRequest)(nil),                // 59: kifu.FindMissedMatesRequest
+	(*FindMissedMatesResponse)(nil),               // 60: kifu.FindMissedMatesResponse
+	(*RenderPositionRequest)(nil),                 // 61: kifu.RenderPositionRequest
+	(*RenderPositionResponse)(nil),                // 62: kifu.RenderPositionResponse
+	(*ExportAnimationRequest)(nil),                // 63: kifu.ExportAnimationRequest
+	(*ExportAnimationResponse)(nil),               // 64: kifu.ExportAnimationResponse
+	(*BulkDeleteKifuRequest)(nil),                 // 65: kifu.BulkDeleteKifuRequest
+	(*BulkDeleteKifuResponse)(nil),                // 66: kifu.BulkDeleteKifuResponse
+	(*ListUserKifuRequest)(nil),                   // 67: kifu.ListUserKifuRequest
+	(*ListUserKifuResponse)(nil),                  // 68: kifu.ListUserKifuResponse
+	(*ReindexKifuRequest)(nil),                    // 69: kifu.ReindexKifuRequest
+	(*ReindexKifuResponse)(nil),                   // 70: kifu.ReindexKifuResponse
+	(*RecentKifuResponse_Kifu)(nil),               // 71: kifu.RecentKifuResponse.Kifu
+	(*GetKifuResponse_Player)(nil),                // 72: kifu.GetKifuResponse.Player
+	(*GetKifuResponse_Step)(nil),                  // 73: kifu.GetKifuResponse.Step
+	(*GetKifuResponse_Castle)(nil),                // 74: kifu.GetKifuResponse.Castle
+	(*GetKifuResponse_Repetition)(nil),            // 75: kifu.GetKifuResponse.Repetition
+	(*GetKifuResponse_ImpassePoints)(nil),         // 76: kifu.GetKifuResponse.ImpassePoints
+	(*GetSamePositionsResponse_Step)(nil),         // 77: kifu.GetSamePositionsResponse.Step
+	(*GetSamePositionsResponse_Kifu)(nil),         // 78: kifu.GetSamePositionsResponse.Kifu
+	(*GetSamePositionsResponse_Continuation)(nil), // 79: kifu.GetSamePositionsResponse.Continuation
+	(*ListKifuRevisionsResponse_Change)(nil),      // 80: kifu.ListKifuRevisionsResponse.Change
+	(*ListKifuRevisionsResponse_Revision)(nil),    // 81: kifu.ListKifuRevisionsResponse.Revision
+	(*ListTrashResponse_Kifu)(nil),                // 82: kifu.ListTrashResponse.Kifu
+	(*SearchPatternRequest_Square)(nil),           // 83: kifu.SearchPatternRequest.Square
+	(*SearchPatternRequest_Hand)(nil),             // 84: kifu.SearchPatternRequest.Hand
+	(*SearchPatternResponse_Match)(nil),           // 85: kifu.SearchPatternResponse.Match
+	(*GetKifuStatsResponse_Strategy)(nil),         // 86: kifu.GetKifuStatsResponse.Strategy
+	(*FindMissedMatesResponse_MissedMate)(nil),    // 87: kifu.FindMissedMatesResponse.MissedMate
+	(*RenderPositionRequest_Arrow)(nil),           // 88: kifu.RenderPositionRequest.Arrow
+	(*BulkDeleteKifuResponse_Failure)(nil),        // 89: kifu.BulkDeleteKifuResponse.Failure
+	(*ReindexKifuResponse_Failure)(nil),           // 90: kifu.ReindexKifuResponse.Failure
+}
+var file_proto_kifu_proto_depIdxs = []int32{
+	71, // 0: kifu.RecentKifuResponse.kifus:type_name -> kifu.RecentKifuResponse.Kifu
+	0,  // 1: kifu.PostKifuRequest.duplicate_policy:type_name -> kifu.PostKifuRequest.DuplicatePolicy
+	72, // 2: kifu.GetKifuResponse.first_players:type_name -> kifu.GetKifuResponse.Player
+	72, // 3: kifu.GetKifuResponse.second_players:type_name -> kifu.GetKifuResponse.Player
+	14, // 4: kifu.GetKifuResponse.other_fields:type_name -> kifu.Value
+	73, // 5: kifu.GetKifuResponse.steps:type_name -> kifu.GetKifuResponse.Step
+	16, // 6: kifu.GetKifuResponse.shares:type_name -> kifu.Share
+	74, // 7: kifu.GetKifuResponse.castles:type_name -> kifu.GetKifuResponse.Castle
+	75, // 8: kifu.GetKifuResponse.repetition:type_name -> kifu.GetKifuResponse.Repetition
+	76, // 9: kifu.GetKifuResponse.impasse_points:type_name -> kifu.GetKifuResponse.ImpassePoints
+	3,  // 10: kifu.GetSamePositionsRequest.match:type_name -> kifu.GetSamePositionsRequest.Match
+	78, // 11: kifu.GetSamePositionsResponse.kifus:type_name -> kifu.GetSamePositionsResponse.Kifu
+	79, // 12: kifu.GetSamePositionsResponse.continuations:type_name -> kifu.GetSamePositionsResponse.Continuation
+	81, // 13: kifu.ListKifuRevisionsResponse.revisions:type_name -> kifu.ListKifuRevisionsResponse.Revision
+	82, // 14: kifu.ListTrashResponse.kifus:type_name -> kifu.ListTrashResponse.Kifu
+	71, // 15: kifu.ListKifuByTagResponse.kifus:type_name -> kifu.RecentKifuResponse.Kifu
+	83, // 16: kifu.SearchPatternRequest.squares:type_name -> kifu.SearchPatternRequest.Square
+	84, // 17: kifu.SearchPatternRequest.hands:type_name -> kifu.SearchPatternRequest.Hand
+	85, // 18: kifu.SearchPatternResponse.matches:type_name -> kifu.SearchPatternResponse.Match
+	86, // 19: kifu.GetKifuStatsResponse.strategies:type_name -> kifu.GetKifuStatsResponse.Strategy
+	87, // 20: kifu.FindMissedMatesResponse.missed_mates:type_name -> kifu.FindMissedMatesResponse.MissedMate
+	88, // 21: kifu.RenderPositionRequest.arrows:type_name -> kifu.RenderPositionRequest.Arrow
+	89, // 22: kifu.BulkDeleteKifuResponse.failures:type_name -> kifu.BulkDeleteKifuResponse.Failure
+	71, // 23: kifu.ListUserKifuResponse.kifus:type_name -> kifu.RecentKifuResponse.Kifu
+	90, // 24: kifu.ReindexKifuResponse.failures:type_name -> kifu.ReindexKifuResponse.Failure
+	11, // 25: kifu.GetKifuResponse.Step.src:type_name -> kifu.Pos
+	11, // 26: kifu.GetKifuResponse.Step.dst:type_name -> kifu.Pos
+	1,  // 27: kifu.GetKifuResponse.Step.piece:type_name -> kifu.Piece.Id
+	2,  // 28: kifu.GetKifuResponse.Step.finished_status:type_name -> kifu.FinishedStatus.Id
+	1,  // 29: kifu.GetKifuResponse.Step.captured:type_name -> kifu.Piece.Id
+	11, // 30: kifu.GetSamePositionsResponse.Step.src:type_name -> kifu.Pos
+	11, // 31: kifu.GetSamePositionsResponse.Step.dst:type_name -> kifu.Pos
+	1,  // 32: kifu.GetSamePositionsResponse.Step.piece:type_name -> kifu.Piece.Id
+	2,  // 33: kifu.GetSamePositionsResponse.Step.finished_status:type_name -> kifu.FinishedStatus.Id
+	77, // 34: kifu.GetSamePositionsResponse.Kifu.steps:type_name -> kifu.GetSamePositionsResponse.Step
+	77, // 35: kifu.GetSamePositionsResponse.Continuation.move:type_name -> kifu.GetSamePositionsResponse.Step
+	80, // 36: kifu.ListKifuRevisionsResponse.Revision.changes:type_name -> kifu.ListKifuRevisionsResponse.Change
+	11, // 37: kifu.RenderPositionRequest.Arrow.from:type_name -> kifu.Pos
+	11, // 38: kifu.RenderPositionRequest.Arrow.to:type_name -> kifu.Pos
+	39, // [39:39] is the sub-list for method output_type
+	39, // [39:39] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_proto_kifu_proto_init() }
+func file_proto_kifu_proto_init() {
+	if File_proto_kifu_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_kifu_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecentKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecentKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pos); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Piece); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinishedStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Share); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSamePositionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSamePositionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListKifuRevisionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListKifuRevisionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreKifuRevisionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreKifuRevisionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrashRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrashResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListKifuByTagRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListKifuByTagResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnshareKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnshareKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateKifuLinkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateKifuLinkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteKifuLinkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteKifuLinkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTeamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTeamResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTeamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTeamResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTeamMembersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTeamMembersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AcceptTeamInvitationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AcceptTeamInvitationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveTeamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveTeamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1697,8 +7295,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RecentKifuResponse); i {
+		file_proto_kifu_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinPublicPoolRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1709,8 +7307,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PostKifuRequest); i {
+		file_proto_kifu_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinPublicPoolResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1721,8 +7319,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PostKifuResponse); i {
+		file_proto_kifu_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeavePublicPoolRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1733,8 +7331,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteKifuRequest); i {
+		file_proto_kifu_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeavePublicPoolResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1745,8 +7343,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteKifuResponse); i {
+		file_proto_kifu_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPatternRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1757,8 +7355,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetKifuRequest); i {
+		file_proto_kifu_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPatternResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1769,8 +7367,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pos); i {
+		file_proto_kifu_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1781,8 +7379,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Piece); i {
+		file_proto_kifu_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuStatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1793,8 +7391,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinishedStatus); i {
+		file_proto_kifu_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindMissedMatesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1805,8 +7403,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value); i {
+		file_proto_kifu_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindMissedMatesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1817,8 +7415,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetKifuResponse); i {
+		file_proto_kifu_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderPositionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1829,8 +7427,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSamePositionsRequest); i {
+		file_proto_kifu_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderPositionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1841,8 +7439,8 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSamePositionsResponse); i {
+		file_proto_kifu_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportAnimationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1853,7 +7451,91 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_kifu_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportAnimationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkDeleteKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkDeleteKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListUserKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListUserKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexKifuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexKifuResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecentKifuResponse_Kifu); i {
 			case 0:
 				return &v.state
@@ -1865,7 +7547,7 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_kifu_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetKifuResponse_Player); i {
 			case 0:
 				return &v.state
@@ -1877,7 +7559,7 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_kifu_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetKifuResponse_Step); i {
 			case 0:
 				return &v.state
@@ -1889,7 +7571,43 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_kifu_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuResponse_Castle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuResponse_Repetition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuResponse_ImpassePoints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSamePositionsResponse_Step); i {
 			case 0:
 				return &v.state
@@ -1901,7 +7619,7 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
-		file_proto_kifu_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_kifu_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSamePositionsResponse_Kifu); i {
 			case 0:
 				return &v.state
@@ -1913,14 +7631,158 @@ func file_proto_kifu_proto_init() {
 				return nil
 			}
 		}
+		file_proto_kifu_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSamePositionsResponse_Continuation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListKifuRevisionsResponse_Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListKifuRevisionsResponse_Revision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrashResponse_Kifu); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPatternRequest_Square); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPatternRequest_Hand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPatternResponse_Match); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKifuStatsResponse_Strategy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindMissedMatesResponse_MissedMate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderPositionRequest_Arrow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkDeleteKifuResponse_Failure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kifu_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexKifuResponse_Failure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_kifu_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   19,
+			NumEnums:      4,
+			NumMessages:   87,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,65 @@
+package kifu
+
+import (
+	"errors"
+)
+
+// Validate methods of the requests are called by lambdarpc.ValidationInterceptor before the methods.
+
+var (
+	errKifuIdRequired = errors.New("kifu_id is required")
+	errUserIdRequired = errors.New("user_id is required")
+	errNoTargetKifu   = errors.New("kifu_ids or user_id is required")
+	errNegativeLimit  = errors.New("limit is negative")
+	errEmptyKifuId    = errors.New("kifu_ids contains empty kifu_id")
+)
+
+func validateTargetKifu(kifuIds []string, userId string) error {
+	if len(kifuIds) == 0 && userId == "" {
+		return errNoTargetKifu
+	}
+	for _, kifuId := range kifuIds {
+		if kifuId == "" {
+			return errEmptyKifuId
+		}
+	}
+	return nil
+}
+
+func (r *ShareKifuRequest) Validate() error {
+	if r.GetKifuId() == "" {
+		return errKifuIdRequired
+	}
+	if r.GetUserId() == "" {
+		return errUserIdRequired
+	}
+	return nil
+}
+
+func (r *UnshareKifuRequest) Validate() error {
+	if r.GetKifuId() == "" {
+		return errKifuIdRequired
+	}
+	if r.GetUserId() == "" {
+		return errUserIdRequired
+	}
+	return nil
+}
+
+func (r *BulkDeleteKifuRequest) Validate() error {
+	return validateTargetKifu(r.GetKifuIds(), r.GetUserId())
+}
+
+func (r *ListUserKifuRequest) Validate() error {
+	if r.GetUserId() == "" {
+		return errUserIdRequired
+	}
+	if r.GetLimit() < 0 {
+		return errNegativeLimit
+	}
+	return nil
+}
+
+func (r *ReindexKifuRequest) Validate() error {
+	return validateTargetKifu(r.GetKifuIds(), r.GetUserId())
+}